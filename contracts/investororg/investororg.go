@@ -5,6 +5,9 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 	
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
@@ -44,6 +47,7 @@ type CampaignView struct {
 	ProjectType        string   `json:"projectType"`
 	Tags               []string `json:"tags"`
 	DurationDays       int      `json:"durationDays"`
+	FundingModel       string   `json:"fundingModel"` // ALL_OR_NOTHING, KEEP_IT_ALL
 	ValidationScore    float64  `json:"validationScore"`
 	RiskLevel          string   `json:"riskLevel"`
 	InvestorCount      int      `json:"investorCount"`
@@ -54,19 +58,21 @@ type CampaignView struct {
 // InvestmentProposal represents an investment proposal with terms
 // Step 7: Investor sends investment proposal to startup
 type InvestmentProposal struct {
-	ProposalID       string      `json:"proposalId"`
-	CampaignID       string      `json:"campaignId"`
-	StartupID        string      `json:"startupId"`
-	InvestorID       string      `json:"investorId"`
-	InvestmentAmount float64     `json:"investmentAmount"`
-	Currency         string      `json:"currency"`
-	ProposedTerms    string      `json:"proposedTerms"`
-	Milestones       []Milestone `json:"milestones"`
-	Status           string      `json:"status"` // PROPOSED, COUNTERED, ACCEPTED, REJECTED, EXPIRED
-	NegotiationRound int         `json:"negotiationRound"`
+	ProposalID       string             `json:"proposalId"`
+	CampaignID       string             `json:"campaignId"`
+	StartupID        string             `json:"startupId"`
+	InvestorID       string             `json:"investorId"`
+	InvestmentAmount float64            `json:"investmentAmount"`
+	Currency         string             `json:"currency"`
+	ProposedTerms    string             `json:"proposedTerms"`
+	NegotiatedTerms  json.RawMessage    `json:"negotiatedTerms"` // Term sheet as normalized by PlatformOrg
+	TermsHash        string             `json:"termsHash"`       // Hash of the normalized terms
+	Milestones       []Milestone        `json:"milestones"`
+	Status           string             `json:"status"` // PROPOSED, COUNTERED, ACCEPTED, REJECTED, EXPIRED
+	NegotiationRound int                `json:"negotiationRound"`
 	History          []NegotiationEntry `json:"history"`
-	CreatedAt        string      `json:"createdAt"`
-	UpdatedAt        string      `json:"updatedAt"`
+	CreatedAt        string             `json:"createdAt"`
+	UpdatedAt        string             `json:"updatedAt"`
 }
 
 // Milestone for milestone-based fund release
@@ -83,12 +89,15 @@ type Milestone struct {
 
 // NegotiationEntry tracks negotiation history
 type NegotiationEntry struct {
-	Round       int     `json:"round"`
-	Party       string  `json:"party"` // STARTUP or INVESTOR
-	Action      string  `json:"action"` // PROPOSE, COUNTER, ACCEPT, REJECT
-	Amount      float64 `json:"amount"`
-	Terms       string  `json:"terms"`
-	Timestamp   string  `json:"timestamp"`
+	Round           int             `json:"round"`
+	Party           string          `json:"party"`  // STARTUP or INVESTOR
+	Action          string          `json:"action"` // PROPOSE, COUNTER, ACCEPT, REJECT
+	Amount          float64         `json:"amount"`
+	Terms           string          `json:"terms"`
+	StructuredTerms json.RawMessage `json:"structuredTerms"`
+	TermsHash       string          `json:"termsHash"`
+	Diff            json.RawMessage `json:"diff"` // Field-level changes from the previous offer
+	Timestamp       string          `json:"timestamp"`
 }
 
 // FundingCommitment represents confirmed funding commitment
@@ -162,6 +171,50 @@ type InvestmentSummaryHash struct {
 	PublishedAt   string `json:"publishedAt"`
 }
 
+// PortfolioTotals aggregates an investor's amounts in a single currency
+type PortfolioTotals struct {
+	Currency  string  `json:"currency"`
+	Committed float64 `json:"committed"` // Live commitments (COMMITTED/ACKNOWLEDGED investments and funding commitments)
+	Confirmed float64 `json:"confirmed"` // Investments confirmed to Platform
+	Withdrawn float64 `json:"withdrawn"` // Investments withdrawn before close
+}
+
+// NegotiationState summarizes where a proposal negotiation stands
+type NegotiationState struct {
+	ProposalID       string  `json:"proposalId"`
+	Status           string  `json:"status"`
+	NegotiationRound int     `json:"negotiationRound"`
+	LastOfferAmount  float64 `json:"lastOfferAmount"`
+	LastOfferBy      string  `json:"lastOfferBy"`
+	AwaitingParty    string  `json:"awaitingParty"` // STARTUP, INVESTOR or NONE once negotiation has ended
+	UpdatedAt        string  `json:"updatedAt"`
+}
+
+// PortfolioMilestone is a milestone in the investor's schedule with its verification status
+type PortfolioMilestone struct {
+	MilestoneID        string  `json:"milestoneId"`
+	AgreementID        string  `json:"agreementId"`
+	Title              string  `json:"title"`
+	TargetDate         string  `json:"targetDate"`
+	FundPercentage     float64 `json:"fundPercentage"`
+	Status             string  `json:"status"`
+	FundsReleased      bool    `json:"fundsReleased"`
+	VerificationStatus string  `json:"verificationStatus"` // NOT_VERIFIED, APPROVED, REJECTED
+	VerificationID     string  `json:"verificationId"`
+	VerifiedAt         string  `json:"verifiedAt"`
+}
+
+// PortfolioCampaign is an investor's full position in one campaign
+type PortfolioCampaign struct {
+	CampaignID   string                      `json:"campaignId"`
+	Totals       map[string]*PortfolioTotals `json:"totals"` // Keyed by currency
+	Investments  []Investment                `json:"investments"`
+	Commitments  []FundingCommitment         `json:"commitments"`
+	Negotiations []NegotiationState          `json:"negotiations"`
+	Milestones   []PortfolioMilestone        `json:"milestones"`
+	LatestRisk   *RiskInsightResponse        `json:"latestRisk"`
+}
+
 // InitLedger initializes the InvestorOrg ledger
 func (i *InvestorContract) InitLedger(ctx contractapi.TransactionContextInterface) error {
 	fmt.Println("InvestorOrg contract initialized")
@@ -198,6 +251,26 @@ func (i *InvestorContract) ViewCampaign(
 	investorCount int,
 	status string,
 ) (string, error) {
+	// The funding model is read from PlatformOrg's published campaign on common-channel
+	publishedResponse := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{[]byte("GetPublishedCampaign"), []byte(campaignID)},
+		"common-channel",
+	)
+	if publishedResponse.Status != 200 {
+		return "", fmt.Errorf("failed to read campaign %s from PlatformOrg: %s", campaignID, publishedResponse.Message)
+	}
+	var published struct {
+		FundingModel string `json:"fundingModel"`
+	}
+	if err := json.Unmarshal(publishedResponse.Payload, &published); err != nil {
+		return "", fmt.Errorf("failed to parse campaign %s: %v", campaignID, err)
+	}
+	fundingModel := published.FundingModel
+	if fundingModel == "" {
+		fundingModel = "KEEP_IT_ALL"
+	}
+
 	// Parse tags
 	var tags []string
 	if tagsJSON != "" {
@@ -228,6 +301,7 @@ func (i *InvestorContract) ViewCampaign(
 		ProjectType:        projectType,
 		Tags:               tags,
 		DurationDays:       durationDays,
+		FundingModel:       fundingModel,
 		ValidationScore:    validationScore,
 		RiskLevel:          riskLevel,
 		InvestorCount:      investorCount,
@@ -288,6 +362,11 @@ func (i *InvestorContract) MakeInvestment(
 		return "", fmt.Errorf("investment %s already exists", investmentID)
 	}
 
+	// Campaign must be within its funding window
+	if err := checkCampaignWindow(ctx, campaignID); err != nil {
+		return "", err
+	}
+
 	// Create investment record
 	investment := Investment{
 		InvestmentID: investmentID,
@@ -419,7 +498,7 @@ func (i *InvestorContract) CreateInvestmentProposal(
 	investorID string,
 	investmentAmount float64,
 	currency string,
-	proposedTerms string,
+	proposedTerms string, // Free text, or a JSON term sheet (instrument, valuation, milestones, board rights, ...)
 	milestonesJSON string,
 ) (string, error) {
 	// Check if proposal already exists
@@ -431,6 +510,11 @@ func (i *InvestorContract) CreateInvestmentProposal(
 		return "", fmt.Errorf("proposal %s already exists", proposalID)
 	}
 
+	// Campaign must be within its funding window
+	if err := checkCampaignWindow(ctx, campaignID); err != nil {
+		return "", err
+	}
+
 	// Parse milestones
 	var milestones []Milestone
 	if milestonesJSON != "" {
@@ -439,16 +523,44 @@ func (i *InvestorContract) CreateInvestmentProposal(
 		}
 	}
 
+	// Build the structured term sheet; milestones default to the proposal's milestones
+	document, err := termsDocument(proposedTerms, nil)
+	if err != nil {
+		return "", err
+	}
+	document["amount"] = investmentAmount
+	document["currency"] = currency
+	if _, found := document["milestones"]; !found {
+		milestoneTerms := []map[string]interface{}{}
+		for _, milestone := range milestones {
+			milestoneTerms = append(milestoneTerms, map[string]interface{}{
+				"milestoneId":    milestone.MilestoneID,
+				"title":          milestone.Title,
+				"targetDate":     milestone.TargetDate,
+				"fundPercentage": milestone.FundPercentage,
+			})
+		}
+		document["milestones"] = milestoneTerms
+	}
+	terms, err := evaluateNegotiationTerms(ctx, nil, document)
+	if err != nil {
+		return "", err
+	}
+	termsHash := terms.TermsHash
+
 	now := time.Now().Format(time.RFC3339)
 
 	// Create negotiation history entry
 	historyEntry := NegotiationEntry{
-		Round:     1,
-		Party:     "INVESTOR",
-		Action:    "PROPOSE",
-		Amount:    investmentAmount,
-		Terms:     proposedTerms,
-		Timestamp: now,
+		Round:           1,
+		Party:           "INVESTOR",
+		Action:          "PROPOSE",
+		Amount:          investmentAmount,
+		Terms:           proposedTerms,
+		StructuredTerms: terms.Terms,
+		TermsHash:       termsHash,
+		Diff:            terms.Diff,
+		Timestamp:       now,
 	}
 
 	// Create proposal
@@ -460,6 +572,8 @@ func (i *InvestorContract) CreateInvestmentProposal(
 		InvestmentAmount: investmentAmount,
 		Currency:         currency,
 		ProposedTerms:    proposedTerms,
+		NegotiatedTerms:  terms.Terms,
+		TermsHash:        termsHash,
 		Milestones:       milestones,
 		Status:           "PROPOSED",
 		NegotiationRound: 1,
@@ -490,6 +604,8 @@ func (i *InvestorContract) CreateInvestmentProposal(
 		"startupId":        startupID,
 		"investorId":       investorID,
 		"investmentAmount": investmentAmount,
+		"instrument":       terms.Instrument,
+		"termsHash":        termsHash,
 		"channel":          "startup-investor-channel",
 		"action":           "INVESTMENT_PROPOSED",
 		"timestamp":        now,
@@ -498,11 +614,12 @@ func (i *InvestorContract) CreateInvestmentProposal(
 	ctx.GetStub().SetEvent("InvestmentProposed", eventJSON)
 
 	response := map[string]interface{}{
-		"message":     "Investment proposal sent to startup",
-		"proposalId":  proposalID,
-		"amount":      investmentAmount,
-		"status":      "PROPOSED",
-		"nextStep":    "Wait for startup response (ACCEPT/REJECT/COUNTER)",
+		"message":    "Investment proposal sent to startup",
+		"proposalId": proposalID,
+		"amount":     investmentAmount,
+		"termsHash":  termsHash,
+		"status":     "PROPOSED",
+		"nextStep":   "Wait for startup response (ACCEPT/REJECT/COUNTER)",
 	}
 	responseJSON, _ := json.Marshal(response)
 	return string(responseJSON), nil
@@ -518,7 +635,7 @@ func (i *InvestorContract) RespondToCounterOffer(
 	investorID string,
 	response string, // ACCEPT, REJECT, COUNTER
 	counterAmount float64,
-	counterTerms string,
+	counterTerms string, // Free text, or a JSON term sheet; free text keeps the current terms as their notes
 ) (string, error) {
 	// Retrieve proposal
 	proposalJSON, err := ctx.GetStub().GetState(proposalID)
@@ -545,16 +662,42 @@ func (i *InvestorContract) RespondToCounterOffer(
 		return "", fmt.Errorf("proposal is not in COUNTERED status, current: %s", proposal.Status)
 	}
 
+	// Rejecting is always allowed; accepting or countering needs an open campaign
+	if response != "REJECT" {
+		if err := checkCampaignWindow(ctx, proposal.CampaignID); err != nil {
+			return "", err
+		}
+	}
+
+	// Counters produce a new term sheet; ACCEPT and REJECT respond to the current one
+	terms := &evaluatedTerms{Terms: proposal.NegotiatedTerms, TermsHash: proposal.TermsHash, Diff: json.RawMessage("[]")}
+	if response == "COUNTER" {
+		document, err := termsDocument(counterTerms, proposal.NegotiatedTerms)
+		if err != nil {
+			return "", err
+		}
+		document["amount"] = counterAmount
+		document["currency"] = proposal.Currency
+		terms, err = evaluateNegotiationTerms(ctx, proposal.NegotiatedTerms, document)
+		if err != nil {
+			return "", err
+		}
+	}
+	termsHash := terms.TermsHash
+
 	now := time.Now().Format(time.RFC3339)
 
 	// Create history entry
 	historyEntry := NegotiationEntry{
-		Round:     proposal.NegotiationRound + 1,
-		Party:     "INVESTOR",
-		Action:    response,
-		Amount:    counterAmount,
-		Terms:     counterTerms,
-		Timestamp: now,
+		Round:           proposal.NegotiationRound + 1,
+		Party:           "INVESTOR",
+		Action:          response,
+		Amount:          counterAmount,
+		Terms:           counterTerms,
+		StructuredTerms: terms.Terms,
+		TermsHash:       termsHash,
+		Diff:            terms.Diff,
+		Timestamp:       now,
 	}
 	proposal.History = append(proposal.History, historyEntry)
 	proposal.NegotiationRound++
@@ -570,6 +713,8 @@ func (i *InvestorContract) RespondToCounterOffer(
 		proposal.Status = "PROPOSED" // Back to proposed for startup to respond
 		proposal.InvestmentAmount = counterAmount
 		proposal.ProposedTerms = counterTerms
+		proposal.NegotiatedTerms = terms.Terms
+		proposal.TermsHash = termsHash
 	default:
 		return "", fmt.Errorf("invalid response: %s. Must be ACCEPT, REJECT, or COUNTER", response)
 	}
@@ -584,16 +729,22 @@ func (i *InvestorContract) RespondToCounterOffer(
 		return "", err
 	}
 
+	// Keep the campaign lookup copy in sync
+	campaignProposalKey := fmt.Sprintf("PROPOSAL_%s_%s", proposal.CampaignID, proposalID)
+	ctx.GetStub().PutState(campaignProposalKey, updatedProposalJSON)
+
 	// Emit event
 	eventPayload := map[string]interface{}{
-		"proposalId":  proposalID,
-		"campaignId":  proposal.CampaignID,
-		"investorId":  investorID,
-		"response":    response,
-		"round":       proposal.NegotiationRound,
-		"channel":     "startup-investor-channel",
-		"action":      "INVESTOR_RESPONDED",
-		"timestamp":   now,
+		"proposalId": proposalID,
+		"campaignId": proposal.CampaignID,
+		"investorId": investorID,
+		"response":   response,
+		"round":      proposal.NegotiationRound,
+		"termsHash":  termsHash,
+		"changes":    terms.Changes,
+		"channel":    "startup-investor-channel",
+		"action":     "INVESTOR_RESPONDED",
+		"timestamp":  now,
 	}
 	eventJSON, _ := json.Marshal(eventPayload)
 	ctx.GetStub().SetEvent("InvestorResponded", eventJSON)
@@ -639,8 +790,18 @@ func (i *InvestorContract) AcceptAgreement(
 		return "", fmt.Errorf("proposal must be ACCEPTED before creating agreement, current: %s", proposal.Status)
 	}
 
+	// Campaign must be within its funding window
+	if err := checkCampaignWindow(ctx, proposal.CampaignID); err != nil {
+		return "", err
+	}
+
 	now := time.Now().Format(time.RFC3339)
 
+	var acceptedTerms struct {
+		Instrument json.RawMessage `json:"instrument"`
+	}
+	json.Unmarshal(proposal.NegotiatedTerms, &acceptedTerms)
+
 	// Store agreement marker (actual agreement is on Platform)
 	agreementMarker := map[string]interface{}{
 		"agreementId":      agreementID,
@@ -650,6 +811,9 @@ func (i *InvestorContract) AcceptAgreement(
 		"investorId":       investorID,
 		"investmentAmount": proposal.InvestmentAmount,
 		"currency":         proposal.Currency,
+		"instrument":       acceptedTerms.Instrument,
+		"negotiatedTerms":  proposal.NegotiatedTerms,
+		"termsHash":        proposal.TermsHash,
 		"investorAccepted": true,
 		"acceptedAt":       now,
 	}
@@ -663,6 +827,8 @@ func (i *InvestorContract) AcceptAgreement(
 		"campaignId":       proposal.CampaignID,
 		"investorId":       investorID,
 		"investmentAmount": proposal.InvestmentAmount,
+		"instrument":       acceptedTerms.Instrument,
+		"termsHash":        proposal.TermsHash,
 		"channel":          "startup-investor-channel",
 		"action":           "INVESTOR_ACCEPTED_AGREEMENT",
 		"timestamp":        now,
@@ -671,11 +837,12 @@ func (i *InvestorContract) AcceptAgreement(
 	ctx.GetStub().SetEvent("InvestorAcceptedAgreement", eventJSON)
 
 	responseData := map[string]interface{}{
-		"message":      "Agreement accepted. Platform will witness and create escrow.",
-		"agreementId":  agreementID,
-		"proposalId":   proposalID,
-		"amount":       proposal.InvestmentAmount,
-		"nextStep":     "Platform to witness agreement and hold funds in escrow",
+		"message":     "Agreement accepted. Platform will witness and create escrow.",
+		"agreementId": agreementID,
+		"proposalId":  proposalID,
+		"amount":      proposal.InvestmentAmount,
+		"termsHash":   proposal.TermsHash,
+		"nextStep":    "Publish the acceptance on common-channel (PublishAgreementAcceptance) for Platform to witness",
 	}
 	responseJSON, _ := json.Marshal(responseData)
 	return string(responseJSON), nil
@@ -697,6 +864,11 @@ func (i *InvestorContract) ConfirmFundingCommitment(
 	currency string,
 	milestonesJSON string,
 ) (string, error) {
+	// Campaign must be within its funding window
+	if err := checkCampaignWindow(ctx, campaignID); err != nil {
+		return "", err
+	}
+
 	// Parse milestones
 	var milestones []Milestone
 	if milestonesJSON != "" {
@@ -798,6 +970,13 @@ func (i *InvestorContract) VerifyMilestone(
 		return "", err
 	}
 
+	// Store by agreement and milestone for PlatformOrg release checks
+	approvalKey := fmt.Sprintf("MILESTONE_APPROVAL_%s_%s", agreementID, milestoneID)
+	err = ctx.GetStub().PutState(approvalKey, verificationJSON)
+	if err != nil {
+		return "", err
+	}
+
 	// Emit event for Platform to release funds if approved
 	eventPayload := map[string]interface{}{
 		"verificationId": verificationID,
@@ -1112,6 +1291,148 @@ func (i *InvestorContract) PublishInvestmentSummary(
 	return string(responseJSON), nil
 }
 
+// PublishAgreementAcceptance publishes the terms the investor accepted in AcceptAgreement
+// PlatformOrg witnesses the agreement only when they match the terms the startup accepted
+// Channel: common-channel
+// Endorsers: InvestorOrg, PlatformOrg
+func (i *InvestorContract) PublishAgreementAcceptance(
+	ctx contractapi.TransactionContextInterface,
+	agreementID string,
+) (string, error) {
+	// Cross-channel read of the agreement marker stored by AcceptAgreement
+	markerResponse := ctx.GetStub().InvokeChaincode(
+		"investororg",
+		[][]byte{[]byte("GetAgreementAcceptance"), []byte(agreementID)},
+		"startup-investor-channel",
+	)
+	if markerResponse.Status != 200 {
+		return "", fmt.Errorf("cross-channel query to startup-investor-channel failed: %s", markerResponse.Message)
+	}
+
+	var marker struct {
+		InvestorAccepted bool            `json:"investorAccepted"`
+		NegotiatedTerms  json.RawMessage `json:"negotiatedTerms"`
+		TermsHash        string          `json:"termsHash"`
+	}
+	if err := json.Unmarshal(markerResponse.Payload, &marker); err != nil {
+		return "", fmt.Errorf("failed to parse agreement acceptance: %v", err)
+	}
+	if !marker.InvestorAccepted {
+		return "", fmt.Errorf("agreement %s has not been accepted by the investor", agreementID)
+	}
+
+	// PlatformOrg records the accepted terms (same channel, so the write is committed)
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{[]byte("RecordAgreementAcceptance"), []byte(agreementID), []byte("INVESTOR"), marker.NegotiatedTerms, []byte(marker.TermsHash)},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("failed to record agreement acceptance with PlatformOrg: %s", response.Message)
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"agreementId": agreementID,
+		"termsHash":   marker.TermsHash,
+		"channel":     "common-channel",
+		"action":      "INVESTOR_ACCEPTANCE_PUBLISHED",
+		"timestamp":   time.Now().Format(time.RFC3339),
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("InvestorAcceptancePublished", eventJSON)
+
+	return string(response.Payload), nil
+}
+
+// ConfirmDistributionReceipt confirms the investor received their payout of a startup distribution
+// Channel: common-channel
+// Endorsers: InvestorOrg, PlatformOrg
+func (i *InvestorContract) ConfirmDistributionReceipt(
+	ctx contractapi.TransactionContextInterface,
+	distributionID string,
+	investorID string,
+	paymentReference string,
+) (string, error) {
+	// PlatformOrg marks the payout line received (same channel, so the write is committed)
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{[]byte("RecordDistributionReceipt"), []byte(distributionID), []byte(investorID), []byte(paymentReference)},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("failed to confirm distribution receipt with PlatformOrg: %s", response.Message)
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"distributionId":   distributionID,
+		"investorId":       investorID,
+		"paymentReference": paymentReference,
+		"channel":          "common-channel",
+		"action":           "DISTRIBUTION_RECEIVED",
+		"timestamp":        time.Now().Format(time.RFC3339),
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("DistributionReceived", eventJSON)
+
+	return string(response.Payload), nil
+}
+
+// PledgeToRewardTier pledges to one of a campaign's reward tiers; sold-out tiers are rejected
+// The pledge is held in escrow until the startup delivers the reward
+// Channel: common-channel
+// Endorsers: InvestorOrg, PlatformOrg
+func (i *InvestorContract) PledgeToRewardTier(
+	ctx contractapi.TransactionContextInterface,
+	pledgeID string,
+	campaignID string,
+	tierID string,
+	backerID string,
+	amount float64,
+	shippingRegion string,
+) (string, error) {
+	// Campaign must be within its funding window
+	if err := checkCampaignWindow(ctx, campaignID); err != nil {
+		return "", err
+	}
+
+	// PlatformOrg records the pledge and moves it into escrow (same channel, so the write is committed)
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{
+			[]byte("RecordPledge"),
+			[]byte(pledgeID),
+			[]byte(campaignID),
+			[]byte(tierID),
+			[]byte(backerID),
+			[]byte(strconv.FormatFloat(amount, 'f', -1, 64)),
+			[]byte(shippingRegion),
+		},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("failed to record pledge with PlatformOrg: %s", response.Message)
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"pledgeId":       pledgeID,
+		"campaignId":     campaignID,
+		"tierId":         tierID,
+		"backerId":       backerID,
+		"amount":         amount,
+		"shippingRegion": shippingRegion,
+		"channel":        "common-channel",
+		"action":         "REWARD_PLEDGED",
+		"timestamp":      time.Now().Format(time.RFC3339),
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("RewardPledged", eventJSON)
+
+	return string(response.Payload), nil
+}
+
 // ============================================================================
 // QUERY FUNCTIONS
 // ============================================================================
@@ -1135,6 +1456,59 @@ func (i *InvestorContract) GetInvestment(ctx contractapi.TransactionContextInter
 	return &investment, nil
 }
 
+// GetFundingCommitment retrieves funding commitment by ID
+// Read by PlatformOrg to reconcile inbound investor payments
+func (i *InvestorContract) GetFundingCommitment(ctx contractapi.TransactionContextInterface, commitmentID string) (*FundingCommitment, error) {
+	commitmentJSON, err := ctx.GetStub().GetState(commitmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commitment: %v", err)
+	}
+	if commitmentJSON == nil {
+		return nil, fmt.Errorf("commitment %s does not exist", commitmentID)
+	}
+
+	var commitment FundingCommitment
+	err = json.Unmarshal(commitmentJSON, &commitment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &commitment, nil
+}
+
+// GetMilestoneVerification retrieves the investor's latest verification of a milestone under an agreement
+// Read by PlatformOrg as fund release evidence
+func (i *InvestorContract) GetMilestoneVerification(ctx contractapi.TransactionContextInterface, agreementID string, milestoneID string) (*MilestoneVerification, error) {
+	verificationJSON, err := ctx.GetStub().GetState(fmt.Sprintf("MILESTONE_APPROVAL_%s_%s", agreementID, milestoneID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read milestone verification: %v", err)
+	}
+	if verificationJSON == nil {
+		return nil, fmt.Errorf("milestone %s has not been verified under agreement %s", milestoneID, agreementID)
+	}
+
+	var verification MilestoneVerification
+	err = json.Unmarshal(verificationJSON, &verification)
+	if err != nil {
+		return nil, err
+	}
+
+	return &verification, nil
+}
+
+// GetAgreementAcceptance retrieves the investor's acceptance marker for an agreement
+// Read by PublishAgreementAcceptance on common-channel
+func (i *InvestorContract) GetAgreementAcceptance(ctx contractapi.TransactionContextInterface, agreementID string) (string, error) {
+	markerJSON, err := ctx.GetStub().GetState(fmt.Sprintf("AGREEMENT_INV_%s", agreementID))
+	if err != nil {
+		return "", fmt.Errorf("failed to read agreement acceptance: %v", err)
+	}
+	if markerJSON == nil {
+		return "", fmt.Errorf("agreement %s has not been accepted by the investor", agreementID)
+	}
+	return string(markerJSON), nil
+}
+
 // GetInvestmentsByInvestor returns all investments by investor
 func (i *InvestorContract) GetInvestmentsByInvestor(ctx contractapi.TransactionContextInterface, investorID string) (string, error) {
 	queryString := fmt.Sprintf(`{"selector":{"investorId":"%s"}}`, investorID)
@@ -1211,6 +1585,326 @@ func (i *InvestorContract) GetInvestmentsByCampaign(ctx contractapi.TransactionC
 	return string(investmentsJSON), nil
 }
 
+// GetProposalsByCampaign returns investment proposals for a campaign, optionally filtered by status
+// status: PROPOSED, COUNTERED, ACCEPTED, REJECTED, EXPIRED (empty for all)
+func (i *InvestorContract) GetProposalsByCampaign(ctx contractapi.TransactionContextInterface, campaignID string, status string) (string, error) {
+	return getProposals(ctx, "campaignId", campaignID, status)
+}
+
+// GetProposalsByStartup returns investment proposals received by a startup, optionally filtered by status
+func (i *InvestorContract) GetProposalsByStartup(ctx contractapi.TransactionContextInterface, startupID string, status string) (string, error) {
+	return getProposals(ctx, "startupId", startupID, status)
+}
+
+// GetProposalsByInvestor returns investment proposals made by an investor, optionally filtered by status
+func (i *InvestorContract) GetProposalsByInvestor(ctx contractapi.TransactionContextInterface, investorID string, status string) (string, error) {
+	return getProposals(ctx, "investorId", investorID, status)
+}
+
+// GetRiskInsightRequestsByStatus returns risk insight requests in a status (PENDING, FULFILLED)
+// Read by ValidatorOrg to rebuild its work queue without relying on events
+func (i *InvestorContract) GetRiskInsightRequestsByStatus(ctx contractapi.TransactionContextInterface, status string) (string, error) {
+	records, err := getQueryResults(ctx, map[string]interface{}{
+		"status":      status,
+		"requestId":   map[string]bool{"$exists": true},
+		"requestedAt": map[string]bool{"$exists": true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	requests := []map[string]interface{}{}
+	for _, record := range records {
+		var request RiskInsightRequest
+		if json.Unmarshal(record.Value, &request) != nil {
+			continue
+		}
+		requests = append(requests, map[string]interface{}{
+			"Key":    record.Key,
+			"Record": request,
+		})
+	}
+
+	requestsJSON, err := json.Marshal(requests)
+	if err != nil {
+		return "", err
+	}
+
+	return string(requestsJSON), nil
+}
+
+// GetInvestorPortfolio returns one investor's position across every campaign they are exposed to
+// Combines investments, proposals, funding commitments, milestone verifications and risk responses
+func (i *InvestorContract) GetInvestorPortfolio(ctx contractapi.TransactionContextInterface, investorID string) (string, error) {
+	campaigns := map[string]*PortfolioCampaign{}
+	totals := map[string]*PortfolioTotals{}
+
+	getCampaign := func(campaignID string) *PortfolioCampaign {
+		if campaigns[campaignID] == nil {
+			campaigns[campaignID] = &PortfolioCampaign{
+				CampaignID:   campaignID,
+				Totals:       map[string]*PortfolioTotals{},
+				Investments:  []Investment{},
+				Commitments:  []FundingCommitment{},
+				Negotiations: []NegotiationState{},
+				Milestones:   []PortfolioMilestone{},
+			}
+		}
+		return campaigns[campaignID]
+	}
+
+	// Investments (the CAMPAIGN_INV_ copies are not kept up to date, so only primary records count)
+	liveInvestments := []Investment{}
+	investmentRecords, err := getQueryResults(ctx, map[string]interface{}{
+		"investorId":   investorID,
+		"investmentId": map[string]bool{"$exists": true},
+		"committedAt":  map[string]bool{"$exists": true},
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, record := range investmentRecords {
+		var investment Investment
+		if json.Unmarshal(record.Value, &investment) != nil || record.Key != investment.InvestmentID {
+			continue
+		}
+		campaign := getCampaign(investment.CampaignID)
+		campaign.Investments = append(campaign.Investments, investment)
+
+		// Live investments are added to Committed once commitments are known (see below)
+		if investment.Status != "CONFIRMED" && investment.Status != "WITHDRAWN" {
+			liveInvestments = append(liveInvestments, investment)
+			continue
+		}
+		for _, t := range []map[string]*PortfolioTotals{campaign.Totals, totals} {
+			total := portfolioTotalsFor(t, investment.Currency)
+			if investment.Status == "CONFIRMED" {
+				total.Confirmed += investment.Amount
+			} else {
+				total.Withdrawn += investment.Amount
+			}
+		}
+	}
+
+	// Proposals and their negotiation state
+	proposalRecords, err := getQueryResults(ctx, map[string]interface{}{
+		"investorId":       investorID,
+		"proposalId":       map[string]bool{"$exists": true},
+		"negotiationRound": map[string]bool{"$exists": true},
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, record := range proposalRecords {
+		var proposal InvestmentProposal
+		if json.Unmarshal(record.Value, &proposal) != nil || record.Key != proposal.ProposalID {
+			continue
+		}
+		campaign := getCampaign(proposal.CampaignID)
+		campaign.Negotiations = append(campaign.Negotiations, negotiationStateOf(proposal))
+	}
+
+	// Milestone verifications by this investor, latest per agreement and milestone
+	verifications := map[string]MilestoneVerification{}
+	verificationRecords, err := getQueryResults(ctx, map[string]interface{}{
+		"investorId":     investorID,
+		"verificationId": map[string]bool{"$exists": true},
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, record := range verificationRecords {
+		var verification MilestoneVerification
+		if json.Unmarshal(record.Value, &verification) != nil {
+			continue
+		}
+		key := verification.AgreementID + "|" + verification.MilestoneID
+		if existing, ok := verifications[key]; !ok || verification.VerifiedAt > existing.VerifiedAt {
+			verifications[key] = verification
+		}
+	}
+
+	// Funding commitments and their milestone schedule, latest commitment per agreement
+	committedAgreements := map[string]FundingCommitment{}
+	commitmentRecords, err := getQueryResults(ctx, map[string]interface{}{
+		"investorId":   investorID,
+		"commitmentId": map[string]bool{"$exists": true},
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, record := range commitmentRecords {
+		var commitment FundingCommitment
+		if json.Unmarshal(record.Value, &commitment) != nil {
+			continue
+		}
+		campaign := getCampaign(commitment.CampaignID)
+		campaign.Commitments = append(campaign.Commitments, commitment)
+		if existing, ok := committedAgreements[commitment.AgreementID]; !ok || commitment.CommittedAt > existing.CommittedAt {
+			committedAgreements[commitment.AgreementID] = commitment
+		}
+
+		for _, m := range commitment.Milestones {
+			milestone := PortfolioMilestone{
+				MilestoneID:        m.MilestoneID,
+				AgreementID:        commitment.AgreementID,
+				Title:              m.Title,
+				TargetDate:         m.TargetDate,
+				FundPercentage:     m.FundPercentage,
+				Status:             m.Status,
+				FundsReleased:      m.FundsReleased,
+				VerificationStatus: "NOT_VERIFIED",
+			}
+			if verification, ok := verifications[commitment.AgreementID+"|"+m.MilestoneID]; ok {
+				milestone.VerificationStatus = "REJECTED"
+				if verification.Approved {
+					milestone.VerificationStatus = "APPROVED"
+				}
+				milestone.VerificationID = verification.VerificationID
+				milestone.VerifiedAt = verification.VerifiedAt
+			}
+			campaign.Milestones = append(campaign.Milestones, milestone)
+		}
+	}
+
+	// Committed counts each agreement once; a live investment in a campaign the investor
+	// already has an agreement commitment for is the same money and is not added again
+	committedCampaigns := map[string]bool{}
+	for _, commitment := range committedAgreements {
+		committedCampaigns[commitment.CampaignID] = true
+		for _, t := range []map[string]*PortfolioTotals{getCampaign(commitment.CampaignID).Totals, totals} {
+			portfolioTotalsFor(t, commitment.Currency).Committed += commitment.Amount
+		}
+	}
+	for _, investment := range liveInvestments {
+		if committedCampaigns[investment.CampaignID] {
+			continue
+		}
+		for _, t := range []map[string]*PortfolioTotals{getCampaign(investment.CampaignID).Totals, totals} {
+			portfolioTotalsFor(t, investment.Currency).Committed += investment.Amount
+		}
+	}
+
+	// Latest risk response per campaign
+	riskRecords, err := getQueryResults(ctx, map[string]interface{}{
+		"investorId": investorID,
+		"responseId": map[string]bool{"$exists": true},
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, record := range riskRecords {
+		var riskResponse RiskInsightResponse
+		if json.Unmarshal(record.Value, &riskResponse) != nil {
+			continue
+		}
+		campaign := getCampaign(riskResponse.CampaignID)
+		if campaign.LatestRisk == nil || riskResponse.ReceivedAt > campaign.LatestRisk.ReceivedAt {
+			latest := riskResponse
+			campaign.LatestRisk = &latest
+		}
+	}
+
+	// Fall back to the campaign-wide insight shared by ValidatorOrg
+	for campaignID, campaign := range campaigns {
+		if campaign.LatestRisk != nil {
+			continue
+		}
+		insightJSON, err := ctx.GetStub().GetState(fmt.Sprintf("RISK_INSIGHT_%s", campaignID))
+		if err != nil || insightJSON == nil {
+			continue
+		}
+		var insight map[string]string
+		if json.Unmarshal(insightJSON, &insight) != nil {
+			continue
+		}
+		riskScore, _ := strconv.ParseFloat(insight["riskScore"], 64)
+		campaign.LatestRisk = &RiskInsightResponse{
+			ResponseID:     insight["insightId"],
+			CampaignID:     campaignID,
+			RiskScore:      riskScore,
+			RiskLevel:      insight["riskLevel"],
+			Recommendation: insight["recommendation"],
+			ReceivedAt:     insight["receivedAt"],
+		}
+	}
+
+	campaignIDs := make([]string, 0, len(campaigns))
+	for campaignID := range campaigns {
+		campaignIDs = append(campaignIDs, campaignID)
+	}
+	sort.Strings(campaignIDs)
+
+	campaignList := make([]*PortfolioCampaign, 0, len(campaignIDs))
+	for _, campaignID := range campaignIDs {
+		campaignList = append(campaignList, campaigns[campaignID])
+	}
+
+	response := map[string]interface{}{
+		"investorId":    investorID,
+		"campaignCount": len(campaignList),
+		"campaigns":     campaignList,
+		"totals":        totals,
+	}
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return "", err
+	}
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// LEDGER HELPERS
+// ============================================================================
+
+// queryRecord is a single key/value returned by a rich query
+type queryRecord struct {
+	Key   string
+	Value []byte
+}
+
+// getQueryResults runs a CouchDB selector query and collects all matching records
+func getQueryResults(ctx contractapi.TransactionContextInterface, selector map[string]interface{}) ([]queryRecord, error) {
+	queryJSON, err := json.Marshal(map[string]interface{}{"selector": selector})
+	if err != nil {
+		return nil, err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetQueryResult(string(queryJSON))
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	var records []queryRecord
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, queryRecord{Key: queryResponse.Key, Value: queryResponse.Value})
+	}
+
+	return records, nil
+}
+
+// getTxTime returns the transaction timestamp so every endorser sees the same time
+func getTxTime(ctx contractapi.TransactionContextInterface) (time.Time, error) {
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read transaction timestamp: %v", err)
+	}
+	return txTimestamp.AsTime().UTC(), nil
+}
+
+// parseDate parses dates given as YYYY-MM-DD or RFC3339
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
 // ============================================================================
 // CROSS-CHANNEL INVOCATION HELPER FUNCTIONS
 // ============================================================================
@@ -1400,6 +2094,214 @@ func generateHash(data string) string {
 	return hex.EncodeToString(hash[:])
 }
 
+// portfolioTotalsFor returns the totals bucket for a currency, creating it if needed
+func portfolioTotalsFor(totals map[string]*PortfolioTotals, currency string) *PortfolioTotals {
+	if totals[currency] == nil {
+		totals[currency] = &PortfolioTotals{Currency: currency}
+	}
+	return totals[currency]
+}
+
+// getProposals lists proposals matching field=value with their negotiation state
+func getProposals(ctx contractapi.TransactionContextInterface, field string, value string, status string) (string, error) {
+	validStatuses := map[string]bool{"": true, "PROPOSED": true, "COUNTERED": true, "ACCEPTED": true, "REJECTED": true, "EXPIRED": true}
+	if !validStatuses[status] {
+		return "", fmt.Errorf("invalid status: %s. Must be PROPOSED, COUNTERED, ACCEPTED, REJECTED, or EXPIRED", status)
+	}
+
+	selector := map[string]interface{}{
+		field:        value,
+		"proposalId": map[string]bool{"$exists": true},
+		"history":    map[string]bool{"$exists": true},
+	}
+	if status != "" {
+		selector["status"] = status
+	}
+
+	records, err := getQueryResults(ctx, selector)
+	if err != nil {
+		return "", err
+	}
+
+	var found []InvestmentProposal
+	for _, record := range records {
+		var proposal InvestmentProposal
+		// Skip the PROPOSAL_<campaign>_<proposal> copy
+		if json.Unmarshal(record.Value, &proposal) != nil || record.Key != proposal.ProposalID {
+			continue
+		}
+		found = append(found, proposal)
+	}
+
+	sort.Slice(found, func(a, b int) bool {
+		if found[a].CreatedAt != found[b].CreatedAt {
+			return found[a].CreatedAt < found[b].CreatedAt
+		}
+		return found[a].ProposalID < found[b].ProposalID
+	})
+
+	proposals := []map[string]interface{}{}
+	for _, proposal := range found {
+		proposals = append(proposals, map[string]interface{}{
+			"Key":         proposal.ProposalID,
+			"Record":      proposal,
+			"Negotiation": negotiationStateOf(proposal),
+		})
+	}
+
+	proposalsJSON, err := json.Marshal(proposals)
+	if err != nil {
+		return "", err
+	}
+
+	return string(proposalsJSON), nil
+}
+
+// checkCampaignWindow rejects investing actions outside the campaign's funding window
+// The window is read from PlatformOrg's published campaign on common-channel and checked against the tx timestamp
+func checkCampaignWindow(ctx contractapi.TransactionContextInterface, campaignID string) error {
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{[]byte("GetPublishedCampaign"), []byte(campaignID)},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return fmt.Errorf("failed to read campaign %s from PlatformOrg: %s", campaignID, response.Message)
+	}
+
+	var campaign struct {
+		Status       string `json:"status"`
+		OpenDate     string `json:"openDate"`
+		CloseDate    string `json:"closeDate"`
+		DurationDays int    `json:"durationDays"`
+	}
+	if err := json.Unmarshal(response.Payload, &campaign); err != nil {
+		return fmt.Errorf("failed to parse campaign %s: %v", campaignID, err)
+	}
+
+	if campaign.Status == "CLOSED" || campaign.Status == "COMPLETED" {
+		return fmt.Errorf("campaign %s is %s and no longer accepts investments", campaignID, campaign.Status)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return err
+	}
+
+	opensAt, closesAt := campaignWindow(campaign.OpenDate, campaign.CloseDate, campaign.DurationDays)
+	if !opensAt.IsZero() && txTime.Before(opensAt) {
+		return fmt.Errorf("campaign %s is not open yet, opens at %s", campaignID, opensAt.Format(time.RFC3339))
+	}
+	if !closesAt.IsZero() && !txTime.Before(closesAt) {
+		return fmt.Errorf("campaign %s closed at %s", campaignID, closesAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// campaignWindow returns when a campaign opens and closes (zero when unknown)
+// A date-only CloseDate closes at the end of that day; without one, OpenDate + DurationDays is used
+func campaignWindow(openDate string, closeDate string, durationDays int) (time.Time, time.Time) {
+	var opensAt, closesAt time.Time
+	if t, err := parseDate(openDate); err == nil {
+		opensAt = t
+	}
+	if t, err := time.Parse(time.RFC3339, closeDate); err == nil {
+		closesAt = t
+	} else if t, err := time.Parse("2006-01-02", closeDate); err == nil {
+		closesAt = t.AddDate(0, 0, 1)
+	} else if !opensAt.IsZero() && durationDays > 0 {
+		closesAt = opensAt.AddDate(0, 0, durationDays)
+	}
+	return opensAt, closesAt
+}
+
+// evaluatedTerms is a term sheet as normalized, hashed and diffed by PlatformOrg
+type evaluatedTerms struct {
+	Terms      json.RawMessage `json:"terms"`
+	TermsHash  string          `json:"termsHash"`
+	Instrument json.RawMessage `json:"instrument"`
+	Diff       json.RawMessage `json:"diff"`
+	Changes    int             `json:"changes"`
+}
+
+// termsDocument builds a term sheet from an offer's terms argument
+// A JSON object is the structured term sheet; free text keeps the previous terms, with the text as their notes
+func termsDocument(offerTerms string, previous json.RawMessage) (map[string]interface{}, error) {
+	document := map[string]interface{}{}
+	if strings.HasPrefix(strings.TrimSpace(offerTerms), "{") {
+		if err := json.Unmarshal([]byte(offerTerms), &document); err != nil {
+			return nil, fmt.Errorf("failed to parse negotiation terms: %v", err)
+		}
+		return document, nil
+	}
+
+	if len(previous) > 0 {
+		if err := json.Unmarshal(previous, &document); err != nil {
+			return nil, fmt.Errorf("failed to parse previous terms: %v", err)
+		}
+	}
+	if offerTerms != "" {
+		document["notes"] = offerTerms
+	}
+	return document, nil
+}
+
+// evaluateNegotiationTerms has PlatformOrg normalize and hash a term sheet, and diff it against the previous one
+func evaluateNegotiationTerms(ctx contractapi.TransactionContextInterface, previous json.RawMessage, document map[string]interface{}) (*evaluatedTerms, error) {
+	documentJSON, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{[]byte("EvaluateNegotiationTerms"), previous, documentJSON},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return nil, fmt.Errorf("failed to evaluate negotiation terms with PlatformOrg: %s", response.Message)
+	}
+
+	var terms evaluatedTerms
+	if err := json.Unmarshal(response.Payload, &terms); err != nil {
+		return nil, fmt.Errorf("failed to parse evaluated terms: %v", err)
+	}
+	return &terms, nil
+}
+
+// negotiationStateOf derives the current round, last offer and whose turn it is
+func negotiationStateOf(proposal InvestmentProposal) NegotiationState {
+	state := NegotiationState{
+		ProposalID:       proposal.ProposalID,
+		Status:           proposal.Status,
+		NegotiationRound: proposal.NegotiationRound,
+		LastOfferAmount:  proposal.InvestmentAmount,
+		LastOfferBy:      "INVESTOR",
+		UpdatedAt:        proposal.UpdatedAt,
+	}
+
+	// Last entry that carried an offer (ACCEPT/REJECT entries may have no amount)
+	for idx := len(proposal.History) - 1; idx >= 0; idx-- {
+		entry := proposal.History[idx]
+		if entry.Action == "PROPOSE" || entry.Action == "COUNTER" {
+			state.LastOfferAmount = entry.Amount
+			state.LastOfferBy = entry.Party
+			break
+		}
+	}
+
+	switch proposal.Status {
+	case "PROPOSED":
+		state.AwaitingParty = "STARTUP"
+	case "COUNTERED":
+		state.AwaitingParty = "INVESTOR"
+	default:
+		state.AwaitingParty = "NONE"
+	}
+	return state
+}
+
 func main() {
 	investorChaincode, err := contractapi.NewChaincode(&InvestorContract{})
 	if err != nil {
@@ -2,9 +2,14 @@ package main
 
 import (
 	"crypto/sha256"
+	"encoding/csv"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
@@ -15,34 +20,50 @@ type PlatformContract struct {
 	contractapi.Contract
 }
 
+// PlatformTransactionContext carries state for a single transaction
+// GetState does not return writes made earlier in the same transaction, so token
+// balances touched by several journal postings, and cap tables touched by several
+// campaign closures in one sweep, are cached here
+type PlatformTransactionContext struct {
+	contractapi.TransactionContext
+	tokenBalances map[string]*TokenBalance
+	capTables     map[string]*CapTable
+}
+
 // ============================================================================
 // DATA STRUCTURES
 // ============================================================================
 
 // PublishedCampaign represents a campaign published on the platform portal
 type PublishedCampaign struct {
-	CampaignID          string      `json:"campaignId"`
-	StartupID           string      `json:"startupId"`
-	ProjectName         string      `json:"projectName"`
-	Category            string      `json:"category"`
-	Description         string      `json:"description"`
-	GoalAmount          float64     `json:"goalAmount"`
-	FundsRaisedAmount   float64     `json:"fundsRaisedAmount"`
-	FundsRaisedPercent  float64     `json:"fundsRaisedPercent"`
-	Currency            string      `json:"currency"`
-	OpenDate            string      `json:"openDate"`
-	CloseDate           string      `json:"closeDate"`
-	DurationDays        int         `json:"durationDays"`
-	ValidationScore     float64     `json:"validationScore"`
-	ValidationHash      string      `json:"validationHash"` // Hash verified with ValidatorOrg
-	ValidationVerified  bool        `json:"validationVerified"`
-	Status              string      `json:"status"` // PENDING_VERIFICATION, PUBLISHED, ACTIVE, FUNDED, COMPLETED, CLOSED
-	InvestorCount       int         `json:"investorCount"`
-	TotalConfirmed      float64     `json:"totalConfirmed"`
-	Milestones          []Milestone `json:"milestones"`
-	AgreementIDs        []string    `json:"agreementIds"`
-	PublishedAt         string      `json:"publishedAt"`
-	UpdatedAt           string      `json:"updatedAt"`
+	CampaignID         string      `json:"campaignId"`
+	StartupID          string      `json:"startupId"`
+	ProjectName        string      `json:"projectName"`
+	Category           string      `json:"category"`
+	Description        string      `json:"description"`
+	GoalAmount         float64     `json:"goalAmount"`
+	FundsRaisedAmount  float64     `json:"fundsRaisedAmount"`
+	FundsRaisedPercent float64     `json:"fundsRaisedPercent"`
+	Currency           string      `json:"currency"`
+	OpenDate           string      `json:"openDate"`
+	CloseDate          string      `json:"closeDate"`
+	DurationDays       int         `json:"durationDays"`
+	IsPromoted         bool        `json:"isPromoted"`   // Promoted campaigns pay the promotion surcharge
+	FundingModel       string      `json:"fundingModel"` // ALL_OR_NOTHING, KEEP_IT_ALL (empty = KEEP_IT_ALL)
+	ShareClass         ShareClass  `json:"shareClass"`   // Equity offered; empty ClassName for non-equity campaigns
+	ValidationScore    float64     `json:"validationScore"`
+	ValidationHash     string      `json:"validationHash"` // Hash verified with ValidatorOrg
+	ValidationVerified bool        `json:"validationVerified"`
+	Status             string      `json:"status"`      // PENDING_VERIFICATION, PUBLISHED, ACTIVE, FUNDED, COMPLETED, CLOSED
+	FinalStatus        string      `json:"finalStatus"` // Set at closure: SUCCESSFUL, PARTIALLY_FUNDED, FAILED, CANCELLED
+	InvestorCount      int         `json:"investorCount"`
+	TotalConfirmed     float64     `json:"totalConfirmed"`
+	Milestones         []Milestone `json:"milestones"`
+	AgreementIDs       []string    `json:"agreementIds"`
+	RewardTierIDs      []string    `json:"rewardTierIds"` // Reward tiers backers can pledge to
+	PledgeIDs          []string    `json:"pledgeIds"`     // Reward pledges, each with its own escrow
+	PublishedAt        string      `json:"publishedAt"`
+	UpdatedAt          string      `json:"updatedAt"`
 }
 
 // Milestone represents a funding milestone
@@ -59,37 +80,503 @@ type Milestone struct {
 
 // Agreement represents investment agreement (Platform as witness)
 type Agreement struct {
-	AgreementID       string      `json:"agreementId"`
-	CampaignID        string      `json:"campaignId"`
-	StartupID         string      `json:"startupId"`
-	InvestorID        string      `json:"investorId"`
-	InvestmentAmount  float64     `json:"investmentAmount"`
-	Currency          string      `json:"currency"`
-	Milestones        []Milestone `json:"milestones"`
-	Terms             string      `json:"terms"`
-	Status            string      `json:"status"` // PROPOSED, NEGOTIATING, ACCEPTED, ACTIVE, COMPLETED, CANCELLED
-	StartupAccepted   bool        `json:"startupAccepted"`
-	InvestorAccepted  bool        `json:"investorAccepted"`
-	PlatformWitnessed bool        `json:"platformWitnessed"`
-	WitnessedAt       string      `json:"witnessedAt"`
-	CreatedAt         string      `json:"createdAt"`
-	AcceptedAt        string      `json:"acceptedAt"`
+	AgreementID       string            `json:"agreementId"`
+	CampaignID        string            `json:"campaignId"`
+	StartupID         string            `json:"startupId"`
+	InvestorID        string            `json:"investorId"`
+	InvestmentAmount  float64           `json:"investmentAmount"`
+	Currency          string            `json:"currency"`
+	Milestones        []Milestone       `json:"milestones"`
+	Terms             string            `json:"terms"`
+	ShareClass        string            `json:"shareClass"`
+	SharesIssued      int               `json:"sharesIssued"`   // Shares issued into the startup cap table when witnessed
+	ConversionID      string            `json:"conversionId"`   // Set when a SAFE or note converted into shares
+	InstrumentType    string            `json:"instrumentType"` // Empty for equity; REVENUE_SHARE, SAFE, CONVERTIBLE_NOTE, LOAN
+	Instrument        InstrumentTerms   `json:"instrument"`     // SAFE and convertible note terms
+	NegotiatedTerms   NegotiationTerms  `json:"negotiatedTerms"`
+	TermsHash         string            `json:"termsHash"` // Hash of the normalized terms every witness signs against
+	InvestorTermsHash string            `json:"investorTermsHash"` // Terms hash the investor accepted
+	StartupTermsHash  string            `json:"startupTermsHash"`  // Terms hash the startup accepted
+	Loan              LoanTerms         `json:"loan"`      // Agreements in lending campaigns
+	RevenueShare      RevenueShareTerms `json:"revenueShare"`
+	Status            string            `json:"status"` // PROPOSED, NEGOTIATING, ACCEPTED, ACTIVE, COMPLETED, CANCELLED
+	StartupAccepted   bool              `json:"startupAccepted"`
+	InvestorAccepted  bool              `json:"investorAccepted"`
+	PlatformWitnessed bool              `json:"platformWitnessed"`
+	WitnessedAt       string            `json:"witnessedAt"`
+	CreatedAt         string            `json:"createdAt"`
+	AcceptedAt        string            `json:"acceptedAt"`
+	VestingSchedule   VestingSchedule   `json:"vestingSchedule"` // Applies to releases from the agreement's escrow
+}
+
+// NegotiationTerms is the structured term sheet negotiated between startup and investor
+// Normalized terms hash the same in every organization (TermsHash)
+type NegotiationTerms struct {
+	Amount            float64          `json:"amount"`
+	Currency          string           `json:"currency"`
+	Instrument        InstrumentTerms  `json:"instrument"`
+	PreMoneyValuation float64          `json:"preMoneyValuation"`
+	EquityPercent     float64          `json:"equityPercent"`
+	Milestones        []TermsMilestone `json:"milestones"`
+	BoardRights       string           `json:"boardRights"` // NONE, OBSERVER, SEAT
+	ProRataRights     bool             `json:"proRataRights"`
+	InformationRights bool             `json:"informationRights"`
+	Notes             string           `json:"notes"`
+}
+
+// TermsMilestone is a milestone as agreed in the term sheet
+type TermsMilestone struct {
+	MilestoneID    string  `json:"milestoneId"`
+	Title          string  `json:"title"`
+	TargetDate     string  `json:"targetDate"`
+	FundPercentage float64 `json:"fundPercentage"`
+}
+
+// TermChange is one field that changed between two offers
+type TermChange struct {
+	Field string `json:"field"` // e.g. amount, instrument.valuationCap, milestones[MS001].targetDate
+	From  string `json:"from"`  // JSON value, empty when the field was added
+	To    string `json:"to"`    // JSON value, empty when the field was removed
+}
+
+// AgreementAcceptance is the term sheet each party accepted, published to common-channel
+// Platform witnesses an agreement only when both parties accepted the terms it recomputes
+type AgreementAcceptance struct {
+	AgreementID        string           `json:"agreementId"`
+	InvestorTerms      NegotiationTerms `json:"investorTerms"`
+	InvestorTermsHash  string           `json:"investorTermsHash"`
+	InvestorAcceptedAt string           `json:"investorAcceptedAt"`
+	StartupTerms       NegotiationTerms `json:"startupTerms"`
+	StartupTermsHash   string           `json:"startupTermsHash"`
+	StartupAcceptedAt  string           `json:"startupAcceptedAt"`
+}
+
+// ShareClass is the equity a campaign offers: investors buy shares at PricePerShare,
+// between MinShares and MaxShares per agreement
+type ShareClass struct {
+	ClassName         string  `json:"className"`
+	PreMoneyValuation float64 `json:"preMoneyValuation"`
+	PricePerShare     float64 `json:"pricePerShare"`
+	PreMoneyShares    int     `json:"preMoneyShares"` // Fully diluted shares implied by the valuation
+	MinShares         int     `json:"minShares"`
+	MaxShares         int     `json:"maxShares"`
+	SharesIssued      int     `json:"sharesIssued"`
+}
+
+// InstrumentTerms are the typed terms of a SAFE or convertible note (empty InstrumentType for priced equity)
+type InstrumentTerms struct {
+	InstrumentType    string  `json:"instrumentType"` // SAFE, CONVERTIBLE_NOTE
+	ValuationCap      float64 `json:"valuationCap"`
+	DiscountPercent   float64 `json:"discountPercent"`
+	MostFavoredNation bool    `json:"mostFavoredNation"` // SAFEs only
+	InterestRate      float64 `json:"interestRate"`      // Annual simple interest percent, notes only
+	MaturityDate      string  `json:"maturityDate"`      // Notes only
+}
+
+// InstrumentConversion records SAFEs and convertible notes converted into a priced round's share class
+type InstrumentConversion struct {
+	ConversionID       string                `json:"conversionId"`
+	CampaignID         string                `json:"campaignId"` // The priced round
+	StartupID          string                `json:"startupId"`
+	ShareClass         string                `json:"shareClass"`
+	RoundPricePerShare float64               `json:"roundPricePerShare"`
+	Conversions        []ConvertedInstrument `json:"conversions"`
+	TotalShares        int                   `json:"totalShares"`
+	ConvertedAt        string                `json:"convertedAt"`
+}
+
+// ConvertedInstrument is one agreement's instrument converted into shares
+type ConvertedInstrument struct {
+	AgreementID       string  `json:"agreementId"`
+	InvestorID        string  `json:"investorId"`
+	InstrumentType    string  `json:"instrumentType"`
+	Principal         float64 `json:"principal"`
+	AccruedInterest   float64 `json:"accruedInterest"` // Notes only
+	ConversionPrice   float64 `json:"conversionPrice"`
+	PriceBasis        string  `json:"priceBasis"`        // ROUND, DISCOUNT, CAP
+	MostFavoredNation bool    `json:"mostFavoredNation"` // Best cap and discount of the startup's SAFEs applied
+	Shares            int     `json:"shares"`
+}
+
+// CapTable is a startup's on-ledger share register (key CAP_TABLE_<startupId>)
+type CapTable struct {
+	StartupID   string          `json:"startupId"`
+	Issuances   []ShareIssuance `json:"issuances"`
+	TotalShares int             `json:"totalShares"` // Fully diluted
+	UpdatedAt   string          `json:"updatedAt"`
+}
+
+// ShareIssuance is a block of shares issued to one holder
+type ShareIssuance struct {
+	HolderID      string  `json:"holderId"` // Investor ID, or FOUNDERS for the pre-money shares
+	ShareClass    string  `json:"shareClass"`
+	Shares        int     `json:"shares"`
+	PricePerShare float64 `json:"pricePerShare"`
+	CampaignID    string  `json:"campaignId"`
+	AgreementID   string  `json:"agreementId"`
+	IssuedAt      string  `json:"issuedAt"`
+}
+
+// CapTableOwnership is a holder's fully diluted ownership of a startup
+type CapTableOwnership struct {
+	HolderID         string   `json:"holderId"`
+	ShareClasses     []string `json:"shareClasses"`
+	Shares           int      `json:"shares"`
+	OwnershipPercent float64  `json:"ownershipPercent"`
+}
+
+// CapTableSnapshot is the fully diluted cap table when a campaign closed (key CAP_TABLE_SNAPSHOT_<campaignId>)
+type CapTableSnapshot struct {
+	StartupID   string              `json:"startupId"`
+	CampaignID  string              `json:"campaignId"`
+	ClosureID   string              `json:"closureId"`
+	FinalStatus string              `json:"finalStatus"`
+	TotalShares int                 `json:"totalShares"`
+	Holders     []CapTableOwnership `json:"holders"`
+	TakenAt     string              `json:"takenAt"`
+}
+
+// RevenueShareOffer makes a campaign revenue-based financing (key REVENUE_SHARE_<campaignId>)
+// The startup owes RevenuePercent of reported revenue to the campaign's investors together,
+// split pro-rata by invested amount; every agreement witnessed in the campaign takes these terms
+type RevenueShareOffer struct {
+	CampaignID         string  `json:"campaignId"`
+	StartupID          string  `json:"startupId"`
+	Currency           string  `json:"currency"`
+	RevenuePercent     float64 `json:"revenuePercent"`
+	CapMultiple        float64 `json:"capMultiple"`
+	ReportingFrequency string  `json:"reportingFrequency"` // MONTHLY, QUARTERLY, ANNUAL
+	FirstPeriodStart   string  `json:"firstPeriodStart"`
+	FirstReportDueAt   string  `json:"firstReportDueAt"`
+	OfferedAt          string  `json:"offeredAt"`
+}
+
+// RevenueShareTerms make an agreement revenue-based financing: the investor is owed their pro-rata
+// part of the campaign's revenue share until CapAmount (invested amount x CapMultiple) is reached
+type RevenueShareTerms struct {
+	RevenuePercent     float64 `json:"revenuePercent"` // The campaign's share of revenue, split across its investors
+	CapMultiple        float64 `json:"capMultiple"`
+	CapAmount          float64 `json:"capAmount"`
+	ReportingFrequency string  `json:"reportingFrequency"` // MONTHLY, QUARTERLY, ANNUAL
+	AccruedAmount      float64 `json:"accruedAmount"`      // Obligations from revenue reports so far
+	LastPeriodEnd      string  `json:"lastPeriodEnd"`
+	NextReportDueAt    string  `json:"nextReportDueAt"`
+	ReportOverdue      bool    `json:"reportOverdue"`
+	Status             string  `json:"status"` // ACTIVE, CAP_REACHED
+}
+
+// RevenueReport is a startup's revenue for one period and the revenue-share obligations it created
+// Obligations are paid as a REVENUE_SHARE distribution (REVSHARE_<reportId>)
+type RevenueReport struct {
+	ReportID        string                   `json:"reportId"`
+	CampaignID      string                   `json:"campaignId"`
+	StartupID       string                   `json:"startupId"`
+	PeriodStart     string                   `json:"periodStart"`
+	PeriodEnd       string                   `json:"periodEnd"`
+	Revenue         float64                  `json:"revenue"`
+	Currency        string                   `json:"currency"`
+	ReportHash      string                   `json:"reportHash"`
+	DueAt           string                   `json:"dueAt"`
+	Late            bool                     `json:"late"` // Submitted after DueAt
+	Obligations     []RevenueShareObligation `json:"obligations"`
+	TotalObligation float64                  `json:"totalObligation"`
+	DistributionID  string                   `json:"distributionId"`
+	SubmittedAt     string                   `json:"submittedAt"`
+}
+
+// RevenueShareObligation is what one revenue-share agreement is owed from a revenue report
+type RevenueShareObligation struct {
+	AgreementID   string  `json:"agreementId"`
+	InvestorID    string  `json:"investorId"`
+	Amount        float64 `json:"amount"`
+	AccruedAmount float64 `json:"accruedAmount"` // Total accrued on the agreement including this report
+	CapAmount     float64 `json:"capAmount"`
+	CapReached    bool    `json:"capReached"`
+}
+
+// LoanTerms are the terms of a lender's agreement in a lending campaign
+type LoanTerms struct {
+	Principal        float64 `json:"principal"`
+	InterestRate     float64 `json:"interestRate"` // Annual percent
+	TermMonths       int     `json:"termMonths"`
+	AmortizationType string  `json:"amortizationType"` // EQUAL_PAYMENT, EQUAL_PRINCIPAL, INTEREST_ONLY
+}
+
+// Loan is a lending campaign's pooled loan, repaid monthly to its lenders pro-rata (key LOAN_<campaignId>)
+type Loan struct {
+	LoanID               string            `json:"loanId"`
+	CampaignID           string            `json:"campaignId"`
+	StartupID            string            `json:"startupId"`
+	Currency             string            `json:"currency"`
+	InterestRate         float64           `json:"interestRate"`
+	TermMonths           int               `json:"termMonths"`
+	AmortizationType     string            `json:"amortizationType"`
+	Principal            float64           `json:"principal"` // Set at origination from the lenders' agreements
+	Lenders              []LoanLender      `json:"lenders"`
+	Schedule             []LoanInstallment `json:"schedule"`
+	PrincipalRepaid      float64           `json:"principalRepaid"`
+	InterestPaid         float64           `json:"interestPaid"`
+	OutstandingPrincipal float64           `json:"outstandingPrincipal"`
+	DaysPastDue          int               `json:"daysPastDue"`
+	Status               string            `json:"status"` // OFFERED, ACTIVE, DELINQUENT, DEFAULTED, REPAID
+	OfferedAt            string            `json:"offeredAt"`
+	OriginatedAt         string            `json:"originatedAt"`
+	UpdatedAt            string            `json:"updatedAt"`
+}
+
+// LoanLender is one investor's share of a loan and what they have been repaid
+type LoanLender struct {
+	InvestorID           string   `json:"investorId"`
+	AgreementIDs         []string `json:"agreementIds"`
+	Principal            float64  `json:"principal"`
+	ShareRatio           float64  `json:"shareRatio"`
+	PrincipalRepaid      float64  `json:"principalRepaid"`
+	InterestPaid         float64  `json:"interestPaid"`
+	OutstandingPrincipal float64  `json:"outstandingPrincipal"`
+}
+
+// LoanInstallment is one monthly payment of a loan's repayment schedule
+type LoanInstallment struct {
+	Number        int     `json:"number"`
+	DueDate       string  `json:"dueDate"`
+	Principal     float64 `json:"principal"`
+	Interest      float64 `json:"interest"`
+	PrincipalPaid float64 `json:"principalPaid"`
+	InterestPaid  float64 `json:"interestPaid"`
+	Status        string  `json:"status"` // PENDING, PARTIAL, PAID
+	PaidAt        string  `json:"paidAt"`
+}
+
+// LoanRepayment is a startup repayment allocated to installments and lenders
+type LoanRepayment struct {
+	RepaymentID     string                    `json:"repaymentId"`
+	LoanID          string                    `json:"loanId"`
+	CampaignID      string                    `json:"campaignId"`
+	Amount          float64                   `json:"amount"`
+	PrincipalAmount float64                   `json:"principalAmount"`
+	InterestAmount  float64                   `json:"interestAmount"`
+	Allocations     []LoanRepaymentAllocation `json:"allocations"`
+	LoanStatus      string                    `json:"loanStatus"`
+	PaidAt          string                    `json:"paidAt"`
+}
+
+// LoanRepaymentAllocation is a lender's part of a repayment
+type LoanRepaymentAllocation struct {
+	InvestorID string  `json:"investorId"`
+	Principal  float64 `json:"principal"`
+	Interest   float64 `json:"interest"`
+}
+
+// RewardTier is a reward a backer receives for pledging at least PledgeAmount to a campaign
+// (key REWARD_TIER_<campaignId>_<tierId>)
+type RewardTier struct {
+	TierID            string   `json:"tierId"`
+	CampaignID        string   `json:"campaignId"`
+	StartupID         string   `json:"startupId"`
+	Title             string   `json:"title"`
+	Description       string   `json:"description"`
+	PledgeAmount      float64  `json:"pledgeAmount"` // Minimum pledge for the tier
+	Currency          string   `json:"currency"`
+	QuantityLimit     int      `json:"quantityLimit"` // 0 for unlimited
+	QuantityPledged   int      `json:"quantityPledged"`
+	EstimatedDelivery string   `json:"estimatedDelivery"` // YYYY-MM-DD
+	ShippingRegions   []string `json:"shippingRegions"`   // Region codes or WORLDWIDE; empty for digital rewards
+	Status            string   `json:"status"`            // AVAILABLE, SOLD_OUT
+	CreatedAt         string   `json:"createdAt"`
+	UpdatedAt         string   `json:"updatedAt"`
+}
+
+// RewardPledge is a backer's pledge to a reward tier, held in escrow until the reward is delivered
+type RewardPledge struct {
+	PledgeID           string              `json:"pledgeId"`
+	CampaignID         string              `json:"campaignId"`
+	TierID             string              `json:"tierId"`
+	StartupID          string              `json:"startupId"`
+	BackerID           string              `json:"backerId"` // Investor ID of the backer
+	Amount             float64             `json:"amount"`
+	Currency           string              `json:"currency"`
+	ShippingRegion     string              `json:"shippingRegion"`
+	EscrowID           string              `json:"escrowId"`
+	ReleaseID          string              `json:"releaseId"`         // Release of the escrow to the startup on delivery
+	Status             string              `json:"status"`            // PLEDGED, FULFILLED, REFUNDED
+	FulfillmentStatus  string              `json:"fulfillmentStatus"` // PENDING, IN_PRODUCTION, SHIPPED, DELIVERED, CANCELLED
+	TrackingReference  string              `json:"trackingReference"`
+	EstimatedDelivery  string              `json:"estimatedDelivery"` // The tier's estimate when the backer pledged
+	DeliveryLate       bool                `json:"deliveryLate"`
+	FlaggedLateAt      string              `json:"flaggedLateAt"`
+	FulfillmentHistory []FulfillmentUpdate `json:"fulfillmentHistory"`
+	PledgedAt          string              `json:"pledgedAt"`
+	DeliveredAt        string              `json:"deliveredAt"`
+	UpdatedAt          string              `json:"updatedAt"`
+}
+
+// FulfillmentUpdate is one fulfillment status recorded by the startup for a pledge
+type FulfillmentUpdate struct {
+	Status            string `json:"status"`
+	TrackingReference string `json:"trackingReference"`
+	RecordedAt        string `json:"recordedAt"`
 }
 
 // FundEscrow represents funds held in escrow by Platform
 type FundEscrow struct {
-	EscrowID     string  `json:"escrowId"`
-	AgreementID  string  `json:"agreementId"`
-	CampaignID   string  `json:"campaignId"`
-	InvestorID   string  `json:"investorId"`
-	StartupID    string  `json:"startupId"`
-	TotalAmount  float64 `json:"totalAmount"`
-	ReleasedAmount float64 `json:"releasedAmount"`
-	HeldAmount   float64 `json:"heldAmount"`
-	Currency     string  `json:"currency"`
-	Status       string  `json:"status"` // ACTIVE, PARTIALLY_RELEASED, FULLY_RELEASED, REFUNDED
-	CreatedAt    string  `json:"createdAt"`
-	UpdatedAt    string  `json:"updatedAt"`
+	EscrowID          string          `json:"escrowId"`
+	AgreementID       string          `json:"agreementId"`
+	CampaignID        string          `json:"campaignId"`
+	InvestorID        string          `json:"investorId"`
+	StartupID         string          `json:"startupId"`
+	TotalAmount       float64         `json:"totalAmount"`
+	ReleasedAmount    float64         `json:"releasedAmount"`
+	HeldAmount        float64         `json:"heldAmount"`
+	ReservedAmount    float64         `json:"reservedAmount"`    // Part of HeldAmount reserved by releases pending approval
+	PendingReleaseIDs []string        `json:"pendingReleaseIds"` // Releases pending approval against this escrow
+	RefundedAmount    float64         `json:"refundedAmount"`
+	Currency          string          `json:"currency"`
+	Status            string          `json:"status"`           // ACTIVE, PARTIALLY_RELEASED, FULLY_RELEASED, REFUNDED, FROZEN
+	FrozenFromStatus  string          `json:"frozenFromStatus"` // Status restored when the escrow is unfrozen
+	ActiveHoldID      string          `json:"activeHoldId"`     // Hold that froze the escrow, empty when not frozen
+	VestingSchedule   VestingSchedule `json:"vestingSchedule"`  // Copied to each release; zero installments release immediately
+	CreatedAt         string          `json:"createdAt"`
+	UpdatedAt         string          `json:"updatedAt"`
+}
+
+// EscrowHold freezes an escrow while a dispute or fraud suspicion is investigated
+// While a hold is ACTIVE no release or refund can be made from the escrow
+type EscrowHold struct {
+	HoldID       string `json:"holdId"`
+	EscrowID     string `json:"escrowId"`
+	CampaignID   string `json:"campaignId"`
+	InvestorID   string `json:"investorId"`
+	Reason       string `json:"reason"`   // e.g. MILESTONE_DISPUTE, SUSPECTED_FRAUD
+	OpenedBy     string `json:"openedBy"` // Party that opened the hold: the verified investor ID or PLATFORM
+	OpenedByMSP  string `json:"openedByMsp"`
+	Status       string `json:"status"` // ACTIVE, RESOLVED
+	ResolutionID string `json:"resolutionId"`
+	OpenedAt     string `json:"openedAt"`
+	ResolvedAt   string `json:"resolvedAt"`
+}
+
+// HoldResolution records why one or more escrow holds were lifted
+type HoldResolution struct {
+	ResolutionID  string   `json:"resolutionId"`
+	CampaignID    string   `json:"campaignId"`
+	HoldIDs       []string `json:"holdIds"`
+	EscrowIDs     []string `json:"escrowIds"`
+	Resolution    string   `json:"resolution"`
+	ResolvedBy    string   `json:"resolvedBy"`
+	ResolvedByMSP string   `json:"resolvedByMsp"`
+	ResolvedAt    string   `json:"resolvedAt"`
+}
+
+// FrozenEscrow is a frozen escrow with the hold that froze it
+type FrozenEscrow struct {
+	Escrow FundEscrow `json:"escrow"`
+	Hold   EscrowHold `json:"hold"`
+}
+
+// DisputeExecution records PlatformOrg carrying out an arbitration decision
+// Stored as DISPUTE_EXECUTION_<disputeId> so a decision is executed only once
+type DisputeExecution struct {
+	ExecutionID      string  `json:"executionId"`
+	DisputeID        string  `json:"disputeId"`
+	Remedy           string  `json:"remedy"` // RELEASE, REFUND
+	EscrowID         string  `json:"escrowId"`
+	CampaignID       string  `json:"campaignId"`
+	ReleaseID        string  `json:"releaseId"`
+	RefundID         string  `json:"refundId"`
+	Amount           float64 `json:"amount"`
+	HoldResolutionID string  `json:"holdResolutionId"` // Set when the decision lifted a hold on the escrow
+	SummaryHash      string  `json:"summaryHash"`
+	ExecutedAt       string  `json:"executedAt"`
+}
+
+// Clawback tracks funds a blacklisted campaign's startup owes back from releases already paid out
+// Stored as CLAWBACK_<campaignId>; recoveries are distributed pro-rata to the campaign's investors
+type Clawback struct {
+	ClawbackID        string          `json:"clawbackId"`
+	CampaignID        string          `json:"campaignId"`
+	StartupID         string          `json:"startupId"`
+	Currency          string          `json:"currency"`
+	Reason            string          `json:"reason"`
+	BlacklistReason   string          `json:"blacklistReason"`
+	ReleaseIDs        []string        `json:"releaseIds"`
+	AmountOwed        float64         `json:"amountOwed"`      // Net amounts the startup received, vesting releases only as claimed
+	ReturnedVesting   float64         `json:"returnedVesting"` // Unclaimed vesting funds returned to investors directly
+	RecoveredAmount   float64         `json:"recoveredAmount"`
+	OutstandingAmount float64         `json:"outstandingAmount"`
+	Shares            []ClawbackShare `json:"shares"`
+	Status            string          `json:"status"` // OPEN, PARTIALLY_RECOVERED, RECOVERED
+	OpenedAt          string          `json:"openedAt"`
+	UpdatedAt         string          `json:"updatedAt"`
+}
+
+// CampaignBlacklist is ValidatorOrg's blacklist status of a campaign on common-channel
+type CampaignBlacklist struct {
+	Blacklisted bool   `json:"blacklisted"`
+	Reason      string `json:"reason"`
+}
+
+// ClawbackShare is one investor's pro-rata claim on recovered funds
+type ClawbackShare struct {
+	InvestorID        string  `json:"investorId"`
+	ReleasedAmount    float64 `json:"releasedAmount"` // Investor's escrowed funds the startup received
+	ShareRatio        float64 `json:"shareRatio"`
+	DistributedAmount float64 `json:"distributedAmount"`
+}
+
+// ClawbackRecovery is one recovery payment from the startup and its distribution to investors
+type ClawbackRecovery struct {
+	RecoveryID    string                 `json:"recoveryId"`
+	ClawbackID    string                 `json:"clawbackId"`
+	CampaignID    string                 `json:"campaignId"`
+	Amount        float64                `json:"amount"`
+	Currency      string                 `json:"currency"`
+	Source        string                 `json:"source"` // STARTUP_CASH (tokens held by the startup) or FIAT (paid off-chain)
+	Reference     string                 `json:"reference"`
+	Distributions []ClawbackDistribution `json:"distributions"`
+	RecordedAt    string                 `json:"recordedAt"`
+}
+
+// ClawbackDistribution is the part of a recovery paid to one investor
+type ClawbackDistribution struct {
+	InvestorID string  `json:"investorId"`
+	Amount     float64 `json:"amount"`
+}
+
+// Distribution is a return declared by the startup to its campaign's investors
+// Each investor's entitlement is proportional to their invested amount under witnessed agreements
+type Distribution struct {
+	DistributionID   string  `json:"distributionId"`
+	CampaignID       string  `json:"campaignId"`
+	StartupID        string  `json:"startupId"`
+	DistributionType string  `json:"distributionType"` // DIVIDEND, REVENUE_SHARE, EXIT_PROCEEDS
+	TotalAmount      float64 `json:"totalAmount"`
+	Currency         string  `json:"currency"`
+	Description      string  `json:"description"`
+	TotalInvested    float64 `json:"totalInvested"`
+	PayoutCount      int     `json:"payoutCount"`
+	ConfirmedCount   int     `json:"confirmedCount"`
+	Status           string  `json:"status"` // DECLARED, PAID (every payout confirmed)
+	DeclaredAt       string  `json:"declaredAt"`
+	UpdatedAt        string  `json:"updatedAt"`
+}
+
+// DistributionPayout is one investor's line of a distribution
+// Stored as DISTRIBUTION_PAYOUT_<distributionId>_<investorId>
+type DistributionPayout struct {
+	PayoutID         string   `json:"payoutId"`
+	DistributionID   string   `json:"distributionId"`
+	CampaignID       string   `json:"campaignId"`
+	InvestorID       string   `json:"investorId"`
+	AgreementIDs     []string `json:"agreementIds"`
+	InvestedAmount   float64  `json:"investedAmount"`
+	ShareRatio       float64  `json:"shareRatio"`
+	Amount           float64  `json:"amount"`
+	Currency         string   `json:"currency"`
+	DistributionType string   `json:"distributionType"`
+	Status           string   `json:"status"` // PENDING, CONFIRMED
+	PaymentReference string   `json:"paymentReference"`
+	DeclaredAt       string   `json:"declaredAt"`
+	ConfirmedAt      string   `json:"confirmedAt"`
 }
 
 // InvestorConfirmationRecord represents recorded investor confirmation
@@ -117,30 +604,257 @@ type ValidatorDecisionRecord struct {
 
 // FundRelease represents fund release to startup (milestone-based)
 type FundRelease struct {
+	ReleaseID          string            `json:"releaseId"`
+	EscrowID           string            `json:"escrowId"`
+	AgreementID        string            `json:"agreementId"`
+	PledgeID           string            `json:"pledgeId"` // Reward pledge whose delivery triggered the release
+	CampaignID         string            `json:"campaignId"`
+	MilestoneID        string            `json:"milestoneId"`
+	StartupID          string            `json:"startupId"`
+	Amount             float64           `json:"amount"`
+	Currency           string            `json:"currency"`
+	FeeAmount          float64           `json:"feeAmount"` // Platform fee deducted at release
+	NetAmount          float64           `json:"netAmount"` // Amount paid to the startup (Amount - FeeAmount)
+	Status             string            `json:"status"`    // PENDING_APPROVAL, RELEASED, EXPIRED, CANCELLED
+	TriggerReason      string            `json:"triggerReason"`
+	RequiredSignatures map[string]int    `json:"requiredSignatures"` // MSP ID -> approvals needed (releases above threshold)
+	Approvals          []ReleaseApproval `json:"approvals"`
+	RequestedAt        string            `json:"requestedAt"`
+	ExpiresAt          string            `json:"expiresAt"` // Pending releases must be approved before this time
+	Evidence           ReleaseEvidence   `json:"evidence"`  // Milestone approvals the release was checked against
+	VestingSchedule    VestingSchedule   `json:"vestingSchedule"`
+	VestingStartsAt    string            `json:"vestingStartsAt"` // Transaction time of the release; vesting is measured from here
+	ClaimedAmount      float64           `json:"claimedAmount"`   // Vested net amount the startup has claimed
+	CancelledAmount    float64           `json:"cancelledAmount"` // Unclaimed vesting funds a clawback returned to the investor
+	ReleasedAt         string            `json:"releasedAt"`
+}
+
+// VestingSchedule unlocks released funds over time instead of at release
+// Nothing vests before the cliff; after it, one installment vests per elapsed interval
+// e.g. cliffDays 90, intervalDays 30, installments 12: 3/12 at day 90, then 1/12 a month
+type VestingSchedule struct {
+	CliffDays    int `json:"cliffDays"`
+	IntervalDays int `json:"intervalDays"`
+	Installments int `json:"installments"` // 0 pays the release out immediately
+}
+
+// VestingClaim records the startup claiming vested funds of a release
+type VestingClaim struct {
+	ClaimID       string  `json:"claimId"`
 	ReleaseID     string  `json:"releaseId"`
 	EscrowID      string  `json:"escrowId"`
-	AgreementID   string  `json:"agreementId"`
 	CampaignID    string  `json:"campaignId"`
-	MilestoneID   string  `json:"milestoneId"`
 	StartupID     string  `json:"startupId"`
 	Amount        float64 `json:"amount"`
 	Currency      string  `json:"currency"`
-	Status        string  `json:"status"` // PENDING, RELEASED
-	TriggerReason string  `json:"triggerReason"`
-	ReleasedAt    string  `json:"releasedAt"`
+	VestedAmount  float64 `json:"vestedAmount"`  // Vested at claim time
+	ClaimedAmount float64 `json:"claimedAmount"` // Total claimed from the release including this claim
+	ClaimedAt     string  `json:"claimedAt"`
+}
+
+// ReleaseEvidence records the validator and investor milestone approvals behind a release
+// Attestation and verification hashes are SHA256 of the records as read from common-channel
+type ReleaseEvidence struct {
+	ValidatorVerificationID  string `json:"validatorVerificationId"`
+	ValidatorValidationHash  string `json:"validatorValidationHash"` // Hash of the validator's MilestoneValidation
+	ValidatorAttestationHash string `json:"validatorAttestationHash"`
+	MilestoneReportHash      string `json:"milestoneReportHash"`
+	InvestorVerificationID   string `json:"investorVerificationId"`
+	InvestorID               string `json:"investorId"`
+	InvestorVerificationHash string `json:"investorVerificationHash"`
+	DisputeID                string `json:"disputeId"` // Set when an arbitration decision ordered the release
+	DisputeSummaryHash       string `json:"disputeSummaryHash"`
+	CheckedAt                string `json:"checkedAt"`
+}
+
+// ReleaseApprover is an identity authorized to approve large fund releases
+type ReleaseApprover struct {
+	ApproverID string `json:"approverId"` // Client identity ID (see GetCallerIdentity)
+	MSPID      string `json:"mspId"`
+	Role       string `json:"role"` // e.g. ESCROW_OFFICER, VALIDATOR_REVIEWER
+}
+
+// ReleaseApproval is one approver's signature on a pending fund release
+type ReleaseApproval struct {
+	ApproverID string `json:"approverId"`
+	MSPID      string `json:"mspId"`
+	Role       string `json:"role"`
+	ApprovedAt string `json:"approvedAt"`
+}
+
+// ReleaseApprovalPolicy decides which fund releases need multi-signature approval
+// Releases above ThresholdAmount need RequiredSignatures approvals per organization
+type ReleaseApprovalPolicy struct {
+	PolicyID            string            `json:"policyId"`
+	ThresholdAmount     float64           `json:"thresholdAmount"` // 0 disables approvals
+	RequiredSignatures  map[string]int    `json:"requiredSignatures"`
+	Approvers           []ReleaseApprover `json:"approvers"`
+	ApprovalWindowHours int               `json:"approvalWindowHours"`
+	UpdatedAt           string            `json:"updatedAt"`
+}
+
+// EscrowRefund represents held funds returned from escrow to the investor
+type EscrowRefund struct {
+	RefundID    string  `json:"refundId"`
+	EscrowID    string  `json:"escrowId"`
+	AgreementID string  `json:"agreementId"`
+	CampaignID  string  `json:"campaignId"`
+	InvestorID  string  `json:"investorId"`
+	StartupID   string  `json:"startupId"`
+	Amount      float64 `json:"amount"`
+	Currency    string  `json:"currency"`
+	Reason      string  `json:"reason"` // e.g. CAMPAIGN_FAILED, CAMPAIGN_CANCELLED, INVESTOR_WITHDRAWN
+	Status      string  `json:"status"` // REFUNDED
+	RefundedAt  string  `json:"refundedAt"`
 }
 
 // CampaignClosure represents campaign closure record
 type CampaignClosure struct {
 	ClosureID          string  `json:"closureId"`
 	CampaignID         string  `json:"campaignId"`
-	FinalStatus        string  `json:"finalStatus"` // SUCCESSFUL, FAILED, CANCELLED
-	FinalAmount        float64 `json:"finalAmount"`
+	FinalStatus        string  `json:"finalStatus"`    // SUCCESSFUL, PARTIALLY_FUNDED (keep-it-all), FAILED, CANCELLED
+	FinalAmount        float64 `json:"finalAmount"`    // Derived from escrow records, not the caller
+	ReportedAmount     float64 `json:"reportedAmount"` // Amount supplied by the caller, kept for audit
 	FinalInvestorCount int     `json:"finalInvestorCount"`
 	ClosureReason      string  `json:"closureReason"`
 	ClosedAt           string  `json:"closedAt"`
 }
 
+// FeeSchedule is the platform fee charged on every fund release
+// Rates are percentages of the released amount; the flat fee is in the release currency
+type FeeSchedule struct {
+	ScheduleID         string             `json:"scheduleId"`
+	PercentageFee      float64            `json:"percentageFee"`
+	FlatFee            float64            `json:"flatFee"`
+	CategorySurcharges map[string]float64 `json:"categorySurcharges"` // category -> extra percentage
+	PromotionSurcharge float64            `json:"promotionSurcharge"` // extra percentage for promoted campaigns
+	UpdatedAt          string             `json:"updatedAt"`
+}
+
+// FeeEntry is one platform revenue ledger entry, recorded per fund release
+type FeeEntry struct {
+	FeeEntryID               string  `json:"feeEntryId"`
+	ReleaseID                string  `json:"releaseId"`
+	EscrowID                 string  `json:"escrowId"`
+	CampaignID               string  `json:"campaignId"`
+	StartupID                string  `json:"startupId"`
+	InvestorID               string  `json:"investorId"`
+	Category                 string  `json:"category"`
+	Currency                 string  `json:"currency"`
+	GrossAmount              float64 `json:"grossAmount"`
+	PercentageRate           float64 `json:"percentageRate"`
+	PercentageAmount         float64 `json:"percentageAmount"`
+	FlatFee                  float64 `json:"flatFee"`
+	CategorySurchargeRate    float64 `json:"categorySurchargeRate"`
+	CategorySurchargeAmount  float64 `json:"categorySurchargeAmount"`
+	PromotionSurchargeRate   float64 `json:"promotionSurchargeRate"`
+	PromotionSurchargeAmount float64 `json:"promotionSurchargeAmount"`
+	TotalFee                 float64 `json:"totalFee"`
+	NetAmount                float64 `json:"netAmount"`
+	RecordedAt               string  `json:"recordedAt"`
+}
+
+// FeeLedger is the platform revenue ledger with totals by currency
+type FeeLedger struct {
+	TotalsByCurrency map[string]float64 `json:"totalsByCurrency"`
+	Entries          []FeeEntry         `json:"entries"`
+	GeneratedAt      string             `json:"generatedAt"`
+}
+
+// JournalLine is one debit or credit to a named account
+// Accounts: INVESTOR_WALLET:<investorId>, ESCROW:<escrowId>, STARTUP_PAYABLE:<startupId>,
+// STARTUP_CASH:<startupId>, VESTING:<releaseId>, PLATFORM_FEES, FIAT_CLEARING (token mint/burn)
+type JournalLine struct {
+	Account string  `json:"account"`
+	Debit   float64 `json:"debit"`
+	Credit  float64 `json:"credit"`
+}
+
+// JournalEntry is a balanced double-entry posting for one money movement
+type JournalEntry struct {
+	EntryID    string        `json:"entryId"`
+	EntryType  string        `json:"entryType"` // ESCROW_FUNDED, FUNDS_RELEASED, ESCROW_REFUNDED, FUNDING_RECEIVED, VESTING_CLAIMED, VESTING_CANCELLED, CLAWBACK_RECOVERED, LOAN_REPAYMENT, TOKENS_MINTED, TOKENS_BURNED, TOKENS_TRANSFERRED
+	Reference  string        `json:"reference"` // escrowId, releaseId or refundId
+	CampaignID string        `json:"campaignId"`
+	Currency   string        `json:"currency"`
+	Lines      []JournalLine `json:"lines"`
+	Memo       string        `json:"memo"`
+	PostedAt   string        `json:"postedAt"`
+}
+
+// TokenBalance is the settlement token balance of one journal account in one currency
+// Every journal posting moves tokens, so balances always equal journal debits minus credits
+type TokenBalance struct {
+	Account   string  `json:"account"`
+	Currency  string  `json:"currency"`
+	Balance   float64 `json:"balance"`
+	UpdatedAt string  `json:"updatedAt"`
+}
+
+// TokenSupply is the amount of settlement tokens in circulation for a currency
+type TokenSupply struct {
+	Currency    string  `json:"currency"`
+	TotalSupply float64 `json:"totalSupply"`
+}
+
+// TrialBalanceAccount is the total debits and credits posted to one account in one currency
+type TrialBalanceAccount struct {
+	Account  string  `json:"account"`
+	Currency string  `json:"currency"`
+	Debits   float64 `json:"debits"`
+	Credits  float64 `json:"credits"`
+	Balance  float64 `json:"balance"` // Debits - Credits
+}
+
+// TrialBalanceTotals sums all accounts in one currency
+type TrialBalanceTotals struct {
+	Currency string  `json:"currency"`
+	Debits   float64 `json:"debits"`
+	Credits  float64 `json:"credits"`
+	Balanced bool    `json:"balanced"`
+}
+
+// TrialBalance proves the journal balances: total debits equal total credits per currency
+type TrialBalance struct {
+	Accounts    []TrialBalanceAccount          `json:"accounts"`
+	Totals      map[string]*TrialBalanceTotals `json:"totals"`
+	EntryCount  int                            `json:"entryCount"`
+	Balanced    bool                           `json:"balanced"`
+	GeneratedAt string                         `json:"generatedAt"`
+}
+
+// PaymentRecord is an off-chain bank or processor payment matched against the ledger
+// INBOUND payments fund a FundingCommitment; OUTBOUND disbursements pay out a FundRelease
+type PaymentRecord struct {
+	PaymentID            string   `json:"paymentId"` // PAYMENT_<processorReference>
+	ProcessorReference   string   `json:"processorReference"`
+	Direction            string   `json:"direction"`      // INBOUND, OUTBOUND
+	MatchReference       string   `json:"matchReference"` // commitmentId (INBOUND) or releaseId (OUTBOUND)
+	CampaignID           string   `json:"campaignId"`
+	CounterpartyID       string   `json:"counterpartyId"` // investorId (INBOUND) or startupId (OUTBOUND)
+	Amount               float64  `json:"amount"`
+	Currency             string   `json:"currency"`
+	ValueDate            string   `json:"valueDate"`
+	ProcessorStatus      string   `json:"processorStatus"` // PENDING, SETTLED, FAILED, RETURNED
+	ExpectedAmount       float64  `json:"expectedAmount"`
+	ExpectedCurrency     string   `json:"expectedCurrency"`
+	ReconciliationStatus string   `json:"reconciliationStatus"` // MATCHED, PENDING_SETTLEMENT, MISMATCHED, UNMATCHED
+	Mismatches           []string `json:"mismatches"`
+	BatchID              string   `json:"batchId"` // Settlement file that last reported the payment, if any
+	RecordedAt           string   `json:"recordedAt"`
+	UpdatedAt            string   `json:"updatedAt"`
+}
+
+// SettlementBatch records one ingested processor settlement file
+type SettlementBatch struct {
+	BatchID           string   `json:"batchId"`
+	RowCount          int      `json:"rowCount"`
+	MatchedCount      int      `json:"matchedCount"`
+	UnreconciledCount int      `json:"unreconciledCount"`
+	PaymentIDs        []string `json:"paymentIds"`
+	IngestedAt        string   `json:"ingestedAt"`
+}
+
 // GlobalMetrics for common-channel (privacy-preserving)
 type GlobalMetrics struct {
 	MetricsID           string `json:"metricsId"`
@@ -165,6 +879,33 @@ type InvestorQuery struct {
 	AnsweredAt  string   `json:"answeredAt"`
 }
 
+// TreasuryTotals aggregates escrow amounts for one currency (and optionally one status)
+type TreasuryTotals struct {
+	Currency      string  `json:"currency"`
+	EscrowCount   int     `json:"escrowCount"`
+	TotalEscrowed float64 `json:"totalEscrowed"`
+	TotalReleased float64 `json:"totalReleased"`
+	TotalRefunded float64 `json:"totalRefunded"`
+	TotalHeld     float64 `json:"totalHeld"`
+}
+
+// EscrowStatement is an escrow together with every release and refund made from it
+type EscrowStatement struct {
+	Escrow   FundEscrow     `json:"escrow"`
+	Releases []FundRelease  `json:"releases"`
+	Refunds  []EscrowRefund `json:"refunds"`
+}
+
+// TreasuryReport reconciles escrowed, released, refunded and held funds for a scope
+type TreasuryReport struct {
+	Scope               string                                `json:"scope"` // CAMPAIGN, STARTUP, PLATFORM
+	ScopeID             string                                `json:"scopeId"`
+	ByCurrency          map[string]*TreasuryTotals            `json:"byCurrency"`
+	ByCurrencyAndStatus map[string]map[string]*TreasuryTotals `json:"byCurrencyAndStatus"`
+	Escrows             []EscrowStatement                     `json:"escrows"`
+	GeneratedAt         string                                `json:"generatedAt"`
+}
+
 // InitLedger initializes the PlatformOrg ledger
 func (p *PlatformContract) InitLedger(ctx contractapi.TransactionContextInterface) error {
 	fmt.Println("PlatformOrg contract initialized - Merged Version")
@@ -229,6 +970,7 @@ func (p *PlatformContract) PublishCampaignToPortal(
 		OpenDate:           openDate,
 		CloseDate:          closeDate,
 		DurationDays:       durationDays,
+		FundingModel:       "KEEP_IT_ALL", // Changed with RecordFundingModel before funds are escrowed
 		ValidationScore:    validationScore,
 		ValidationHash:     validationHash,
 		ValidationVerified: false, // Will be verified with Validator
@@ -237,6 +979,8 @@ func (p *PlatformContract) PublishCampaignToPortal(
 		TotalConfirmed:     0,
 		Milestones:         milestones,
 		AgreementIDs:       []string{},
+		RewardTierIDs:      []string{},
+		PledgeIDs:          []string{},
 		PublishedAt:        now,
 		UpdatedAt:          now,
 	}
@@ -277,6 +1021,75 @@ func (p *PlatformContract) PublishCampaignToPortal(
 	return string(responseJSON), nil
 }
 
+// RecordFundingModel sets how a campaign's escrow is settled at close, before any funds are escrowed
+// Invoked by StartupContract.SetFundingModel on common-channel
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (p *PlatformContract) RecordFundingModel(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	startupID string,
+	fundingModel string, // ALL_OR_NOTHING or KEEP_IT_ALL
+) (string, error) {
+	if err := requireMSP(ctx, "StartupOrgMSP"); err != nil {
+		return "", err
+	}
+
+	fundingModel, err := normalizeFundingModel(fundingModel)
+	if err != nil {
+		return "", err
+	}
+
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign PublishedCampaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+	if campaign.StartupID != startupID {
+		return "", fmt.Errorf("campaign %s does not belong to startup %s", campaignID, startupID)
+	}
+	if campaign.Status == "CLOSED" {
+		return "", fmt.Errorf("campaign %s is closed", campaignID)
+	}
+	if len(campaign.AgreementIDs) > 0 || len(campaign.PledgeIDs) > 0 {
+		return "", fmt.Errorf("campaign %s already holds escrowed funds and cannot change its funding model", campaignID)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	campaign.FundingModel = fundingModel
+	campaign.UpdatedAt = now
+
+	updatedCampaignJSON, err := json.Marshal(campaign)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(campaignID, updatedCampaignJSON)
+	if err != nil {
+		return "", err
+	}
+
+	response := map[string]interface{}{
+		"message":      "Funding model recorded",
+		"campaignId":   campaignID,
+		"fundingModel": fundingModel,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
 // VerifyAndPublish verifies campaign hash with ValidatorOrg and publishes
 // Step 5.1: Platform verifies with Validator before publishing
 // Channel: common-channel
@@ -328,6 +1141,11 @@ func (p *PlatformContract) VerifyAndPublish(
 		return "", err
 	}
 
+	// Index the open campaign for CloseExpiredCampaigns
+	if err := putOpenCampaignIndex(ctx, campaignID); err != nil {
+		return "", err
+	}
+
 	// Emit event
 	eventPayload := map[string]interface{}{
 		"campaignId":         campaignID,
@@ -349,6 +1167,108 @@ func (p *PlatformContract) VerifyAndPublish(
 	return string(responseJSON), nil
 }
 
+// RecordAgreementAcceptance records the term sheet a party accepted, checked against the hash it accepted
+// InvestorOrg and StartupOrg call it through their PublishAgreementAcceptance
+// Channel: common-channel
+// Endorsers: PlatformOrg, and InvestorOrg or StartupOrg
+func (p *PlatformContract) RecordAgreementAcceptance(
+	ctx contractapi.TransactionContextInterface,
+	agreementID string,
+	party string, // INVESTOR or STARTUP
+	termsJSON string,
+	termsHash string,
+) (string, error) {
+	partyMSPs := map[string]string{"INVESTOR": "InvestorOrgMSP", "STARTUP": "StartupOrgMSP"}
+	mspID, ok := partyMSPs[party]
+	if !ok {
+		return "", fmt.Errorf("invalid party: %s. Must be INVESTOR or STARTUP", party)
+	}
+	if err := requireMSP(ctx, mspID); err != nil {
+		return "", err
+	}
+
+	// The published terms must hash to what the party accepted on its own channel
+	terms, err := parseNegotiationTerms(termsJSON)
+	if err != nil {
+		return "", err
+	}
+	terms, recomputedHash, err := normalizeTerms(terms)
+	if err != nil {
+		return "", err
+	}
+	if recomputedHash != termsHash {
+		return "", fmt.Errorf("terms hash %s does not match the published terms, which hash to %s", termsHash, recomputedHash)
+	}
+
+	// Witnessed agreements keep the terms they were witnessed against
+	agreementJSON, err := ctx.GetStub().GetState(agreementID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read agreement: %v", err)
+	}
+	if agreementJSON != nil {
+		var agreement Agreement
+		if err := json.Unmarshal(agreementJSON, &agreement); err != nil {
+			return "", err
+		}
+		if agreement.PlatformWitnessed {
+			return "", fmt.Errorf("agreement %s has already been witnessed", agreementID)
+		}
+	}
+
+	acceptance, err := getAgreementAcceptance(ctx, agreementID)
+	if err != nil {
+		return "", err
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	if party == "INVESTOR" {
+		acceptance.InvestorTerms = terms
+		acceptance.InvestorTermsHash = termsHash
+		acceptance.InvestorAcceptedAt = now
+	} else {
+		acceptance.StartupTerms = terms
+		acceptance.StartupTermsHash = termsHash
+		acceptance.StartupAcceptedAt = now
+	}
+
+	acceptanceJSON, err := json.Marshal(acceptance)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(agreementAcceptanceKey(agreementID), acceptanceJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"agreementId": agreementID,
+		"party":       party,
+		"termsHash":   termsHash,
+		"channel":     "common-channel",
+		"action":      "AGREEMENT_ACCEPTANCE_RECORDED",
+		"timestamp":   now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("AgreementAcceptanceRecorded", eventJSON)
+
+	response := map[string]interface{}{
+		"message":           fmt.Sprintf("%s acceptance recorded", party),
+		"agreementId":       agreementID,
+		"party":             party,
+		"termsHash":         termsHash,
+		"investorTermsHash": acceptance.InvestorTermsHash,
+		"startupTermsHash":  acceptance.StartupTermsHash,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
 // WitnessAgreement records Platform as witness to startup-investor agreement
 // Step 9: Platform and Validator witness the agreement for multi-party visibility
 // Channel: common-channel
@@ -371,7 +1291,11 @@ func (p *PlatformContract) WitnessAgreement(
 	}
 
 	var agreement Agreement
-	now := time.Now().Format(time.RFC3339)
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
 
 	if existingJSON != nil {
 		// Update existing agreement
@@ -410,52 +1334,127 @@ func (p *PlatformContract) WitnessAgreement(
 		}
 	}
 
-	// Platform witnesses the agreement
-	agreement.PlatformWitnessed = true
-	agreement.WitnessedAt = now
-	agreement.Status = "ACTIVE"
-	agreement.AcceptedAt = now
-
-	agreementJSON, err := json.Marshal(agreement)
+	// Platform witnesses the term sheet both parties accepted; validators sign against its hash
+	acceptance, negotiatedTerms, termsHash, err := acceptedTerms(ctx, agreementID, investmentAmount, currency)
 	if err != nil {
 		return "", err
 	}
+	agreement.NegotiatedTerms = negotiatedTerms
+	agreement.TermsHash = termsHash
+	agreement.InvestorTermsHash = acceptance.InvestorTermsHash
+	agreement.StartupTermsHash = acceptance.StartupTermsHash
 
-	// Store agreement
-	err = ctx.GetStub().PutState(agreementID, agreementJSON)
+	// SAFEs and notes convert into shares later, in a priced round
+	instrument := negotiatedTerms.Instrument
+	if instrument.InstrumentType != "" {
+		agreement.InstrumentType = instrument.InstrumentType
+		agreement.Instrument = instrument
+	}
+
+	// Every agreement in a lending campaign is a loan under the campaign's terms
+	loan, err := getLoan(ctx, agreement.CampaignID)
 	if err != nil {
 		return "", err
 	}
+	if loan != nil {
+		if loan.Status != "OFFERED" {
+			return "", fmt.Errorf("loan for campaign %s has already been originated", agreement.CampaignID)
+		}
+		if instrument.InstrumentType != "" {
+			return "", fmt.Errorf("campaign %s is a lending campaign and cannot take a %s", agreement.CampaignID, instrument.InstrumentType)
+		}
+		agreement.InstrumentType = "LOAN"
+		agreement.Loan = LoanTerms{
+			Principal:        agreement.InvestmentAmount,
+			InterestRate:     loan.InterestRate,
+			TermMonths:       loan.TermMonths,
+			AmortizationType: loan.AmortizationType,
+		}
+	}
 
-	// Update campaign with agreement
-	campaignJSON, _ := ctx.GetStub().GetState(campaignID)
+	// Every agreement in a revenue-share campaign is repaid from the campaign's share of revenue
+	revenueShare, err := getRevenueShareOffer(ctx, agreement.CampaignID)
+	if err != nil {
+		return "", err
+	}
+	if revenueShare != nil {
+		if instrument.InstrumentType != "" {
+			return "", fmt.Errorf("campaign %s is a revenue-share campaign and cannot take a %s", agreement.CampaignID, instrument.InstrumentType)
+		}
+		agreement.InstrumentType = "REVENUE_SHARE"
+		agreement.RevenueShare = RevenueShareTerms{
+			RevenuePercent:     revenueShare.RevenuePercent,
+			CapMultiple:        revenueShare.CapMultiple,
+			CapAmount:          math.Round(agreement.InvestmentAmount*revenueShare.CapMultiple*100) / 100,
+			ReportingFrequency: revenueShare.ReportingFrequency,
+			NextReportDueAt:    revenueShare.FirstReportDueAt,
+			Status:             "ACTIVE",
+		}
+	}
+
+	// Platform witnesses the agreement
+	agreement.PlatformWitnessed = true
+	agreement.WitnessedAt = now
+	agreement.Status = "ACTIVE"
+	agreement.AcceptedAt = now
+
+	// Update campaign with agreement
+	campaignJSON, _ := ctx.GetStub().GetState(campaignID)
 	if campaignJSON != nil {
 		var campaign PublishedCampaign
 		json.Unmarshal(campaignJSON, &campaign)
+
+		// Equity campaigns issue the investor's shares into the startup cap table
+		err = issueAgreementShares(ctx, &agreement, &campaign, now)
+		if err != nil {
+			return "", err
+		}
+
 		campaign.AgreementIDs = append(campaign.AgreementIDs, agreementID)
 		campaign.UpdatedAt = now
 		updatedCampaignJSON, _ := json.Marshal(campaign)
 		ctx.GetStub().PutState(campaignID, updatedCampaignJSON)
 	}
 
+	agreementJSON, err := json.Marshal(agreement)
+	if err != nil {
+		return "", err
+	}
+
+	// Store agreement
+	err = ctx.GetStub().PutState(agreementID, agreementJSON)
+	if err != nil {
+		return "", err
+	}
+
 	// Create escrow for the funds
 	escrow := FundEscrow{
-		EscrowID:       fmt.Sprintf("ESCROW_%s", agreementID),
-		AgreementID:    agreementID,
-		CampaignID:     campaignID,
-		InvestorID:     investorID,
-		StartupID:      startupID,
-		TotalAmount:    investmentAmount,
-		ReleasedAmount: 0,
-		HeldAmount:     investmentAmount,
-		Currency:       currency,
-		Status:         "ACTIVE",
-		CreatedAt:      now,
-		UpdatedAt:      now,
+		EscrowID:          fmt.Sprintf("ESCROW_%s", agreementID),
+		AgreementID:       agreementID,
+		CampaignID:        campaignID,
+		InvestorID:        investorID,
+		StartupID:         startupID,
+		TotalAmount:       investmentAmount,
+		ReleasedAmount:    0,
+		HeldAmount:        investmentAmount,
+		PendingReleaseIDs: []string{},
+		Currency:          currency,
+		Status:            "ACTIVE",
+		CreatedAt:         now,
+		UpdatedAt:         now,
 	}
 	escrowJSON, _ := json.Marshal(escrow)
 	ctx.GetStub().PutState(escrow.EscrowID, escrowJSON)
 
+	// Investor tokens are locked into escrow (fails if the wallet balance is too low)
+	err = postJournalEntry(ctx, "ESCROW_FUNDED", escrow.EscrowID, campaignID, currency, []JournalLine{
+		{Account: escrowAccount(escrow.EscrowID), Debit: investmentAmount},
+		{Account: investorWalletAccount(investorID), Credit: investmentAmount},
+	}, fmt.Sprintf("Agreement %s witnessed", agreementID), now)
+	if err != nil {
+		return "", err
+	}
+
 	// Emit event
 	eventPayload := map[string]interface{}{
 		"agreementId":      agreementID,
@@ -464,6 +1463,7 @@ func (p *PlatformContract) WitnessAgreement(
 		"investorId":       investorID,
 		"investmentAmount": investmentAmount,
 		"escrowId":         escrow.EscrowID,
+		"termsHash":        termsHash,
 		"status":           "ACTIVE",
 		"channel":          "common-channel",
 		"action":           "AGREEMENT_WITNESSED",
@@ -477,6 +1477,7 @@ func (p *PlatformContract) WitnessAgreement(
 		"agreementId":      agreementID,
 		"escrowId":         escrow.EscrowID,
 		"investmentAmount": investmentAmount,
+		"termsHash":        termsHash,
 		"status":           "ACTIVE",
 	}
 	responseJSON, _ := json.Marshal(response)
@@ -485,6 +1486,7 @@ func (p *PlatformContract) WitnessAgreement(
 
 // TriggerFundRelease releases funds to startup based on milestone completion
 // Step 13: Platform releases funds from escrow when milestone is verified
+// Releases above the approval policy threshold wait in PENDING_APPROVAL for ApproveFundRelease
 // Channel: common-channel
 // Endorsers: PlatformOrg (multi-party visibility)
 func (p *PlatformContract) TriggerFundRelease(
@@ -499,6 +1501,18 @@ func (p *PlatformContract) TriggerFundRelease(
 	currency string,
 	triggerReason string,
 ) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+
+	existingJSON, err := ctx.GetStub().GetState(releaseID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read release: %v", err)
+	}
+	if existingJSON != nil {
+		return "", fmt.Errorf("release %s already exists", releaseID)
+	}
+
 	// Retrieve escrow
 	escrowJSON, err := ctx.GetStub().GetState(escrowID)
 	if err != nil {
@@ -514,12 +1528,34 @@ func (p *PlatformContract) TriggerFundRelease(
 		return "", err
 	}
 
-	// Check sufficient funds in escrow
-	if amount > escrow.HeldAmount {
-		return "", fmt.Errorf("insufficient funds in escrow. Available: %f, Requested: %f", escrow.HeldAmount, amount)
+	// The release is recorded against the escrow's own agreement, campaign, startup and currency
+	if agreementID != escrow.AgreementID || campaignID != escrow.CampaignID || startupID != escrow.StartupID || currency != escrow.Currency {
+		return "", fmt.Errorf("release does not match escrow %s: agreement %s, campaign %s, startup %s, currency %s", escrowID, escrow.AgreementID, escrow.CampaignID, escrow.StartupID, escrow.Currency)
 	}
 
-	now := time.Now().Format(time.RFC3339)
+	// Frozen escrows are on hold for a dispute or fraud investigation
+	if err := checkEscrowNotFrozen(escrow); err != nil {
+		return "", err
+	}
+	if err := checkCampaignNotBlacklisted(ctx, escrow.CampaignID); err != nil {
+		return "", err
+	}
+
+	// Check sufficient funds in escrow (funds reserved by pending releases are not available)
+	if amount > availableEscrowAmount(escrow) {
+		return "", fmt.Errorf("insufficient funds in escrow. Available: %f, Requested: %f", availableEscrowAmount(escrow), amount)
+	}
+
+	// All-or-nothing campaigns release only after closing with the goal met
+	if err := checkFundingModelAllowsRelease(ctx, escrow.CampaignID); err != nil {
+		return "", err
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
 
 	// Create fund release record
 	release := FundRelease{
@@ -531,76 +1567,35 @@ func (p *PlatformContract) TriggerFundRelease(
 		StartupID:     startupID,
 		Amount:        amount,
 		Currency:      currency,
-		Status:        "RELEASED",
-		TriggerReason: triggerReason,
-		ReleasedAt:    now,
+		TriggerReason:      triggerReason,
+		RequiredSignatures: map[string]int{},
+		Approvals:          []ReleaseApproval{},
+		RequestedAt:        now,
 	}
 
-	releaseJSON, err := json.Marshal(release)
+	// Validator and investor must both have approved the milestone
+	evidence, err := collectReleaseEvidence(ctx, release, escrow, now)
 	if err != nil {
 		return "", err
 	}
+	release.Evidence = *evidence
 
-	// Store release record
-	err = ctx.GetStub().PutState(releaseID, releaseJSON)
+	// Large releases need N-of-M approver signatures before funds move
+	policy, err := getReleaseApprovalPolicy(ctx)
 	if err != nil {
 		return "", err
 	}
-
-	// Update escrow
-	escrow.ReleasedAmount += amount
-	escrow.HeldAmount -= amount
-	escrow.UpdatedAt = now
-	if escrow.HeldAmount <= 0 {
-		escrow.Status = "FULLY_RELEASED"
-	} else {
-		escrow.Status = "PARTIALLY_RELEASED"
+	if policy.ThresholdAmount > 0 && amount > policy.ThresholdAmount {
+		return requestReleaseApproval(ctx, &release, &escrow, policy)
 	}
 
-	updatedEscrowJSON, _ := json.Marshal(escrow)
-	ctx.GetStub().PutState(escrowID, updatedEscrowJSON)
-
-	// Update campaign
-	campaignJSON, err := ctx.GetStub().GetState(campaignID)
-	if err == nil && campaignJSON != nil {
-		var campaign PublishedCampaign
-		json.Unmarshal(campaignJSON, &campaign)
-		
-		// Update milestone status
-		for i, m := range campaign.Milestones {
-			if m.MilestoneID == milestoneID {
-				campaign.Milestones[i].FundsReleased = true
-				campaign.Milestones[i].ReleasedAt = now
-				campaign.Milestones[i].Status = "VERIFIED"
-				break
-			}
-		}
-		
-		campaign.FundsRaisedAmount += amount
-		if campaign.GoalAmount > 0 {
-			campaign.FundsRaisedPercent = (campaign.FundsRaisedAmount / campaign.GoalAmount) * 100
-		}
-		campaign.Status = "FUNDED"
-		campaign.UpdatedAt = now
-		updatedCampaignJSON, _ := json.Marshal(campaign)
-		ctx.GetStub().PutState(campaignID, updatedCampaignJSON)
+	err = executeFundRelease(ctx, &release, &escrow, now)
+	if err != nil {
+		return "", err
 	}
 
 	// Emit event
-	eventPayload := map[string]interface{}{
-		"releaseId":     releaseID,
-		"escrowId":      escrowID,
-		"agreementId":   agreementID,
-		"campaignId":    campaignID,
-		"milestoneId":   milestoneID,
-		"startupId":     startupID,
-		"amount":        amount,
-		"triggerReason": triggerReason,
-		"channel":       "common-channel",
-		"action":        "FUNDS_RELEASED",
-		"timestamp":     now,
-	}
-	eventJSON, _ := json.Marshal(eventPayload)
+	eventJSON, _ := json.Marshal(fundsReleasedEventPayload(release, now))
 	ctx.GetStub().SetEvent("FundsReleased", eventJSON)
 
 	response := map[string]interface{}{
@@ -608,6 +1603,8 @@ func (p *PlatformContract) TriggerFundRelease(
 		"releaseId":     releaseID,
 		"milestoneId":   milestoneID,
 		"amount":        amount,
+		"feeAmount":     release.FeeAmount,
+		"netAmount":     release.NetAmount,
 		"escrowBalance": escrow.HeldAmount,
 		"status":        "RELEASED",
 	}
@@ -628,42 +1625,48 @@ func (p *PlatformContract) CloseCampaign(
 	finalInvestorCount int,
 	closureReason string,
 ) (string, error) {
-	// Create closure record
-	closure := CampaignClosure{
-		ClosureID:          closureID,
-		CampaignID:         campaignID,
-		FinalStatus:        finalStatus,
-		FinalAmount:        finalAmount,
-		FinalInvestorCount: finalInvestorCount,
-		ClosureReason:      closureReason,
-		ClosedAt:           time.Now().Format(time.RFC3339),
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
 	}
 
-	closureJSON, err := json.Marshal(closure)
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("failed to read campaign: %v", err)
 	}
+	if campaignJSON != nil {
+		var campaign PublishedCampaign
+		if err := json.Unmarshal(campaignJSON, &campaign); err != nil {
+			return "", err
+		}
+		if campaign.Status == "CLOSED" {
+			return "", fmt.Errorf("campaign %s is already closed", campaignID)
+		}
 
-	// Store closure record
-	err = ctx.GetStub().PutState(closureID, closureJSON)
+		// The funding model applies to manual closes as well: all-or-nothing campaigns can only
+		// close SUCCESSFUL when escrowed funds meet the goal, and keep-it-all campaigns that raised
+		// funds keep them (PARTIALLY_FUNDED) instead of being refunded as FAILED
+		if finalStatus == "SUCCESSFUL" || finalStatus == "FAILED" {
+			raised, _, err := campaignEscrowTotals(ctx, campaign)
+			if err != nil {
+				return "", err
+			}
+			if finalStatus == "SUCCESSFUL" && isAllOrNothing(campaign) && raised < campaign.GoalAmount {
+				return "", fmt.Errorf("all-or-nothing campaign %s raised %.2f of %.2f and cannot close SUCCESSFUL", campaignID, raised, campaign.GoalAmount)
+			}
+			if finalStatus == "FAILED" && !isAllOrNothing(campaign) && raised > 0 {
+				finalStatus = "PARTIALLY_FUNDED"
+			}
+		}
+	}
+
+	txTime, err := getTxTime(ctx)
 	if err != nil {
 		return "", err
 	}
 
-	// Update campaign status
-	campaignJSON, err := ctx.GetStub().GetState(campaignID)
-	if err == nil && campaignJSON != nil {
-		var campaign PublishedCampaign
-		json.Unmarshal(campaignJSON, &campaign)
-		campaign.Status = "CLOSED"
-		campaign.FundsRaisedAmount = finalAmount
-		campaign.InvestorCount = finalInvestorCount
-		if campaign.GoalAmount > 0 {
-			campaign.FundsRaisedPercent = (finalAmount / campaign.GoalAmount) * 100
-		}
-		campaign.UpdatedAt = time.Now().Format(time.RFC3339)
-		updatedCampaignJSON, _ := json.Marshal(campaign)
-		ctx.GetStub().PutState(campaignID, updatedCampaignJSON)
+	closure, refunds, err := closeCampaign(ctx, closureID, campaignID, finalStatus, finalAmount, finalInvestorCount, closureReason, txTime.Format(time.RFC3339))
+	if err != nil {
+		return "", err
 	}
 
 	// Emit event
@@ -671,7 +1674,9 @@ func (p *PlatformContract) CloseCampaign(
 		"closureId":     closureID,
 		"campaignId":    campaignID,
 		"finalStatus":   finalStatus,
+		"finalAmount":   closure.FinalAmount,
 		"closureReason": closureReason,
+		"refunds":       refundEventEntries(refunds),
 		"channel":       "common-channel",
 		"action":        "CAMPAIGN_CLOSED",
 		"timestamp":     closure.ClosedAt,
@@ -684,468 +1689,8576 @@ func (p *PlatformContract) CloseCampaign(
 		"closureId":   closureID,
 		"campaignId":  campaignID,
 		"finalStatus": finalStatus,
+		"finalAmount": closure.FinalAmount,
+		"refundCount": len(refunds),
 	}
 	responseJSON, _ := json.Marshal(response)
 	return string(responseJSON), nil
 }
 
-// ============================================================================
-// INVESTOR-PLATFORM-CHANNEL FUNCTIONS
-// Endorsed by: InvestorOrg, PlatformOrg
-// ============================================================================
-
-// RecordInvestorConfirmation records investment confirmation from InvestorOrg
-// Channel: investor-platform-channel
-// Endorsers: InvestorOrg, PlatformOrg
-func (p *PlatformContract) RecordInvestorConfirmation(
-	ctx contractapi.TransactionContextInterface,
-	recordID string,
-	confirmationID string,
-	campaignID string,
-	investorID string,
-	amount float64,
-	currency string,
-) (string, error) {
-	// Create confirmation record
-	record := InvestorConfirmationRecord{
-		RecordID:       recordID,
-		ConfirmationID: confirmationID,
-		CampaignID:     campaignID,
-		InvestorID:     investorID,
-		Amount:         amount,
-		Currency:       currency,
-		RecordedAt:     time.Now().Format(time.RFC3339),
+// CloseExpiredCampaigns closes every open campaign whose funding window has ended
+// Final status is SUCCESSFUL when escrowed funds reach the goal; below goal, all-or-nothing
+// campaigns are FAILED (and refunded) and keep-it-all campaigns are PARTIALLY_FUNDED
+// Closure IDs are CLOSURE_<campaignId>; a single CampaignsClosed event lists all closures
+// Campaigns come from the open campaign index (a range scan, re-validated at commit), not a rich query
+// Channel: common-channel
+// Endorsers: PlatformOrg (multi-party visibility)
+func (p *PlatformContract) CloseExpiredCampaigns(ctx contractapi.TransactionContextInterface) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
 	}
 
-	recordJSON, err := json.Marshal(record)
+	txTime, err := getTxTime(ctx)
 	if err != nil {
 		return "", err
 	}
+	now := txTime.Format(time.RFC3339)
 
-	// Store on investor-platform-channel
-	err = ctx.GetStub().PutState(recordID, recordJSON)
+	campaignIDs, err := getOpenCampaignIDs(ctx)
 	if err != nil {
 		return "", err
 	}
 
-	// Update campaign funding totals
-	campaignJSON, err := ctx.GetStub().GetState(campaignID)
-	if err == nil && campaignJSON != nil {
+	var campaigns []PublishedCampaign
+	for _, campaignID := range campaignIDs {
+		campaignJSON, err := ctx.GetStub().GetState(campaignID)
+		if err != nil {
+			return "", fmt.Errorf("failed to read campaign: %v", err)
+		}
 		var campaign PublishedCampaign
-		json.Unmarshal(campaignJSON, &campaign)
-		campaign.TotalConfirmed += amount
-		campaign.FundsRaisedAmount += amount
-		campaign.InvestorCount++
-		if campaign.GoalAmount > 0 {
-			campaign.FundsRaisedPercent = (campaign.FundsRaisedAmount / campaign.GoalAmount) * 100
+		if campaignJSON == nil || json.Unmarshal(campaignJSON, &campaign) != nil {
+			continue
+		}
+		// Campaigns that already closed are never closed again
+		if campaign.Status == "CLOSED" || campaign.FinalStatus != "" {
+			continue
+		}
+		if campaign.Status == "PUBLISHED" || campaign.Status == "ACTIVE" || campaign.Status == "FUNDED" {
+			campaigns = append(campaigns, campaign)
 		}
-		campaign.UpdatedAt = time.Now().Format(time.RFC3339)
-		updatedCampaignJSON, _ := json.Marshal(campaign)
-		ctx.GetStub().PutState(campaignID, updatedCampaignJSON)
 	}
 
-	// Emit event
-	eventPayload := map[string]interface{}{
-		"recordId":       recordID,
-		"confirmationId": confirmationID,
-		"campaignId":     campaignID,
-		"investorId":     investorID,
-		"channel":        "investor-platform-channel",
-		"action":         "INVESTOR_CONFIRMATION_RECORDED",
-		"timestamp":      record.RecordedAt,
+	closures := []map[string]interface{}{}
+	for _, campaign := range campaigns {
+		_, closesAt := campaignWindow(campaign.OpenDate, campaign.CloseDate, campaign.DurationDays)
+		if closesAt.IsZero() || txTime.Before(closesAt) {
+			continue
+		}
+
+		raised, investorCount, err := campaignEscrowTotals(ctx, campaign)
+		if err != nil {
+			return "", err
+		}
+
+		// All-or-nothing fails (and refunds) below goal; keep-it-all keeps whatever was raised
+		finalStatus := "FAILED"
+		if campaign.GoalAmount > 0 && raised >= campaign.GoalAmount {
+			finalStatus = "SUCCESSFUL"
+		} else if !isAllOrNothing(campaign) && raised > 0 {
+			finalStatus = "PARTIALLY_FUNDED"
+		}
+		reason := fmt.Sprintf("Funding window closed at %s", closesAt.Format(time.RFC3339))
+
+		closure, refunds, err := closeCampaign(ctx, fmt.Sprintf("CLOSURE_%s", campaign.CampaignID), campaign.CampaignID, finalStatus, raised, investorCount, reason, now)
+		if err != nil {
+			return "", err
+		}
+
+		closures = append(closures, map[string]interface{}{
+			"closureId":     closure.ClosureID,
+			"campaignId":    closure.CampaignID,
+			"finalStatus":   closure.FinalStatus,
+			"finalAmount":   closure.FinalAmount,
+			"closureReason": closure.ClosureReason,
+			"refunds":       refundEventEntries(refunds),
+		})
+	}
+
+	// Emit one event for the whole sweep (Fabric keeps only the last event per transaction)
+	if len(closures) > 0 {
+		eventPayload := map[string]interface{}{
+			"closures":  closures,
+			"channel":   "common-channel",
+			"action":    "CAMPAIGNS_CLOSED",
+			"timestamp": now,
+		}
+		eventJSON, _ := json.Marshal(eventPayload)
+		ctx.GetStub().SetEvent("CampaignsClosed", eventJSON)
 	}
-	eventJSON, _ := json.Marshal(eventPayload)
-	ctx.GetStub().SetEvent("InvestorConfirmationRecorded", eventJSON)
 
 	response := map[string]interface{}{
-		"message":    "Investor confirmation recorded",
-		"recordId":   recordID,
-		"campaignId": campaignID,
+		"message":     fmt.Sprintf("Closed %d expired campaigns", len(closures)),
+		"closedCount": len(closures),
+		"closures":    closures,
 	}
 	responseJSON, _ := json.Marshal(response)
 	return string(responseJSON), nil
 }
 
-// ============================================================================
-// VALIDATOR-PLATFORM-CHANNEL FUNCTIONS
-// Endorsed by: ValidatorOrg, PlatformOrg
-// ============================================================================
-
-// RecordValidatorDecision records final validation decision from ValidatorOrg
-// Channel: validator-platform-channel
-// Endorsers: ValidatorOrg, PlatformOrg
-func (p *PlatformContract) RecordValidatorDecision(
+// RefundEscrow returns all funds still held in an escrow to the investor
+// Used for investor withdrawals and one-off refunds; investors can withdraw until the campaign closes,
+// and escrows of campaigns that closed SUCCESSFUL or PARTIALLY_FUNDED are not refundable
+// Channel: common-channel
+// Endorsers: PlatformOrg (multi-party visibility)
+func (p *PlatformContract) RefundEscrow(
 	ctx contractapi.TransactionContextInterface,
-	recordID string,
-	campaignID string,
-	validationID string,
-	approved bool,
-	overallScore float64,
-	reportHash string,
+	refundID string,
+	escrowID string,
+	reason string,
 ) (string, error) {
-	// Create decision record
-	record := ValidatorDecisionRecord{
-		RecordID:     recordID,
-		CampaignID:   campaignID,
-		ValidationID: validationID,
-		Approved:     approved,
-		OverallScore: overallScore,
-		ReportHash:   reportHash,
-		RecordedAt:   time.Now().Format(time.RFC3339),
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
 	}
 
-	recordJSON, err := json.Marshal(record)
+	escrowJSON, err := ctx.GetStub().GetState(escrowID)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("failed to read escrow: %v", err)
+	}
+	if escrowJSON == nil {
+		return "", fmt.Errorf("escrow %s does not exist", escrowID)
 	}
 
-	// Store on validator-platform-channel
-	err = ctx.GetStub().PutState(recordID, recordJSON)
+	var escrow FundEscrow
+	err = json.Unmarshal(escrowJSON, &escrow)
 	if err != nil {
 		return "", err
 	}
 
-	// Store by campaign for lookup
-	decisionKey := fmt.Sprintf("DECISION_%s", campaignID)
-	err = ctx.GetStub().PutState(decisionKey, recordJSON)
+	if err := checkCampaignAllowsRefund(ctx, escrow.CampaignID); err != nil {
+		return "", err
+	}
+
+	txTime, err := getTxTime(ctx)
 	if err != nil {
 		return "", err
 	}
+	now := txTime.Format(time.RFC3339)
 
-	// Emit event
+	refund, err := refundEscrow(ctx, &escrow, refundID, reason, now)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event for InvestorOrg reconciliation
 	eventPayload := map[string]interface{}{
-		"recordId":     recordID,
-		"campaignId":   campaignID,
-		"validationId": validationID,
-		"approved":     approved,
-		"overallScore": overallScore,
-		"channel":      "validator-platform-channel",
-		"action":       "VALIDATOR_DECISION_RECORDED",
-		"timestamp":    record.RecordedAt,
+		"campaignId": escrow.CampaignID,
+		"reason":     reason,
+		"refunds":    refundEventEntries([]EscrowRefund{*refund}),
+		"channel":    "common-channel",
+		"action":     "ESCROW_REFUNDED",
+		"timestamp":  now,
 	}
 	eventJSON, _ := json.Marshal(eventPayload)
-	ctx.GetStub().SetEvent("ValidatorDecisionRecorded", eventJSON)
+	ctx.GetStub().SetEvent("EscrowRefunded", eventJSON)
 
 	response := map[string]interface{}{
-		"message":      "Validator decision recorded",
-		"recordId":     recordID,
-		"campaignId":   campaignID,
-		"approved":     approved,
-		"overallScore": overallScore,
+		"message":    "Escrow refunded to investor",
+		"refundId":   refundID,
+		"escrowId":   escrowID,
+		"investorId": escrow.InvestorID,
+		"amount":     refund.Amount,
+		"currency":   refund.Currency,
+		"status":     escrow.Status,
 	}
 	responseJSON, _ := json.Marshal(response)
 	return string(responseJSON), nil
 }
 
-// ============================================================================
-// COMMON-CHANNEL FUNCTIONS
-// Read by: All Orgs, Write by: PlatformOrg (aggregated metrics only - privacy preserving)
-// ============================================================================
-
-// PublishGlobalMetrics publishes aggregated system-wide metrics to common-channel
+// RefundCampaignEscrows refunds every escrow of a campaign that still holds funds
+// Refund IDs are REFUND_<escrowId>; a single EscrowRefunded event lists all refunds
+// Campaigns that closed SUCCESSFUL or PARTIALLY_FUNDED are not refunded
 // Channel: common-channel
-// Purpose: Privacy-preserving global metrics (never raw data)
-func (p *PlatformContract) PublishGlobalMetrics(
+// Endorsers: PlatformOrg (multi-party visibility)
+func (p *PlatformContract) RefundCampaignEscrows(
 	ctx contractapi.TransactionContextInterface,
-	metricsID string,
-	totalCampaigns int,
-	activeCampaigns int,
-	successfulCampaigns int,
-	totalInvestorCount int,
+	campaignID string,
+	reason string,
 ) (string, error) {
-	// Generate metrics hash (no sensitive data)
-	metricsData := map[string]interface{}{
-		"metricsId":           metricsID,
-		"totalCampaigns":      totalCampaigns,
-		"activeCampaigns":     activeCampaigns,
-		"successfulCampaigns": successfulCampaigns,
-		"totalInvestorCount":  totalInvestorCount,
-		"timestamp":           time.Now().Format(time.RFC3339),
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
 	}
-	metricsDataJSON, _ := json.Marshal(metricsData)
-	metricsHash := generateHash(string(metricsDataJSON))
 
-	// Create global metrics
-	metrics := GlobalMetrics{
-		MetricsID:           metricsID,
-		TotalCampaigns:      totalCampaigns,
-		ActiveCampaigns:     activeCampaigns,
-		SuccessfulCampaigns: successfulCampaigns,
-		TotalInvestorCount:  totalInvestorCount,
-		MetricsHash:         metricsHash,
-		PublishedAt:         time.Now().Format(time.RFC3339),
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+	var campaign PublishedCampaign
+	if err := json.Unmarshal(campaignJSON, &campaign); err != nil {
+		return "", err
 	}
 
-	metricsJSON, err := json.Marshal(metrics)
+	if err := checkCampaignAllowsRefund(ctx, campaignID); err != nil {
+		return "", err
+	}
+
+	txTime, err := getTxTime(ctx)
 	if err != nil {
 		return "", err
 	}
+	now := txTime.Format(time.RFC3339)
 
-	// Store on common-channel
-	err = ctx.GetStub().PutState(metricsID, metricsJSON)
+	refunds, err := refundCampaignEscrows(ctx, campaignID, reason, now)
 	if err != nil {
 		return "", err
 	}
 
-	// Also store as latest metrics
-	err = ctx.GetStub().PutState("COMMON_GLOBAL_METRICS_LATEST", metricsJSON)
+	totals := map[string]float64{}
+	for _, refund := range refunds {
+		totals[refund.Currency] += refund.Amount
+	}
+
+	// Frozen escrows were skipped
+	escrows, err := campaignEscrows(ctx, campaign)
 	if err != nil {
 		return "", err
 	}
+	frozenEscrowIDs := []string{}
+	for _, escrow := range escrows {
+		if escrow.Status == "FROZEN" {
+			frozenEscrowIDs = append(frozenEscrowIDs, escrow.EscrowID)
+		}
+	}
 
-	// Emit event
+	// Emit one event for the whole batch (Fabric keeps only the last event per transaction)
 	eventPayload := map[string]interface{}{
-		"metricsId":           metricsID,
-		"totalCampaigns":      totalCampaigns,
-		"activeCampaigns":     activeCampaigns,
-		"successfulCampaigns": successfulCampaigns,
-		"totalInvestorCount":  totalInvestorCount,
-		"metricsHash":         metricsHash,
-		"channel":             "common-channel",
-		"action":              "GLOBAL_METRICS_PUBLISHED",
-		"timestamp":           metrics.PublishedAt,
+		"campaignId":      campaignID,
+		"reason":          reason,
+		"refunds":         refundEventEntries(refunds),
+		"frozenEscrowIds": frozenEscrowIDs,
+		"channel":         "common-channel",
+		"action":          "ESCROW_REFUNDED",
+		"timestamp":       now,
 	}
 	eventJSON, _ := json.Marshal(eventPayload)
-	ctx.GetStub().SetEvent("GlobalMetricsPublished", eventJSON)
+	ctx.GetStub().SetEvent("EscrowRefunded", eventJSON)
 
 	response := map[string]interface{}{
-		"message":             "Global metrics published to common channel",
-		"metricsId":           metricsID,
-		"totalCampaigns":      totalCampaigns,
-		"activeCampaigns":     activeCampaigns,
-		"successfulCampaigns": successfulCampaigns,
-		"metricsHash":         metricsHash,
-		"channel":             "common-channel",
+		"message":          fmt.Sprintf("Refunded %d escrows", len(refunds)),
+		"campaignId":       campaignID,
+		"refundCount":      len(refunds),
+		"totalsByCurrency": totals,
+		"frozenEscrowIds":  frozenEscrowIDs,
 	}
 	responseJSON, _ := json.Marshal(response)
 	return string(responseJSON), nil
 }
 
 // ============================================================================
-// QUERY FUNCTIONS
+// ESCROW HOLDS
+// Frozen escrows block releases and refunds until the hold is resolved
 // ============================================================================
 
-// GetPublishedCampaign retrieves published campaign by ID
-func (p *PlatformContract) GetPublishedCampaign(ctx contractapi.TransactionContextInterface, campaignID string) (*PublishedCampaign, error) {
-	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+// FreezeEscrow puts an escrow on hold, blocking releases and refunds
+// PlatformOrg opens holds as PLATFORM; the escrow's investor can open one for a milestone dispute,
+// recorded under the investorId attribute of the caller's identity
+// Channel: common-channel
+// Endorsers: PlatformOrg (multi-party visibility)
+func (p *PlatformContract) FreezeEscrow(
+	ctx contractapi.TransactionContextInterface,
+	holdID string,
+	escrowID string,
+	reason string,
+) (string, error) {
+	escrowJSON, err := ctx.GetStub().GetState(escrowID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read campaign: %v", err)
+		return "", fmt.Errorf("failed to read escrow: %v", err)
 	}
-	if campaignJSON == nil {
-		return nil, fmt.Errorf("campaign %s does not exist", campaignID)
+	if escrowJSON == nil {
+		return "", fmt.Errorf("escrow %s does not exist", escrowID)
 	}
 
-	var campaign PublishedCampaign
-	err = json.Unmarshal(campaignJSON, &campaign)
+	var escrow FundEscrow
+	err = json.Unmarshal(escrowJSON, &escrow)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return &campaign, nil
+	mspID, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		return "", fmt.Errorf("failed to read client MSP ID: %v", err)
+	}
+	openedBy := "PLATFORM"
+	switch mspID {
+	case "PlatformOrgMSP":
+	case "InvestorOrgMSP":
+		if err := requireInvestorIdentity(ctx, escrow.InvestorID); err != nil {
+			return "", err
+		}
+		openedBy = escrow.InvestorID
+	default:
+		return "", fmt.Errorf("only PlatformOrgMSP or the escrow's investor can freeze an escrow, caller is %s", mspID)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	hold, err := freezeEscrow(ctx, &escrow, holdID, reason, openedBy, mspID, now)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"campaignId": escrow.CampaignID,
+		"reason":     reason,
+		"openedBy":   openedBy,
+		"holds":      holdEventEntries([]EscrowHold{*hold}),
+		"channel":    "common-channel",
+		"action":     "ESCROW_FROZEN",
+		"timestamp":  now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("EscrowFrozen", eventJSON)
+
+	response := map[string]interface{}{
+		"message":    "Escrow frozen. Releases and refunds are blocked until the hold is resolved.",
+		"holdId":     holdID,
+		"escrowId":   escrowID,
+		"heldAmount": escrow.HeldAmount,
+		"status":     escrow.Status,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
 }
 
-// GetActiveCampaigns returns all active published campaigns
-func (p *PlatformContract) GetActiveCampaigns(ctx contractapi.TransactionContextInterface) (string, error) {
-	queryString := `{"selector":{"status":"PUBLISHED"}}`
+// FreezeCampaignEscrows puts every escrow of a campaign that still holds funds on hold
+// Hold IDs are <holdId>_<escrowId>; a single EscrowFrozen event lists all holds
+// Only PlatformOrg freezes whole campaigns; the holds are opened by PLATFORM
+// Channel: common-channel
+// Endorsers: PlatformOrg (multi-party visibility)
+func (p *PlatformContract) FreezeCampaignEscrows(
+	ctx contractapi.TransactionContextInterface,
+	holdID string,
+	campaignID string,
+	reason string,
+) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
 
-	resultsIterator, err := ctx.GetStub().GetQueryResult(queryString)
+	escrows, err := getEscrows(ctx, map[string]interface{}{
+		"campaignId": campaignID,
+		"heldAmount": map[string]interface{}{"$gt": 0},
+	})
 	if err != nil {
 		return "", err
 	}
-	defer resultsIterator.Close()
 
-	var campaigns []map[string]interface{}
-	for resultsIterator.HasNext() {
-		queryResponse, err := resultsIterator.Next()
-		if err != nil {
-			return "", err
-		}
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+	openedBy := "PLATFORM"
 
-		var campaign PublishedCampaign
-		err = json.Unmarshal(queryResponse.Value, &campaign)
-		if err != nil {
+	holds := []EscrowHold{}
+	for idx := range escrows {
+		escrow := &escrows[idx]
+		if escrow.Status == "FROZEN" || escrow.Status == "REFUNDED" || escrow.Status == "FULLY_RELEASED" {
 			continue
 		}
-
-		campaignMap := map[string]interface{}{
-			"Key":    queryResponse.Key,
-			"Record": campaign,
+		hold, err := freezeEscrow(ctx, escrow, fmt.Sprintf("%s_%s", holdID, escrow.EscrowID), reason, openedBy, "PlatformOrgMSP", now)
+		if err != nil {
+			return "", err
 		}
-		campaigns = append(campaigns, campaignMap)
+		holds = append(holds, *hold)
 	}
 
-	campaignsJSON, err := json.Marshal(campaigns)
-	if err != nil {
-		return "", err
+	// Emit one event for the whole campaign (Fabric keeps only the last event per transaction)
+	eventPayload := map[string]interface{}{
+		"campaignId": campaignID,
+		"reason":     reason,
+		"openedBy":   openedBy,
+		"holds":      holdEventEntries(holds),
+		"channel":    "common-channel",
+		"action":     "ESCROW_FROZEN",
+		"timestamp":  now,
 	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("EscrowFrozen", eventJSON)
 
-	return string(campaignsJSON), nil
+	response := map[string]interface{}{
+		"message":     fmt.Sprintf("Froze %d escrows", len(holds)),
+		"campaignId":  campaignID,
+		"frozenCount": len(holds),
+		"holds":       holdEventEntries(holds),
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
 }
 
-// GetValidatorDecision retrieves validator decision for a campaign
-func (p *PlatformContract) GetValidatorDecision(ctx contractapi.TransactionContextInterface, campaignID string) (*ValidatorDecisionRecord, error) {
-	decisionKey := fmt.Sprintf("DECISION_%s", campaignID)
-	decisionJSON, err := ctx.GetStub().GetState(decisionKey)
+// UnfreezeEscrow lifts the active hold on an escrow and restores its previous status
+// A resolution record explaining the outcome is required
+// Channel: common-channel
+// Endorsers: PlatformOrg (multi-party visibility)
+func (p *PlatformContract) UnfreezeEscrow(
+	ctx contractapi.TransactionContextInterface,
+	resolutionID string,
+	escrowID string,
+	resolution string,
+	resolvedBy string,
+) (string, error) {
+	escrowJSON, err := ctx.GetStub().GetState(escrowID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read decision: %v", err)
+		return "", fmt.Errorf("failed to read escrow: %v", err)
 	}
-	if decisionJSON == nil {
-		return nil, fmt.Errorf("validator decision for campaign %s does not exist", campaignID)
+	if escrowJSON == nil {
+		return "", fmt.Errorf("escrow %s does not exist", escrowID)
 	}
 
-	var decision ValidatorDecisionRecord
-	err = json.Unmarshal(decisionJSON, &decision)
+	var escrow FundEscrow
+	err = json.Unmarshal(escrowJSON, &escrow)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return &decision, nil
+	return resolveEscrowHolds(ctx, resolutionID, escrow.CampaignID, []FundEscrow{escrow}, resolution, resolvedBy)
 }
 
-// GetLatestGlobalMetrics retrieves the latest global metrics
-func (p *PlatformContract) GetLatestGlobalMetrics(ctx contractapi.TransactionContextInterface) (*GlobalMetrics, error) {
-	metricsJSON, err := ctx.GetStub().GetState("COMMON_GLOBAL_METRICS_LATEST")
+// UnfreezeCampaignEscrows lifts the active holds on every frozen escrow of a campaign
+// Channel: common-channel
+// Endorsers: PlatformOrg (multi-party visibility)
+func (p *PlatformContract) UnfreezeCampaignEscrows(
+	ctx contractapi.TransactionContextInterface,
+	resolutionID string,
+	campaignID string,
+	resolution string,
+	resolvedBy string,
+) (string, error) {
+	escrows, err := getEscrows(ctx, map[string]interface{}{
+		"campaignId": campaignID,
+		"status":     "FROZEN",
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read metrics: %v", err)
-	}
-	if metricsJSON == nil {
-		return nil, fmt.Errorf("no global metrics published yet")
+		return "", err
 	}
-
-	var metrics GlobalMetrics
-	err = json.Unmarshal(metricsJSON, &metrics)
-	if err != nil {
-		return nil, err
+	if len(escrows) == 0 {
+		return "", fmt.Errorf("campaign %s has no frozen escrows", campaignID)
 	}
 
-	return &metrics, nil
+	return resolveEscrowHolds(ctx, resolutionID, campaignID, escrows, resolution, resolvedBy)
 }
 
 // ============================================================================
-// CROSS-CHANNEL INVOCATION HELPER FUNCTIONS
+// DISPUTE DECISIONS
+// ValidatorOrg arbitrates disputes; binding RELEASE and REFUND decisions are
+// executed here from the summary ValidatorOrg publishes on common-channel
 // ============================================================================
 
-// InvokeStartupOrgGetCampaign reads full campaign data from StartupOrg
-// Cross-channel READ from startup-platform-channel
-func (p *PlatformContract) InvokeStartupOrgGetCampaign(
+// ExecuteDisputeDecision carries out a RELEASE or REFUND ordered by an arbitration decision
+// A hold on the escrow is lifted first. The binding decision stands in for milestone
+// evidence and multi-signature approval. Release ID DISPUTE_RELEASE_<disputeId>,
+// refund ID DISPUTE_REFUND_<disputeId>, hold resolution ID DISPUTE_<disputeId>
+// Channel: common-channel
+// Endorsers: PlatformOrg (multi-party visibility)
+func (p *PlatformContract) ExecuteDisputeDecision(
 	ctx contractapi.TransactionContextInterface,
-	campaignID string,
+	disputeID string,
 ) (string, error) {
-	args := [][]byte{
-		[]byte("GetCampaign"),
-		[]byte(campaignID),
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+
+	executionID := fmt.Sprintf("DISPUTE_EXECUTION_%s", disputeID)
+	existingJSON, err := ctx.GetStub().GetState(executionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read dispute execution: %v", err)
+	}
+	if existingJSON != nil {
+		return "", fmt.Errorf("decision of dispute %s has already been executed", disputeID)
 	}
 
+	// Read the published decision from ValidatorOrg
 	response := ctx.GetStub().InvokeChaincode(
-		"startuporg",
-		args,
-		"startup-platform-channel",
+		"validatororg",
+		[][]byte{[]byte("GetDisputeSummary"), []byte(disputeID)},
+		"common-channel",
 	)
-
 	if response.Status != 200 {
-		return "", fmt.Errorf("cross-channel query to StartupOrg failed: %s", response.Message)
+		return "", fmt.Errorf("dispute %s has no decision on common-channel: %s", disputeID, response.Message)
 	}
 
-	return string(response.Payload), nil
-}
+	var summary struct {
+		DisputeID   string  `json:"disputeId"`
+		CampaignID  string  `json:"campaignId"`
+		Outcome     string  `json:"outcome"`
+		Remedy      string  `json:"remedy"`
+		EscrowID    string  `json:"escrowId"`
+		MilestoneID string  `json:"milestoneId"`
+		Amount      float64 `json:"amount"`
+		SummaryHash string  `json:"summaryHash"`
+	}
+	if err := json.Unmarshal(response.Payload, &summary); err != nil {
+		return "", fmt.Errorf("failed to parse dispute summary: %v", err)
+	}
+	if summary.Outcome != "UPHELD" || (summary.Remedy != "RELEASE" && summary.Remedy != "REFUND") {
+		return "", fmt.Errorf("dispute %s (%s, remedy %s) has no release or refund to execute", disputeID, summary.Outcome, summary.Remedy)
+	}
 
-// InvokeValidatorOrgGetValidation reads validation data from ValidatorOrg
-// Cross-channel READ from validator-platform-channel
-func (p *PlatformContract) InvokeValidatorOrgGetValidation(
-	ctx contractapi.TransactionContextInterface,
-	validationID string,
-) (string, error) {
-	args := [][]byte{
-		[]byte("GetValidation"),
-		[]byte(validationID),
+	escrowJSON, err := ctx.GetStub().GetState(summary.EscrowID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read escrow: %v", err)
+	}
+	if escrowJSON == nil {
+		return "", fmt.Errorf("escrow %s does not exist", summary.EscrowID)
 	}
 
-	response := ctx.GetStub().InvokeChaincode(
-		"validatororg",
-		args,
-		"validator-platform-channel",
-	)
+	var escrow FundEscrow
+	err = json.Unmarshal(escrowJSON, &escrow)
+	if err != nil {
+		return "", err
+	}
+	if summary.CampaignID != "" && escrow.CampaignID != summary.CampaignID {
+		return "", fmt.Errorf("escrow %s is not part of campaign %s", escrow.EscrowID, summary.CampaignID)
+	}
 
-	if response.Status != 200 {
-		return "", fmt.Errorf("cross-channel query to ValidatorOrg failed: %s", response.Message)
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
 	}
+	now := txTime.Format(time.RFC3339)
+	reason := fmt.Sprintf("Arbitration decision on dispute %s", disputeID)
 
-	return string(response.Payload), nil
+	execution := DisputeExecution{
+		ExecutionID: executionID,
+		DisputeID:   disputeID,
+		Remedy:      summary.Remedy,
+		EscrowID:    escrow.EscrowID,
+		CampaignID:  escrow.CampaignID,
+		SummaryHash: summary.SummaryHash,
+		ExecutedAt:  now,
+	}
+
+	// The decision resolves any hold placed for the dispute
+	if escrow.Status == "FROZEN" {
+		hold, err := liftEscrowHold(ctx, &escrow, fmt.Sprintf("DISPUTE_%s", disputeID), now)
+		if err != nil {
+			return "", err
+		}
+		resolution := HoldResolution{
+			ResolutionID:  hold.ResolutionID,
+			CampaignID:    escrow.CampaignID,
+			HoldIDs:       []string{hold.HoldID},
+			EscrowIDs:     []string{escrow.EscrowID},
+			Resolution:    reason,
+			ResolvedBy:    "ARBITRATION",
+			ResolvedByMSP: "PlatformOrgMSP",
+			ResolvedAt:    now,
+		}
+		resolutionJSON, err := json.Marshal(resolution)
+		if err != nil {
+			return "", err
+		}
+		err = ctx.GetStub().PutState(resolution.ResolutionID, resolutionJSON)
+		if err != nil {
+			return "", err
+		}
+		execution.HoldResolutionID = resolution.ResolutionID
+	}
+
+	if summary.Remedy == "RELEASE" {
+		if summary.Amount > availableEscrowAmount(escrow) {
+			return "", fmt.Errorf("insufficient funds in escrow. Available: %f, Requested: %f", availableEscrowAmount(escrow), summary.Amount)
+		}
+		if err := checkFundingModelAllowsRelease(ctx, escrow.CampaignID); err != nil {
+			return "", err
+		}
+
+		release := FundRelease{
+			ReleaseID:          fmt.Sprintf("DISPUTE_RELEASE_%s", disputeID),
+			EscrowID:           escrow.EscrowID,
+			AgreementID:        escrow.AgreementID,
+			CampaignID:         escrow.CampaignID,
+			MilestoneID:        summary.MilestoneID,
+			StartupID:          escrow.StartupID,
+			Amount:             summary.Amount,
+			Currency:           escrow.Currency,
+			TriggerReason:      reason,
+			RequiredSignatures: map[string]int{},
+			Approvals:          []ReleaseApproval{},
+			RequestedAt:        now,
+			Evidence: ReleaseEvidence{
+				DisputeID:          disputeID,
+				DisputeSummaryHash: summary.SummaryHash,
+				CheckedAt:          now,
+			},
+		}
+		err = executeFundRelease(ctx, &release, &escrow, now)
+		if err != nil {
+			return "", err
+		}
+		execution.ReleaseID = release.ReleaseID
+		execution.Amount = release.Amount
+	} else {
+		refund, err := refundEscrow(ctx, &escrow, fmt.Sprintf("DISPUTE_REFUND_%s", disputeID), reason, now)
+		if err != nil {
+			return "", err
+		}
+		execution.RefundID = refund.RefundID
+		execution.Amount = refund.Amount
+	}
+
+	executionJSON, err := json.Marshal(execution)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(executionID, executionJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit one event for the hold, release or refund (Fabric keeps only the last event per transaction)
+	eventPayload := map[string]interface{}{
+		"disputeId":        disputeID,
+		"remedy":           execution.Remedy,
+		"escrowId":         execution.EscrowID,
+		"campaignId":       execution.CampaignID,
+		"investorId":       escrow.InvestorID,
+		"startupId":        escrow.StartupID,
+		"releaseId":        execution.ReleaseID,
+		"refundId":         execution.RefundID,
+		"amount":           execution.Amount,
+		"currency":         escrow.Currency,
+		"holdResolutionId": execution.HoldResolutionID,
+		"summaryHash":      execution.SummaryHash,
+		"channel":          "common-channel",
+		"action":           "DISPUTE_DECISION_EXECUTED",
+		"timestamp":        now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("DisputeDecisionExecuted", eventJSON)
+
+	responseData := map[string]interface{}{
+		"message":       "Dispute decision executed",
+		"executionId":   executionID,
+		"remedy":        execution.Remedy,
+		"releaseId":     execution.ReleaseID,
+		"refundId":      execution.RefundID,
+		"amount":        execution.Amount,
+		"escrowBalance": escrow.HeldAmount,
+		"escrowStatus":  escrow.Status,
+	}
+	responseJSON, _ := json.Marshal(responseData)
+	return string(responseJSON), nil
 }
 
-// InvokeStartupOrgNotifyFundRelease notifies StartupOrg about fund release
-// Cross-channel call to common-channel
-func (p *PlatformContract) InvokeStartupOrgNotifyFundRelease(
+// ============================================================================
+// VESTING
+// Releases from escrows with a vesting schedule unlock over time, measured by
+// transaction timestamp; the startup claims what has vested
+// ============================================================================
+
+// SetVestingSchedule sets the vesting schedule of an agreement and its escrow
+// Applies to releases made after it is set; installments 0 removes the schedule
+// Channel: common-channel
+// Endorsers: PlatformOrg (multi-party visibility)
+func (p *PlatformContract) SetVestingSchedule(
 	ctx contractapi.TransactionContextInterface,
-	campaignID string,
-	amount string,
-	status string,
+	agreementID string,
+	cliffDays int,
+	intervalDays int,
+	installments int,
 ) (string, error) {
-	args := [][]byte{
-		[]byte("ReceiveFunding"),
-		[]byte(campaignID),
-		[]byte(amount),
-		[]byte(status),
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+	if cliffDays < 0 || installments < 0 {
+		return "", fmt.Errorf("cliff days and installments cannot be negative")
+	}
+	if installments > 0 && intervalDays <= 0 {
+		return "", fmt.Errorf("vesting installments need a positive interval")
 	}
 
-	response := ctx.GetStub().InvokeChaincode(
-		"startuporg",
-		args,
-		"common-channel",
-	)
+	agreementJSON, err := ctx.GetStub().GetState(agreementID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read agreement: %v", err)
+	}
+	if agreementJSON == nil {
+		return "", fmt.Errorf("agreement %s does not exist", agreementID)
+	}
 
-	if response.Status != 200 {
-		return "", fmt.Errorf("cross-channel invoke to StartupOrg failed: %s", response.Message)
+	var agreement Agreement
+	err = json.Unmarshal(agreementJSON, &agreement)
+	if err != nil {
+		return "", err
 	}
 
-	// Emit cross-channel event
+	escrowID := fmt.Sprintf("ESCROW_%s", agreementID)
+	escrowJSON, err := ctx.GetStub().GetState(escrowID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read escrow: %v", err)
+	}
+	if escrowJSON == nil {
+		return "", fmt.Errorf("escrow %s does not exist", escrowID)
+	}
+
+	var escrow FundEscrow
+	err = json.Unmarshal(escrowJSON, &escrow)
+	if err != nil {
+		return "", err
+	}
+
+	schedule := VestingSchedule{CliffDays: cliffDays, IntervalDays: intervalDays, Installments: installments}
+	if installments == 0 {
+		schedule = VestingSchedule{}
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	agreement.VestingSchedule = schedule
+	updatedAgreementJSON, err := json.Marshal(agreement)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(agreementID, updatedAgreementJSON)
+	if err != nil {
+		return "", err
+	}
+
+	escrow.VestingSchedule = schedule
+	escrow.UpdatedAt = now
+	updatedEscrowJSON, err := json.Marshal(escrow)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(escrowID, updatedEscrowJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
 	eventPayload := map[string]interface{}{
-		"campaignId":     campaignID,
-		"targetChannel":  "common-channel",
-		"targetContract": "startuporg",
-		"action":         "CROSS_CHANNEL_FUND_RELEASE_NOTIFY",
-		"timestamp":      time.Now().Format(time.RFC3339),
+		"agreementId":  agreementID,
+		"escrowId":     escrowID,
+		"cliffDays":    schedule.CliffDays,
+		"intervalDays": schedule.IntervalDays,
+		"installments": schedule.Installments,
+		"channel":      "common-channel",
+		"action":       "VESTING_SCHEDULE_SET",
+		"timestamp":    now,
 	}
 	eventJSON, _ := json.Marshal(eventPayload)
-	ctx.GetStub().SetEvent("CrossChannelInvoke", eventJSON)
+	ctx.GetStub().SetEvent("VestingScheduleSet", eventJSON)
 
-	return string(response.Payload), nil
+	response := map[string]interface{}{
+		"message":         "Vesting schedule set",
+		"agreementId":     agreementID,
+		"escrowId":        escrowID,
+		"vestingSchedule": schedule,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
 }
 
-// InvokeCommonChannelPublish publishes to common-channel (all orgs can read)
-func (p *PlatformContract) InvokeCommonChannelPublish(
+// ClaimVestedFunds pays the startup the vested but unclaimed part of a vesting release
+// Vesting is measured by the transaction timestamp
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (p *PlatformContract) ClaimVestedFunds(
 	ctx contractapi.TransactionContextInterface,
-	metricsID string,
-	metricsHash string,
+	claimID string,
+	releaseID string,
+	startupID string,
 ) (string, error) {
-	// Common channel is directly accessible, but this shows cross-channel pattern
-	args := [][]byte{
-		[]byte("PublishGlobalMetrics"),
-		[]byte(metricsID),
-		[]byte(metricsHash),
+	if err := requireMSP(ctx, "StartupOrgMSP"); err != nil {
+		return "", err
 	}
 
-	response := ctx.GetStub().InvokeChaincode(
-		"platformorg", // same contract but different channel
-		args,
-		"common-channel",
-	)
+	existingJSON, err := ctx.GetStub().GetState(claimID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read claim: %v", err)
+	}
+	if existingJSON != nil {
+		return "", fmt.Errorf("claim %s already exists", claimID)
+	}
 
-	if response.Status != 200 {
-		return "", fmt.Errorf("cross-channel invoke to common-channel failed: %s", response.Message)
+	releaseJSON, err := ctx.GetStub().GetState(releaseID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read release: %v", err)
+	}
+	if releaseJSON == nil {
+		return "", fmt.Errorf("release %s does not exist", releaseID)
 	}
 
-	return string(response.Payload), nil
-}
+	var release FundRelease
+	err = json.Unmarshal(releaseJSON, &release)
+	if err != nil {
+		return "", err
+	}
+	if release.Status != "RELEASED" || release.VestingSchedule.Installments == 0 {
+		return "", fmt.Errorf("release %s has no vesting funds to claim", releaseID)
+	}
+	if release.StartupID != startupID {
+		return "", fmt.Errorf("release %s was not made to startup %s", releaseID, startupID)
+	}
+	if release.CancelledAmount > 0 {
+		return "", fmt.Errorf("vesting of release %s was cancelled by a clawback", releaseID)
+	}
+	if err := checkCampaignNotBlacklisted(ctx, release.CampaignID); err != nil {
+		return "", err
+	}
 
-// generateHash generates SHA256 hash
-func generateHash(data string) string {
-	hash := sha256.Sum256([]byte(data))
-	return hex.EncodeToString(hash[:])
-}
+	// Claims stop while the escrow is on hold
+	escrowJSON, err := ctx.GetStub().GetState(release.EscrowID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read escrow: %v", err)
+	}
+	if escrowJSON != nil {
+		var escrow FundEscrow
+		if err := json.Unmarshal(escrowJSON, &escrow); err != nil {
+			return "", err
+		}
+		if err := checkEscrowNotFrozen(escrow); err != nil {
+			return "", err
+		}
+	}
 
-func main() {
-	platformChaincode, err := contractapi.NewChaincode(&PlatformContract{})
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	vested, nextUnlockAt, err := vestedAmount(release, txTime)
+	if err != nil {
+		return "", err
+	}
+	claimable := vested - release.ClaimedAmount
+	if claimable <= journalTolerance {
+		if nextUnlockAt.IsZero() {
+			return "", fmt.Errorf("release %s is fully claimed", releaseID)
+		}
+		return "", fmt.Errorf("release %s has no unclaimed vested funds; next installment unlocks at %s", releaseID, nextUnlockAt.Format(time.RFC3339))
+	}
+
+	now := txTime.Format(time.RFC3339)
+
+	err = postJournalEntry(ctx, "VESTING_CLAIMED", claimID, release.CampaignID, release.Currency, []JournalLine{
+		{Account: startupCashAccount(startupID), Debit: claimable},
+		{Account: vestingAccount(releaseID), Credit: claimable},
+	}, fmt.Sprintf("Vested funds of release %s", releaseID), now)
+	if err != nil {
+		return "", err
+	}
+
+	release.ClaimedAmount += claimable
+	updatedReleaseJSON, err := json.Marshal(release)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(releaseID, updatedReleaseJSON)
+	if err != nil {
+		return "", err
+	}
+
+	claim := VestingClaim{
+		ClaimID:       claimID,
+		ReleaseID:     releaseID,
+		EscrowID:      release.EscrowID,
+		CampaignID:    release.CampaignID,
+		StartupID:     startupID,
+		Amount:        claimable,
+		Currency:      release.Currency,
+		VestedAmount:  vested,
+		ClaimedAmount: release.ClaimedAmount,
+		ClaimedAt:     now,
+	}
+	claimJSON, err := json.Marshal(claim)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(claimID, claimJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"claimId":       claimID,
+		"releaseId":     releaseID,
+		"campaignId":    release.CampaignID,
+		"startupId":     startupID,
+		"amount":        claimable,
+		"currency":      release.Currency,
+		"claimedAmount": release.ClaimedAmount,
+		"channel":       "common-channel",
+		"action":        "VESTED_FUNDS_CLAIMED",
+		"timestamp":     now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("VestedFundsClaimed", eventJSON)
+
+	response := map[string]interface{}{
+		"message":        "Vested funds claimed",
+		"claimId":        claimID,
+		"releaseId":      releaseID,
+		"amount":         claimable,
+		"currency":       release.Currency,
+		"claimedAmount":  release.ClaimedAmount,
+		"unvestedAmount": release.NetAmount - vested,
+		"nextUnlockAt":   formatOptionalTime(nextUnlockAt),
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// CLAWBACKS
+// Funds released to a campaign later blacklisted for fraud are owed back by the
+// startup; recoveries are returned to the campaign's investors pro-rata
+// ============================================================================
+
+// OpenClawback records what the startup of a blacklisted campaign owes back from released funds
+// The blacklist must have been published to common-channel by ValidatorOrg (PublishBlacklistedCampaign).
+// Each investor's share is proportional to their escrowed funds that were released
+// Channel: common-channel
+// Endorsers: PlatformOrg (multi-party visibility)
+func (p *PlatformContract) OpenClawback(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	reason string,
+) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+
+	clawbackID := fmt.Sprintf("CLAWBACK_%s", campaignID)
+	existingJSON, err := ctx.GetStub().GetState(clawbackID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read clawback: %v", err)
+	}
+	if existingJSON != nil {
+		return "", fmt.Errorf("clawback for campaign %s already exists", campaignID)
+	}
+
+	// Clawbacks need proven fraud: a blacklist entry from ValidatorOrg
+	blacklist, err := getCampaignBlacklist(ctx, campaignID)
+	if err != nil {
+		return "", err
+	}
+	if !blacklist.Blacklisted {
+		return "", fmt.Errorf("campaign %s is not blacklisted on common-channel", campaignID)
+	}
+
+	escrows, err := getEscrows(ctx, map[string]interface{}{"campaignId": campaignID})
+	if err != nil {
+		return "", err
+	}
+	escrowIDs := []string{}
+	investorByEscrow := map[string]string{}
+	for _, escrow := range escrows {
+		escrowIDs = append(escrowIDs, escrow.EscrowID)
+		investorByEscrow[escrow.EscrowID] = escrow.InvestorID
+	}
+	releasesByEscrow, err := getReleasesForEscrows(ctx, escrowIDs)
+	if err != nil {
+		return "", err
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	clawback := Clawback{
+		ClawbackID:      clawbackID,
+		CampaignID:      campaignID,
+		Reason:          reason,
+		BlacklistReason: blacklist.Reason,
+		ReleaseIDs:      []string{},
+		Shares:          []ClawbackShare{},
+		Status:          "OPEN",
+		OpenedAt:        now,
+		UpdatedAt:       now,
+	}
+
+	releasedByInvestor := map[string]float64{}
+	investorIDs := []string{}
+	totalReleased := 0.0
+	for _, escrowID := range escrowIDs {
+		for _, release := range releasesByEscrow[escrowID] {
+			if release.Status != "RELEASED" {
+				continue
+			}
+			if clawback.Currency == "" {
+				clawback.Currency = release.Currency
+				clawback.StartupID = release.StartupID
+			}
+			if release.Currency != clawback.Currency {
+				return "", fmt.Errorf("release %s is in %s, clawback is in %s", release.ReleaseID, release.Currency, clawback.Currency)
+			}
+
+			investorID := investorByEscrow[escrowID]
+			owed := releaseNetAmount(release)
+			if release.VestingSchedule.Installments > 0 {
+				// The startup only holds what it claimed; the rest goes straight back to the investor
+				returned, err := cancelReleaseVesting(ctx, release.ReleaseID, investorID, now)
+				if err != nil {
+					return "", err
+				}
+				clawback.ReturnedVesting += returned
+				owed = release.ClaimedAmount
+			}
+			if owed <= journalTolerance {
+				continue
+			}
+
+			if _, ok := releasedByInvestor[investorID]; !ok {
+				investorIDs = append(investorIDs, investorID)
+			}
+			releasedByInvestor[investorID] += owed
+			totalReleased += owed
+			clawback.AmountOwed += owed
+			clawback.ReleaseIDs = append(clawback.ReleaseIDs, release.ReleaseID)
+		}
+	}
+	if clawback.AmountOwed <= 0 && clawback.ReturnedVesting <= 0 {
+		return "", fmt.Errorf("campaign %s has no released funds to claw back", campaignID)
+	}
+	clawback.OutstandingAmount = clawback.AmountOwed
+	if clawback.AmountOwed <= journalTolerance {
+		clawback.Status = "RECOVERED"
+	}
+
+	sort.Strings(investorIDs)
+	for _, investorID := range investorIDs {
+		clawback.Shares = append(clawback.Shares, ClawbackShare{
+			InvestorID:     investorID,
+			ReleasedAmount: releasedByInvestor[investorID],
+			ShareRatio:     releasedByInvestor[investorID] / totalReleased,
+		})
+	}
+
+	clawbackJSON, err := json.Marshal(clawback)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(clawbackID, clawbackJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"clawbackId":      clawbackID,
+		"campaignId":      campaignID,
+		"startupId":       clawback.StartupID,
+		"amountOwed":      clawback.AmountOwed,
+		"currency":        clawback.Currency,
+		"releaseIds":      clawback.ReleaseIDs,
+		"investorCount":   len(clawback.Shares),
+		"returnedVesting": clawback.ReturnedVesting,
+		"channel":         "common-channel",
+		"action":          "CLAWBACK_OPENED",
+		"timestamp":       now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("ClawbackOpened", eventJSON)
+
+	responseData := map[string]interface{}{
+		"message":         "Clawback opened",
+		"clawbackId":      clawbackID,
+		"startupId":       clawback.StartupID,
+		"amountOwed":      clawback.AmountOwed,
+		"returnedVesting": clawback.ReturnedVesting,
+		"currency":        clawback.Currency,
+		"shares":          clawback.Shares,
+	}
+	responseJSON, _ := json.Marshal(responseData)
+	return string(responseJSON), nil
+}
+
+// RecordClawbackRecovery records a recovery payment from the startup and distributes it to investors pro-rata
+// source: STARTUP_CASH when paid from the startup's settlement tokens, FIAT when paid off-chain
+// Channel: common-channel
+// Endorsers: PlatformOrg (multi-party visibility)
+func (p *PlatformContract) RecordClawbackRecovery(
+	ctx contractapi.TransactionContextInterface,
+	recoveryID string,
+	campaignID string,
+	amount float64,
+	source string,
+	reference string,
+) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+	if amount <= 0 {
+		return "", fmt.Errorf("recovery amount must be positive")
+	}
+	if source != "STARTUP_CASH" && source != "FIAT" {
+		return "", fmt.Errorf("invalid source: %s. Must be STARTUP_CASH or FIAT", source)
+	}
+
+	clawback, err := getClawback(ctx, campaignID)
+	if err != nil {
+		return "", err
+	}
+	if clawback.Status == "RECOVERED" {
+		return "", fmt.Errorf("clawback %s has been fully recovered", clawback.ClawbackID)
+	}
+	if amount > clawback.OutstandingAmount+journalTolerance {
+		return "", fmt.Errorf("recovery of %.2f exceeds outstanding amount %.2f", amount, clawback.OutstandingAmount)
+	}
+
+	existingJSON, err := ctx.GetStub().GetState(recoveryID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read recovery: %v", err)
+	}
+	if existingJSON != nil {
+		return "", fmt.Errorf("recovery %s already exists", recoveryID)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	recovery := ClawbackRecovery{
+		RecoveryID:    recoveryID,
+		ClawbackID:    clawback.ClawbackID,
+		CampaignID:    campaignID,
+		Amount:        amount,
+		Currency:      clawback.Currency,
+		Source:        source,
+		Reference:     reference,
+		Distributions: []ClawbackDistribution{},
+		RecordedAt:    now,
+	}
+
+	sourceAccount := fiatClearingAccount()
+	if source == "STARTUP_CASH" {
+		sourceAccount = startupCashAccount(clawback.StartupID)
+	}
+	lines := []JournalLine{{Account: sourceAccount, Credit: amount}}
+	ratios := []float64{}
+	for _, share := range clawback.Shares {
+		ratios = append(ratios, share.ShareRatio)
+	}
+	amounts := splitProRata(amount, ratios)
+	for idx := range clawback.Shares {
+		share := &clawback.Shares[idx]
+		distributed := amounts[idx]
+		share.DistributedAmount += distributed
+
+		recovery.Distributions = append(recovery.Distributions, ClawbackDistribution{
+			InvestorID: share.InvestorID,
+			Amount:     distributed,
+		})
+		lines = append(lines, JournalLine{Account: investorWalletAccount(share.InvestorID), Debit: distributed})
+	}
+
+	err = postJournalEntry(ctx, "CLAWBACK_RECOVERED", recoveryID, campaignID, clawback.Currency, lines,
+		fmt.Sprintf("Clawback recovery for blacklisted campaign %s", campaignID), now)
+	if err != nil {
+		return "", err
+	}
+
+	recoveryJSON, err := json.Marshal(recovery)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(recoveryID, recoveryJSON)
+	if err != nil {
+		return "", err
+	}
+
+	clawback.RecoveredAmount += amount
+	clawback.OutstandingAmount = clawback.AmountOwed - clawback.RecoveredAmount
+	clawback.Status = "PARTIALLY_RECOVERED"
+	if clawback.OutstandingAmount <= journalTolerance {
+		clawback.OutstandingAmount = 0
+		clawback.Status = "RECOVERED"
+	}
+	clawback.UpdatedAt = now
+
+	clawbackJSON, err := json.Marshal(clawback)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(clawback.ClawbackID, clawbackJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event for InvestorOrg reconciliation
+	eventPayload := map[string]interface{}{
+		"recoveryId":        recoveryID,
+		"clawbackId":        clawback.ClawbackID,
+		"campaignId":        campaignID,
+		"amount":            amount,
+		"currency":          clawback.Currency,
+		"source":            source,
+		"distributions":     recovery.Distributions,
+		"outstandingAmount": clawback.OutstandingAmount,
+		"status":            clawback.Status,
+		"channel":           "common-channel",
+		"action":            "CLAWBACK_RECOVERED",
+		"timestamp":         now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("ClawbackRecovered", eventJSON)
+
+	responseData := map[string]interface{}{
+		"message":           "Clawback recovery distributed to investors",
+		"recoveryId":        recoveryID,
+		"amount":            amount,
+		"distributions":     recovery.Distributions,
+		"recoveredAmount":   clawback.RecoveredAmount,
+		"outstandingAmount": clawback.OutstandingAmount,
+		"status":            clawback.Status,
+	}
+	responseJSON, _ := json.Marshal(responseData)
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// DISTRIBUTIONS
+// Returns from the startup to investors: one payout line per investor, pro-rata
+// to invested amounts, confirmed by InvestorOrg on receipt
+// ============================================================================
+
+// RecordDistribution records a distribution declared by the startup and one payout line per investor
+// Invoked by StartupContract.DeclareDistribution on common-channel
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (p *PlatformContract) RecordDistribution(
+	ctx contractapi.TransactionContextInterface,
+	distributionID string,
+	campaignID string,
+	startupID string,
+	distributionType string,
+	totalAmount float64,
+	currency string,
+	description string,
+) (string, error) {
+	if err := requireMSP(ctx, "StartupOrgMSP"); err != nil {
+		return "", err
+	}
+	validTypes := map[string]bool{"DIVIDEND": true, "REVENUE_SHARE": true, "EXIT_PROCEEDS": true}
+	if !validTypes[distributionType] {
+		return "", fmt.Errorf("invalid distribution type: %s. Must be DIVIDEND, REVENUE_SHARE or EXIT_PROCEEDS", distributionType)
+	}
+	if totalAmount <= 0 {
+		return "", fmt.Errorf("distribution amount must be positive")
+	}
+
+	existingJSON, err := ctx.GetStub().GetState(distributionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read distribution: %v", err)
+	}
+	if existingJSON != nil {
+		return "", fmt.Errorf("distribution %s already exists", distributionID)
+	}
+
+	stakes, totalInvested, err := getInvestorStakes(ctx, campaignID, startupID, currency)
+	if err != nil {
+		return "", err
+	}
+	if len(stakes) == 0 {
+		return "", fmt.Errorf("campaign %s has no witnessed %s agreements with startup %s", campaignID, currency, startupID)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	distribution := Distribution{
+		DistributionID:   distributionID,
+		CampaignID:       campaignID,
+		StartupID:        startupID,
+		DistributionType: distributionType,
+		TotalAmount:      totalAmount,
+		Currency:         currency,
+		Description:      description,
+		TotalInvested:    totalInvested,
+		PayoutCount:      len(stakes),
+		Status:           "DECLARED",
+		DeclaredAt:       now,
+		UpdatedAt:        now,
+	}
+
+	// Pro-rata to invested amounts
+	ratios := []float64{}
+	for _, stake := range stakes {
+		ratios = append(ratios, stake.Invested/totalInvested)
+	}
+	payouts, err := recordDistributionPayouts(ctx, distribution, stakes, splitProRata(totalAmount, ratios))
+	if err != nil {
+		return "", err
+	}
+
+	distributionJSON, err := json.Marshal(distribution)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(distributionID, distributionJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event for InvestorOrg
+	eventPayload := map[string]interface{}{
+		"distributionId":   distributionID,
+		"campaignId":       campaignID,
+		"startupId":        startupID,
+		"distributionType": distributionType,
+		"totalAmount":      totalAmount,
+		"currency":         currency,
+		"payouts":          payoutEventEntries(payouts),
+		"channel":          "common-channel",
+		"action":           "DISTRIBUTION_DECLARED",
+		"timestamp":        now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("DistributionDeclared", eventJSON)
+
+	response := map[string]interface{}{
+		"message":        "Distribution recorded with one payout per investor",
+		"distributionId": distributionID,
+		"totalAmount":    totalAmount,
+		"currency":       currency,
+		"payouts":        payoutEventEntries(payouts),
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// RecordDistributionReceipt marks an investor's payout as received
+// Only the investor the payout belongs to can confirm it: the caller's certificate must carry
+// the investorId attribute of the payout's investor
+// Invoked by InvestorContract.ConfirmDistributionReceipt on common-channel
+// Channel: common-channel
+// Endorsers: InvestorOrg, PlatformOrg
+func (p *PlatformContract) RecordDistributionReceipt(
+	ctx contractapi.TransactionContextInterface,
+	distributionID string,
+	investorID string,
+	paymentReference string,
+) (string, error) {
+	if err := requireMSP(ctx, "InvestorOrgMSP"); err != nil {
+		return "", err
+	}
+
+	distribution, err := getDistribution(ctx, distributionID)
+	if err != nil {
+		return "", err
+	}
+
+	payoutID := distributionPayoutID(distributionID, investorID)
+	payoutJSON, err := ctx.GetStub().GetState(payoutID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read payout: %v", err)
+	}
+	if payoutJSON == nil {
+		return "", fmt.Errorf("investor %s has no payout in distribution %s", investorID, distributionID)
+	}
+
+	var payout DistributionPayout
+	err = json.Unmarshal(payoutJSON, &payout)
+	if err != nil {
+		return "", err
+	}
+	if err := requireInvestorIdentity(ctx, payout.InvestorID); err != nil {
+		return "", err
+	}
+	if payout.Status == "CONFIRMED" {
+		return "", fmt.Errorf("payout %s was already confirmed", payoutID)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	payout.Status = "CONFIRMED"
+	payout.PaymentReference = paymentReference
+	payout.ConfirmedAt = now
+	updatedPayoutJSON, err := json.Marshal(payout)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(payoutID, updatedPayoutJSON)
+	if err != nil {
+		return "", err
+	}
+
+	distribution.ConfirmedCount++
+	if distribution.ConfirmedCount >= distribution.PayoutCount {
+		distribution.Status = "PAID"
+	}
+	distribution.UpdatedAt = now
+	distributionJSON, err := json.Marshal(distribution)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(distributionID, distributionJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"distributionId":     distributionID,
+		"payoutId":           payoutID,
+		"investorId":         investorID,
+		"amount":             payout.Amount,
+		"currency":           payout.Currency,
+		"paymentReference":   paymentReference,
+		"distributionStatus": distribution.Status,
+		"channel":            "common-channel",
+		"action":             "DISTRIBUTION_RECEIPT_CONFIRMED",
+		"timestamp":          now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("DistributionReceiptConfirmed", eventJSON)
+
+	response := map[string]interface{}{
+		"message":            "Distribution receipt confirmed",
+		"payoutId":           payoutID,
+		"amount":             payout.Amount,
+		"currency":           payout.Currency,
+		"confirmedCount":     distribution.ConfirmedCount,
+		"payoutCount":        distribution.PayoutCount,
+		"distributionStatus": distribution.Status,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// REVENUE-SHARE FINANCING
+// Revenue-share agreements are repaid from a percentage of reported revenue
+// until a cap; obligations are paid out as REVENUE_SHARE distributions
+// ============================================================================
+
+// revenueReportGraceDays is how long after a period ends its revenue report is due
+const revenueReportGraceDays = 15
+
+// RecordRevenueShareTerms makes a campaign a revenue-share campaign, before any agreement is witnessed
+// revenuePercent is the share of each period's revenue owed to all of the campaign's investors together
+// reportingFrequency: MONTHLY, QUARTERLY or ANNUAL; firstPeriodStart: YYYY-MM-DD
+// Invoked by StartupContract.OfferRevenueShareTerms on common-channel
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (p *PlatformContract) RecordRevenueShareTerms(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	startupID string,
+	revenuePercent float64,
+	capMultiple float64,
+	reportingFrequency string,
+	firstPeriodStart string,
+) (string, error) {
+	if err := requireMSP(ctx, "StartupOrgMSP"); err != nil {
+		return "", err
+	}
+	if revenuePercent <= 0 || revenuePercent > 100 {
+		return "", fmt.Errorf("revenue percent must be between 0 and 100")
+	}
+	if capMultiple < 1 {
+		return "", fmt.Errorf("repayment cap multiple must be at least 1")
+	}
+	periodStart, err := parseDate(firstPeriodStart)
+	if err != nil {
+		return "", fmt.Errorf("invalid first period start: %v", err)
+	}
+	periodEnd, err := nextReportingPeriodEnd(periodStart, reportingFrequency)
+	if err != nil {
+		return "", err
+	}
+
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign PublishedCampaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+	if campaign.StartupID != startupID {
+		return "", fmt.Errorf("campaign %s does not belong to startup %s", campaignID, startupID)
+	}
+	if campaign.Status == "CLOSED" {
+		return "", fmt.Errorf("campaign %s is closed", campaignID)
+	}
+	if len(campaign.AgreementIDs) > 0 {
+		return "", fmt.Errorf("campaign %s already has witnessed agreements", campaignID)
+	}
+	if campaign.ShareClass.ClassName != "" {
+		return "", fmt.Errorf("campaign %s is an equity offering", campaignID)
+	}
+	if len(campaign.RewardTierIDs) > 0 {
+		return "", fmt.Errorf("campaign %s is a reward campaign", campaignID)
+	}
+	loan, err := getLoan(ctx, campaignID)
+	if err != nil {
+		return "", err
+	}
+	if loan != nil {
+		return "", fmt.Errorf("campaign %s is a lending campaign", campaignID)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	offer := RevenueShareOffer{
+		CampaignID:         campaignID,
+		StartupID:          startupID,
+		Currency:           campaign.Currency,
+		RevenuePercent:     revenuePercent,
+		CapMultiple:        capMultiple,
+		ReportingFrequency: reportingFrequency,
+		FirstPeriodStart:   firstPeriodStart,
+		FirstReportDueAt:   periodEnd.AddDate(0, 0, revenueReportGraceDays).Format(time.RFC3339),
+		OfferedAt:          now,
+	}
+	offerJSON, err := json.Marshal(offer)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(revenueShareOfferKey(campaignID), offerJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"campaignId":         campaignID,
+		"startupId":          startupID,
+		"revenuePercent":     revenuePercent,
+		"capMultiple":        capMultiple,
+		"reportingFrequency": reportingFrequency,
+		"firstReportDueAt":   offer.FirstReportDueAt,
+		"channel":            "common-channel",
+		"action":             "REVENUE_SHARE_TERMS_RECORDED",
+		"timestamp":          now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("RevenueShareTermsRecorded", eventJSON)
+
+	response := map[string]interface{}{
+		"message":      "Revenue-share terms recorded",
+		"campaignId":   campaignID,
+		"revenueShare": offer,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// RecordRevenueReport records a startup's revenue for a period and each revenue-share agreement's obligation
+// The campaign's revenue percent is split across its investors pro-rata by invested amount.
+// Obligations are limited to what is left under each cap; agreements reaching the cap are COMPLETED.
+// Reports submitted more than 15 days after the period ends are flagged late.
+// Each campaign period is reported once, and no period may start before an agreement's last reported period ended
+// Invoked by StartupContract.SubmitRevenueReport on common-channel
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (p *PlatformContract) RecordRevenueReport(
+	ctx contractapi.TransactionContextInterface,
+	reportID string,
+	campaignID string,
+	startupID string,
+	periodStart string,
+	periodEnd string,
+	revenue float64,
+	currency string,
+	reportHash string,
+) (string, error) {
+	if err := requireMSP(ctx, "StartupOrgMSP"); err != nil {
+		return "", err
+	}
+	if revenue < 0 {
+		return "", fmt.Errorf("revenue cannot be negative")
+	}
+	startsAt, err := parseDate(periodStart)
+	if err != nil {
+		return "", fmt.Errorf("invalid period start: %v", err)
+	}
+	endsAt, err := parseDate(periodEnd)
+	if err != nil {
+		return "", fmt.Errorf("invalid period end: %v", err)
+	}
+	if !endsAt.After(startsAt) {
+		return "", fmt.Errorf("period end must be after period start")
+	}
+
+	existingJSON, err := ctx.GetStub().GetState(reportID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read revenue report: %v", err)
+	}
+	if existingJSON != nil {
+		return "", fmt.Errorf("revenue report %s already exists", reportID)
+	}
+
+	periodKey := revenuePeriodKey(campaignID, currency, periodStart, periodEnd)
+	periodReportJSON, err := ctx.GetStub().GetState(periodKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read revenue period: %v", err)
+	}
+	if periodReportJSON != nil {
+		return "", fmt.Errorf("period %s to %s of campaign %s has already been reported", periodStart, periodEnd, campaignID)
+	}
+
+	offer, err := getRevenueShareOffer(ctx, campaignID)
+	if err != nil {
+		return "", err
+	}
+	if offer == nil {
+		return "", fmt.Errorf("campaign %s is not a revenue-share campaign", campaignID)
+	}
+	if offer.StartupID != startupID {
+		return "", fmt.Errorf("campaign %s does not belong to startup %s", campaignID, startupID)
+	}
+
+	agreements, err := getRevenueShareAgreements(ctx, map[string]interface{}{"campaignId": campaignID})
+	if err != nil {
+		return "", err
+	}
+
+	// The campaign's share of revenue is split pro-rata across all of its investors,
+	// including those whose agreements have reached their cap
+	investorStakes, campaignInvested, err := getInvestorStakes(ctx, campaignID, startupID, currency)
+	if err != nil {
+		return "", err
+	}
+	ratios := []float64{}
+	for _, stake := range investorStakes {
+		ratios = append(ratios, stake.Invested/campaignInvested)
+	}
+	shares := splitProRata(math.Round(revenue*offer.RevenuePercent)/100, ratios)
+	shareByInvestor := map[string]float64{}
+	for idx, stake := range investorStakes {
+		shareByInvestor[stake.InvestorID] = shares[idx]
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+	dueAt := endsAt.AddDate(0, 0, revenueReportGraceDays)
+
+	report := RevenueReport{
+		ReportID:    reportID,
+		CampaignID:  campaignID,
+		StartupID:   startupID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Revenue:     revenue,
+		Currency:    currency,
+		ReportHash:  reportHash,
+		DueAt:       dueAt.Format(time.RFC3339),
+		Late:        txTime.After(dueAt),
+		Obligations: []RevenueShareObligation{},
+		SubmittedAt: now,
+	}
+
+	stakesByInvestor := map[string]*investorStake{}
+	owedByInvestor := map[string]float64{}
+	investorIDs := []string{}
+	totalInvested := 0.0
+	for _, agreement := range agreements {
+		if agreement.StartupID != startupID || agreement.Currency != currency {
+			continue
+		}
+		terms := &agreement.RevenueShare
+		if terms.LastPeriodEnd != "" {
+			lastEndsAt, err := parseDate(terms.LastPeriodEnd)
+			if err != nil {
+				return "", fmt.Errorf("invalid last period end of agreement %s: %v", agreement.AgreementID, err)
+			}
+			if startsAt.Before(lastEndsAt) {
+				return "", fmt.Errorf("period starting %s overlaps agreement %s, already reported up to %s", periodStart, agreement.AgreementID, terms.LastPeriodEnd)
+			}
+		}
+
+		// The investor's share of revenue, up to what is left under the cap; an investor's
+		// agreements take their share in agreement ID order
+		amount := shareByInvestor[agreement.InvestorID]
+		if remaining := math.Round((terms.CapAmount-terms.AccruedAmount)*100) / 100; amount > remaining {
+			amount = remaining
+		}
+		shareByInvestor[agreement.InvestorID] -= amount
+		terms.AccruedAmount += amount
+		terms.LastPeriodEnd = periodEnd
+		terms.ReportOverdue = false
+		if nextEnd, err := nextReportingPeriodEnd(endsAt, terms.ReportingFrequency); err == nil {
+			terms.NextReportDueAt = nextEnd.AddDate(0, 0, revenueReportGraceDays).Format(time.RFC3339)
+		}
+
+		capReached := terms.AccruedAmount >= terms.CapAmount-journalTolerance
+		if capReached {
+			terms.Status = "CAP_REACHED"
+			agreement.Status = "COMPLETED"
+		}
+
+		agreementJSON, err := json.Marshal(agreement)
+		if err != nil {
+			return "", err
+		}
+		err = ctx.GetStub().PutState(agreement.AgreementID, agreementJSON)
+		if err != nil {
+			return "", err
+		}
+
+		report.Obligations = append(report.Obligations, RevenueShareObligation{
+			AgreementID:   agreement.AgreementID,
+			InvestorID:    agreement.InvestorID,
+			Amount:        amount,
+			AccruedAmount: terms.AccruedAmount,
+			CapAmount:     terms.CapAmount,
+			CapReached:    capReached,
+		})
+		report.TotalObligation += amount
+
+		if amount <= 0 {
+			continue
+		}
+		invested, err := agreementInvestedAmount(ctx, agreement)
+		if err != nil {
+			return "", err
+		}
+		stake := stakesByInvestor[agreement.InvestorID]
+		if stake == nil {
+			stake = &investorStake{InvestorID: agreement.InvestorID, AgreementIDs: []string{}}
+			stakesByInvestor[agreement.InvestorID] = stake
+			investorIDs = append(investorIDs, agreement.InvestorID)
+		}
+		stake.AgreementIDs = append(stake.AgreementIDs, agreement.AgreementID)
+		stake.Invested += invested
+		totalInvested += invested
+		owedByInvestor[agreement.InvestorID] += amount
+	}
+	if len(report.Obligations) == 0 {
+		return "", fmt.Errorf("campaign %s has no active %s revenue-share agreements with startup %s", campaignID, currency, startupID)
+	}
+
+	// Obligations are paid as a distribution with one payout line per investor
+	if report.TotalObligation > 0 {
+		sort.Strings(investorIDs)
+		stakes := []investorStake{}
+		amounts := []float64{}
+		for _, investorID := range investorIDs {
+			stakes = append(stakes, *stakesByInvestor[investorID])
+			amounts = append(amounts, owedByInvestor[investorID])
+		}
+
+		distribution := Distribution{
+			DistributionID:   fmt.Sprintf("REVSHARE_%s", reportID),
+			CampaignID:       campaignID,
+			StartupID:        startupID,
+			DistributionType: "REVENUE_SHARE",
+			TotalAmount:      report.TotalObligation,
+			Currency:         currency,
+			Description:      fmt.Sprintf("Revenue share for %s to %s", periodStart, periodEnd),
+			TotalInvested:    totalInvested,
+			PayoutCount:      len(stakes),
+			Status:           "DECLARED",
+			DeclaredAt:       now,
+			UpdatedAt:        now,
+		}
+		if _, err := recordDistributionPayouts(ctx, distribution, stakes, amounts); err != nil {
+			return "", err
+		}
+		distributionJSON, err := json.Marshal(distribution)
+		if err != nil {
+			return "", err
+		}
+		err = ctx.GetStub().PutState(distribution.DistributionID, distributionJSON)
+		if err != nil {
+			return "", err
+		}
+		report.DistributionID = distribution.DistributionID
+	}
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(reportID, reportJSON)
+	if err != nil {
+		return "", err
+	}
+	periodJSON, _ := json.Marshal(map[string]string{"reportId": reportID})
+	err = ctx.GetStub().PutState(periodKey, periodJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"reportId":        reportID,
+		"campaignId":      campaignID,
+		"startupId":       startupID,
+		"periodStart":     periodStart,
+		"periodEnd":       periodEnd,
+		"revenue":         revenue,
+		"currency":        currency,
+		"late":            report.Late,
+		"totalObligation": report.TotalObligation,
+		"distributionId":  report.DistributionID,
+		"obligations":     report.Obligations,
+		"channel":         "common-channel",
+		"action":          "REVENUE_REPORTED",
+		"timestamp":       now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("RevenueReported", eventJSON)
+
+	response := map[string]interface{}{
+		"message":         "Revenue report recorded",
+		"reportId":        reportID,
+		"late":            report.Late,
+		"dueAt":           report.DueAt,
+		"totalObligation": report.TotalObligation,
+		"distributionId":  report.DistributionID,
+		"obligations":     report.Obligations,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// FlagOverdueRevenueReports flags active revenue-share agreements whose next revenue report is past due
+// A single RevenueReportsOverdue event lists the newly flagged agreements
+// Channel: common-channel
+// Endorsers: PlatformOrg (multi-party visibility)
+func (p *PlatformContract) FlagOverdueRevenueReports(ctx contractapi.TransactionContextInterface) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	agreements, err := getRevenueShareAgreements(ctx, map[string]interface{}{})
+	if err != nil {
+		return "", err
+	}
+
+	flagged := []map[string]interface{}{}
+	for _, agreement := range agreements {
+		dueAt, err := time.Parse(time.RFC3339, agreement.RevenueShare.NextReportDueAt)
+		if err != nil || agreement.RevenueShare.ReportOverdue || !txTime.After(dueAt) {
+			continue
+		}
+
+		agreement.RevenueShare.ReportOverdue = true
+		agreementJSON, err := json.Marshal(agreement)
+		if err != nil {
+			return "", err
+		}
+		err = ctx.GetStub().PutState(agreement.AgreementID, agreementJSON)
+		if err != nil {
+			return "", err
+		}
+
+		flagged = append(flagged, map[string]interface{}{
+			"agreementId":     agreement.AgreementID,
+			"campaignId":      agreement.CampaignID,
+			"startupId":       agreement.StartupID,
+			"investorId":      agreement.InvestorID,
+			"nextReportDueAt": agreement.RevenueShare.NextReportDueAt,
+		})
+	}
+
+	// Emit one event for the whole sweep (Fabric keeps only the last event per transaction)
+	if len(flagged) > 0 {
+		eventPayload := map[string]interface{}{
+			"agreements": flagged,
+			"channel":    "common-channel",
+			"action":     "REVENUE_REPORTS_OVERDUE",
+			"timestamp":  now,
+		}
+		eventJSON, _ := json.Marshal(eventPayload)
+		ctx.GetStub().SetEvent("RevenueReportsOverdue", eventJSON)
+	}
+
+	response := map[string]interface{}{
+		"message":      fmt.Sprintf("Flagged %d agreements with overdue revenue reports", len(flagged)),
+		"flaggedCount": len(flagged),
+		"agreements":   flagged,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// EQUITY CROWDFUNDING
+// Campaigns offer a share class; witnessed agreements issue shares into the
+// startup's cap table, which is snapshotted whenever a campaign closes
+// ============================================================================
+
+// RecordShareClass sets the share class a campaign offers, before any agreement is witnessed
+// The first share class seeds the startup's cap table with the founders' pre-money shares
+// Invoked by StartupContract.OfferShareClass on common-channel
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (p *PlatformContract) RecordShareClass(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	startupID string,
+	className string,
+	preMoneyValuation float64,
+	pricePerShare float64,
+	minShares int,
+	maxShares int,
+) (string, error) {
+	if err := requireMSP(ctx, "StartupOrgMSP"); err != nil {
+		return "", err
+	}
+	if className == "" {
+		return "", fmt.Errorf("share class name is required")
+	}
+	if preMoneyValuation <= 0 || pricePerShare <= 0 {
+		return "", fmt.Errorf("pre-money valuation and price per share must be positive")
+	}
+	if minShares < 1 || maxShares < minShares {
+		return "", fmt.Errorf("shares per investor must be at least 1 and maximum must not be below minimum")
+	}
+
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign PublishedCampaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+	if campaign.StartupID != startupID {
+		return "", fmt.Errorf("campaign %s does not belong to startup %s", campaignID, startupID)
+	}
+	if campaign.Status == "CLOSED" {
+		return "", fmt.Errorf("campaign %s is closed", campaignID)
+	}
+	if len(campaign.AgreementIDs) > 0 {
+		return "", fmt.Errorf("campaign %s already has witnessed agreements", campaignID)
+	}
+	if len(campaign.RewardTierIDs) > 0 {
+		return "", fmt.Errorf("campaign %s is a reward campaign", campaignID)
+	}
+	loan, err := getLoan(ctx, campaignID)
+	if err != nil {
+		return "", err
+	}
+	if loan != nil {
+		return "", fmt.Errorf("campaign %s is a lending campaign", campaignID)
+	}
+	revenueShare, err := getRevenueShareOffer(ctx, campaignID)
+	if err != nil {
+		return "", err
+	}
+	if revenueShare != nil {
+		return "", fmt.Errorf("campaign %s is a revenue-share campaign", campaignID)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	campaign.ShareClass = ShareClass{
+		ClassName:         className,
+		PreMoneyValuation: preMoneyValuation,
+		PricePerShare:     pricePerShare,
+		PreMoneyShares:    int(math.Round(preMoneyValuation / pricePerShare)),
+		MinShares:         minShares,
+		MaxShares:         maxShares,
+	}
+	campaign.UpdatedAt = now
+
+	updatedJSON, err := json.Marshal(campaign)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(campaignID, updatedJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// A startup's first priced round: the pre-money shares are held by the founders
+	capTable, err := getCapTable(ctx, startupID)
+	if err != nil {
+		return "", err
+	}
+	if len(capTable.Issuances) == 0 {
+		capTable.Issuances = append(capTable.Issuances, ShareIssuance{
+			HolderID:   "FOUNDERS",
+			ShareClass: "COMMON",
+			Shares:     campaign.ShareClass.PreMoneyShares,
+			CampaignID: campaignID,
+			IssuedAt:   now,
+		})
+		if err := putCapTable(ctx, capTable, now); err != nil {
+			return "", err
+		}
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"campaignId":        campaignID,
+		"startupId":         startupID,
+		"className":         className,
+		"preMoneyValuation": preMoneyValuation,
+		"pricePerShare":     pricePerShare,
+		"preMoneyShares":    campaign.ShareClass.PreMoneyShares,
+		"minShares":         minShares,
+		"maxShares":         maxShares,
+		"channel":           "common-channel",
+		"action":            "SHARE_CLASS_RECORDED",
+		"timestamp":         now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("ShareClassRecorded", eventJSON)
+
+	response := map[string]interface{}{
+		"message":    "Share class recorded",
+		"campaignId": campaignID,
+		"shareClass": campaign.ShareClass,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ConvertInstruments converts a startup's outstanding SAFEs and convertible notes into a priced round
+// The round is an equity campaign that closed SUCCESSFUL or PARTIALLY_FUNDED. Each instrument converts
+// its principal (plus simple interest for notes) at the lowest of the round price, the discounted price
+// and the cap price (valuation cap / pre-money shares); MFN SAFEs take the best cap and discount of
+// the startup's SAFEs being converted
+// Channel: common-channel
+// Endorsers: PlatformOrg (multi-party visibility)
+func (p *PlatformContract) ConvertInstruments(
+	ctx contractapi.TransactionContextInterface,
+	conversionID string,
+	campaignID string,
+) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+
+	existingJSON, err := ctx.GetStub().GetState(conversionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read conversion: %v", err)
+	}
+	if existingJSON != nil {
+		return "", fmt.Errorf("conversion %s already exists", conversionID)
+	}
+
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign PublishedCampaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+	shareClass := campaign.ShareClass
+	if shareClass.ClassName == "" {
+		return "", fmt.Errorf("campaign %s is not a priced round", campaignID)
+	}
+
+	// The closure snapshot tells whether the round completed
+	snapshotJSON, err := ctx.GetStub().GetState(capTableSnapshotKey(campaignID))
+	if err != nil {
+		return "", fmt.Errorf("failed to read cap table snapshot: %v", err)
+	}
+	var snapshot CapTableSnapshot
+	if snapshotJSON != nil {
+		if err := json.Unmarshal(snapshotJSON, &snapshot); err != nil {
+			return "", err
+		}
+	}
+	if snapshot.FinalStatus != "SUCCESSFUL" && snapshot.FinalStatus != "PARTIALLY_FUNDED" {
+		return "", fmt.Errorf("priced round %s has not closed successfully", campaignID)
+	}
+
+	agreements, err := getOutstandingInstruments(ctx, campaign.StartupID)
+	if err != nil {
+		return "", err
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	// Best terms among the SAFEs being converted, for MFN holders
+	bestCap, bestDiscount := 0.0, 0.0
+	for _, agreement := range agreements {
+		if agreement.Instrument.InstrumentType != "SAFE" || agreement.Currency != campaign.Currency {
+			continue
+		}
+		if agreement.Instrument.ValuationCap > 0 && (bestCap == 0 || agreement.Instrument.ValuationCap < bestCap) {
+			bestCap = agreement.Instrument.ValuationCap
+		}
+		bestDiscount = math.Max(bestDiscount, agreement.Instrument.DiscountPercent)
+	}
+
+	capTable, err := getCapTable(ctx, campaign.StartupID)
+	if err != nil {
+		return "", err
+	}
+
+	conversion := InstrumentConversion{
+		ConversionID:       conversionID,
+		CampaignID:         campaignID,
+		StartupID:          campaign.StartupID,
+		ShareClass:         shareClass.ClassName,
+		RoundPricePerShare: shareClass.PricePerShare,
+		Conversions:        []ConvertedInstrument{},
+		ConvertedAt:        now,
+	}
+	for _, agreement := range agreements {
+		if agreement.Currency != campaign.Currency {
+			continue
+		}
+		principal, err := agreementInvestedAmount(ctx, agreement)
+		if err != nil {
+			return "", err
+		}
+		if principal <= 0 {
+			continue
+		}
+
+		terms := agreement.Instrument
+		converted := ConvertedInstrument{
+			AgreementID:       agreement.AgreementID,
+			InvestorID:        agreement.InvestorID,
+			InstrumentType:    terms.InstrumentType,
+			Principal:         principal,
+			MostFavoredNation: terms.MostFavoredNation,
+		}
+		if terms.MostFavoredNation {
+			if bestCap > 0 && (terms.ValuationCap == 0 || bestCap < terms.ValuationCap) {
+				terms.ValuationCap = bestCap
+			}
+			terms.DiscountPercent = math.Max(terms.DiscountPercent, bestDiscount)
+		}
+
+		// Notes accrue simple interest from witnessing until conversion
+		if terms.InstrumentType == "CONVERTIBLE_NOTE" {
+			if witnessedAt, err := time.Parse(time.RFC3339, agreement.WitnessedAt); err == nil && txTime.After(witnessedAt) {
+				years := txTime.Sub(witnessedAt).Hours() / 24 / 365
+				converted.AccruedInterest = math.Round(principal*terms.InterestRate*years) / 100
+			}
+		}
+
+		converted.ConversionPrice, converted.PriceBasis = shareClass.PricePerShare, "ROUND"
+		if terms.DiscountPercent > 0 {
+			if price := shareClass.PricePerShare * (1 - terms.DiscountPercent/100); price < converted.ConversionPrice {
+				converted.ConversionPrice, converted.PriceBasis = price, "DISCOUNT"
+			}
+		}
+		if terms.ValuationCap > 0 && shareClass.PreMoneyShares > 0 {
+			if price := terms.ValuationCap / float64(shareClass.PreMoneyShares); price < converted.ConversionPrice {
+				converted.ConversionPrice, converted.PriceBasis = price, "CAP"
+			}
+		}
+		converted.Shares = int(math.Floor((principal+converted.AccruedInterest)/converted.ConversionPrice + 1e-9))
+
+		capTable.Issuances = append(capTable.Issuances, ShareIssuance{
+			HolderID:      agreement.InvestorID,
+			ShareClass:    shareClass.ClassName,
+			Shares:        converted.Shares,
+			PricePerShare: converted.ConversionPrice,
+			CampaignID:    campaignID,
+			AgreementID:   agreement.AgreementID,
+			IssuedAt:      now,
+		})
+
+		agreement.ShareClass = shareClass.ClassName
+		agreement.SharesIssued = converted.Shares
+		agreement.ConversionID = conversionID
+		agreementJSON, err := json.Marshal(agreement)
+		if err != nil {
+			return "", err
+		}
+		err = ctx.GetStub().PutState(agreement.AgreementID, agreementJSON)
+		if err != nil {
+			return "", err
+		}
+
+		conversion.Conversions = append(conversion.Conversions, converted)
+		conversion.TotalShares += converted.Shares
+	}
+	if len(conversion.Conversions) == 0 {
+		return "", fmt.Errorf("startup %s has no outstanding %s SAFEs or convertible notes", campaign.StartupID, campaign.Currency)
+	}
+
+	if err := putCapTable(ctx, capTable, now); err != nil {
+		return "", err
+	}
+
+	conversionJSON, err := json.Marshal(conversion)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(conversionID, conversionJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"conversionId": conversionID,
+		"campaignId":   campaignID,
+		"startupId":    campaign.StartupID,
+		"shareClass":   shareClass.ClassName,
+		"conversions":  conversion.Conversions,
+		"totalShares":  conversion.TotalShares,
+		"channel":      "common-channel",
+		"action":       "INSTRUMENTS_CONVERTED",
+		"timestamp":    now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("InstrumentsConverted", eventJSON)
+
+	response := map[string]interface{}{
+		"message":      fmt.Sprintf("Converted %d instruments into %d %s shares", len(conversion.Conversions), conversion.TotalShares, shareClass.ClassName),
+		"conversionId": conversionID,
+		"conversions":  conversion.Conversions,
+		"totalShares":  conversion.TotalShares,
+		"capTable":     capTableOwnership(capTable),
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// DEBT CROWDFUNDING
+// Lending campaigns pool their investors' agreements into one loan, repaid
+// monthly by the startup and allocated to the lenders pro-rata
+// ============================================================================
+
+// loanDefaultDays is how long an installment can be past due before the loan defaults
+const loanDefaultDays = 90
+
+// RecordLoanTerms makes a campaign a lending campaign, before any agreement is witnessed
+// Every agreement witnessed in the campaign becomes a loan with these terms
+// Invoked by StartupContract.OfferLoanTerms on common-channel
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (p *PlatformContract) RecordLoanTerms(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	startupID string,
+	interestRate float64,
+	termMonths int,
+	amortizationType string,
+) (string, error) {
+	if err := requireMSP(ctx, "StartupOrgMSP"); err != nil {
+		return "", err
+	}
+	if interestRate < 0 {
+		return "", fmt.Errorf("interest rate cannot be negative")
+	}
+	if termMonths < 1 {
+		return "", fmt.Errorf("loan term must be at least 1 month")
+	}
+	validTypes := map[string]bool{"EQUAL_PAYMENT": true, "EQUAL_PRINCIPAL": true, "INTEREST_ONLY": true}
+	if !validTypes[amortizationType] {
+		return "", fmt.Errorf("invalid amortization type: %s. Must be EQUAL_PAYMENT, EQUAL_PRINCIPAL or INTEREST_ONLY", amortizationType)
+	}
+
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign PublishedCampaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+	if campaign.StartupID != startupID {
+		return "", fmt.Errorf("campaign %s does not belong to startup %s", campaignID, startupID)
+	}
+	if campaign.Status == "CLOSED" {
+		return "", fmt.Errorf("campaign %s is closed", campaignID)
+	}
+	if len(campaign.AgreementIDs) > 0 {
+		return "", fmt.Errorf("campaign %s already has witnessed agreements", campaignID)
+	}
+	if campaign.ShareClass.ClassName != "" {
+		return "", fmt.Errorf("campaign %s is an equity offering", campaignID)
+	}
+	if len(campaign.RewardTierIDs) > 0 {
+		return "", fmt.Errorf("campaign %s is a reward campaign", campaignID)
+	}
+	revenueShare, err := getRevenueShareOffer(ctx, campaignID)
+	if err != nil {
+		return "", err
+	}
+	if revenueShare != nil {
+		return "", fmt.Errorf("campaign %s is a revenue-share campaign", campaignID)
+	}
+
+	loan, err := getLoan(ctx, campaignID)
+	if err != nil {
+		return "", err
+	}
+	if loan != nil && loan.Status != "OFFERED" {
+		return "", fmt.Errorf("loan for campaign %s has already been originated", campaignID)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	loan = &Loan{
+		LoanID:           loanKey(campaignID),
+		CampaignID:       campaignID,
+		StartupID:        startupID,
+		Currency:         campaign.Currency,
+		InterestRate:     interestRate,
+		TermMonths:       termMonths,
+		AmortizationType: amortizationType,
+		Lenders:          []LoanLender{},
+		Schedule:         []LoanInstallment{},
+		Status:           "OFFERED",
+		OfferedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := putLoan(ctx, loan); err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"loanId":           loan.LoanID,
+		"campaignId":       campaignID,
+		"startupId":        startupID,
+		"interestRate":     interestRate,
+		"termMonths":       termMonths,
+		"amortizationType": amortizationType,
+		"channel":          "common-channel",
+		"action":           "LOAN_TERMS_RECORDED",
+		"timestamp":        now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("LoanTermsRecorded", eventJSON)
+
+	response := map[string]interface{}{
+		"message":          "Loan terms recorded",
+		"loanId":           loan.LoanID,
+		"campaignId":       campaignID,
+		"interestRate":     interestRate,
+		"termMonths":       termMonths,
+		"amortizationType": amortizationType,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// OriginateLoan pools a closed lending campaign's agreements into its loan and generates the
+// monthly repayment schedule; firstPaymentDate is YYYY-MM-DD
+// Channel: common-channel
+// Endorsers: PlatformOrg (multi-party visibility)
+func (p *PlatformContract) OriginateLoan(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	firstPaymentDate string,
+) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+	firstDueAt, err := parseDate(firstPaymentDate)
+	if err != nil {
+		return "", fmt.Errorf("invalid first payment date: %v", err)
+	}
+
+	loan, err := getLoan(ctx, campaignID)
+	if err != nil {
+		return "", err
+	}
+	if loan == nil {
+		return "", fmt.Errorf("campaign %s is not a lending campaign", campaignID)
+	}
+	if loan.Status != "OFFERED" {
+		return "", fmt.Errorf("loan for campaign %s has already been originated", campaignID)
+	}
+
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	var campaign PublishedCampaign
+	if campaignJSON != nil {
+		if err := json.Unmarshal(campaignJSON, &campaign); err != nil {
+			return "", err
+		}
+	}
+	if campaign.Status != "CLOSED" {
+		return "", fmt.Errorf("campaign %s must be closed before its loan is originated", campaignID)
+	}
+
+	// Lenders are the campaign's witnessed agreements, less refunds
+	stakes, principal, err := getInvestorStakes(ctx, campaignID, loan.StartupID, loan.Currency)
+	if err != nil {
+		return "", err
+	}
+	if len(stakes) == 0 {
+		return "", fmt.Errorf("campaign %s has no funded %s agreements", campaignID, loan.Currency)
+	}
+	for _, stake := range stakes {
+		loan.Lenders = append(loan.Lenders, LoanLender{
+			InvestorID:           stake.InvestorID,
+			AgreementIDs:         stake.AgreementIDs,
+			Principal:            stake.Invested,
+			ShareRatio:           stake.Invested / principal,
+			OutstandingPrincipal: stake.Invested,
+		})
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	loan.Principal = principal
+	loan.OutstandingPrincipal = principal
+	loan.Schedule = buildLoanSchedule(principal, loan.InterestRate, loan.TermMonths, loan.AmortizationType, firstDueAt)
+	loan.Status = "ACTIVE"
+	loan.OriginatedAt = now
+	loan.UpdatedAt = now
+	if err := putLoan(ctx, loan); err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"loanId":           loan.LoanID,
+		"campaignId":       campaignID,
+		"startupId":        loan.StartupID,
+		"principal":        principal,
+		"currency":         loan.Currency,
+		"interestRate":     loan.InterestRate,
+		"termMonths":       loan.TermMonths,
+		"amortizationType": loan.AmortizationType,
+		"lenderCount":      len(loan.Lenders),
+		"channel":          "common-channel",
+		"action":           "LOAN_ORIGINATED",
+		"timestamp":        now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("LoanOriginated", eventJSON)
+
+	response := map[string]interface{}{
+		"message":   "Loan originated",
+		"loanId":    loan.LoanID,
+		"principal": principal,
+		"lenders":   loan.Lenders,
+		"schedule":  loan.Schedule,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// RecordLoanRepayment applies a startup repayment to the schedule, oldest installment first and
+// interest before principal, and pays the lenders pro-rata from the startup's cash
+// Invoked by StartupContract.RepayLoan on common-channel
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (p *PlatformContract) RecordLoanRepayment(
+	ctx contractapi.TransactionContextInterface,
+	repaymentID string,
+	campaignID string,
+	startupID string,
+	amount float64,
+) (string, error) {
+	if err := requireMSP(ctx, "StartupOrgMSP"); err != nil {
+		return "", err
+	}
+	if amount <= 0 {
+		return "", fmt.Errorf("repayment amount must be positive")
+	}
+
+	existingJSON, err := ctx.GetStub().GetState(repaymentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read repayment: %v", err)
+	}
+	if existingJSON != nil {
+		return "", fmt.Errorf("repayment %s already exists", repaymentID)
+	}
+
+	loan, err := getLoan(ctx, campaignID)
+	if err != nil {
+		return "", err
+	}
+	if loan == nil {
+		return "", fmt.Errorf("campaign %s is not a lending campaign", campaignID)
+	}
+	if loan.StartupID != startupID {
+		return "", fmt.Errorf("loan for campaign %s does not belong to startup %s", campaignID, startupID)
+	}
+	if loan.Status == "OFFERED" || loan.Status == "REPAID" {
+		return "", fmt.Errorf("loan for campaign %s is %s and cannot be repaid", campaignID, loan.Status)
+	}
+
+	remainingDue := 0.0
+	for _, installment := range loan.Schedule {
+		remainingDue += installment.Principal + installment.Interest - installment.PrincipalPaid - installment.InterestPaid
+	}
+	if amount > remainingDue+journalTolerance {
+		return "", fmt.Errorf("repayment %.2f exceeds the %.2f remaining on the loan", amount, remainingDue)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	// Oldest installment first, interest before principal
+	remaining := amount
+	principalPaid, interestPaid := 0.0, 0.0
+	for idx := range loan.Schedule {
+		installment := &loan.Schedule[idx]
+		if installment.Status == "PAID" || remaining <= journalTolerance {
+			continue
+		}
+		interest := math.Min(remaining, installment.Interest-installment.InterestPaid)
+		installment.InterestPaid = math.Round((installment.InterestPaid+interest)*100) / 100
+		remaining -= interest
+		principal := math.Min(remaining, installment.Principal-installment.PrincipalPaid)
+		installment.PrincipalPaid = math.Round((installment.PrincipalPaid+principal)*100) / 100
+		remaining -= principal
+		interestPaid += interest
+		principalPaid += principal
+
+		installment.Status = "PARTIAL"
+		if installment.PrincipalPaid >= installment.Principal-journalTolerance && installment.InterestPaid >= installment.Interest-journalTolerance {
+			installment.Status = "PAID"
+			installment.PaidAt = now
+		}
+	}
+	principalPaid = math.Round(principalPaid*100) / 100
+	interestPaid = math.Round(interestPaid*100) / 100
+
+	// Lenders share principal and interest by their part of the loan
+	ratios := []float64{}
+	for _, lender := range loan.Lenders {
+		ratios = append(ratios, lender.ShareRatio)
+	}
+	principalShares := splitProRata(principalPaid, ratios)
+	interestShares := splitProRata(interestPaid, ratios)
+
+	repayment := LoanRepayment{
+		RepaymentID:     repaymentID,
+		LoanID:          loan.LoanID,
+		CampaignID:      campaignID,
+		Amount:          amount,
+		PrincipalAmount: principalPaid,
+		InterestAmount:  interestPaid,
+		Allocations:     []LoanRepaymentAllocation{},
+		PaidAt:          now,
+	}
+	lines := []JournalLine{{Account: startupCashAccount(startupID), Credit: principalPaid + interestPaid}}
+	for idx := range loan.Lenders {
+		lender := &loan.Lenders[idx]
+		lender.PrincipalRepaid = math.Round((lender.PrincipalRepaid+principalShares[idx])*100) / 100
+		lender.InterestPaid = math.Round((lender.InterestPaid+interestShares[idx])*100) / 100
+		lender.OutstandingPrincipal = math.Round((lender.Principal-lender.PrincipalRepaid)*100) / 100
+		repayment.Allocations = append(repayment.Allocations, LoanRepaymentAllocation{
+			InvestorID: lender.InvestorID,
+			Principal:  principalShares[idx],
+			Interest:   interestShares[idx],
+		})
+		if paid := principalShares[idx] + interestShares[idx]; paid > 0 {
+			lines = append(lines, JournalLine{Account: investorWalletAccount(lender.InvestorID), Debit: paid})
+		}
+	}
+
+	err = postJournalEntry(ctx, "LOAN_REPAYMENT", repaymentID, campaignID, loan.Currency, lines,
+		fmt.Sprintf("Loan repayment for campaign %s", campaignID), now)
+	if err != nil {
+		return "", err
+	}
+
+	loan.PrincipalRepaid = math.Round((loan.PrincipalRepaid+principalPaid)*100) / 100
+	loan.InterestPaid = math.Round((loan.InterestPaid+interestPaid)*100) / 100
+	loan.OutstandingPrincipal = math.Round((loan.Principal-loan.PrincipalRepaid)*100) / 100
+	assessLoan(loan, txTime)
+	loan.UpdatedAt = now
+	if err := putLoan(ctx, loan); err != nil {
+		return "", err
+	}
+
+	repayment.LoanStatus = loan.Status
+	repaymentJSON, err := json.Marshal(repayment)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(repaymentID, repaymentJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"repaymentId":          repaymentID,
+		"loanId":               loan.LoanID,
+		"campaignId":           campaignID,
+		"startupId":            startupID,
+		"amount":               amount,
+		"principalAmount":      principalPaid,
+		"interestAmount":       interestPaid,
+		"allocations":          repayment.Allocations,
+		"outstandingPrincipal": loan.OutstandingPrincipal,
+		"loanStatus":           loan.Status,
+		"channel":              "common-channel",
+		"action":               "LOAN_REPAID",
+		"timestamp":            now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("LoanRepaid", eventJSON)
+
+	response := map[string]interface{}{
+		"message":              "Loan repayment recorded",
+		"repaymentId":          repaymentID,
+		"principalAmount":      principalPaid,
+		"interestAmount":       interestPaid,
+		"allocations":          repayment.Allocations,
+		"outstandingPrincipal": loan.OutstandingPrincipal,
+		"daysPastDue":          loan.DaysPastDue,
+		"loanStatus":           loan.Status,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// AssessLoanDelinquency updates every active loan's days past due: loans with a past-due installment
+// are DELINQUENT, and DEFAULTED once it is 90 days past due
+// A single LoansAssessed event lists the loans whose status changed
+// Channel: common-channel
+// Endorsers: PlatformOrg (multi-party visibility)
+func (p *PlatformContract) AssessLoanDelinquency(ctx contractapi.TransactionContextInterface) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	records, err := getQueryResults(ctx, map[string]interface{}{
+		"loanId": map[string]bool{"$exists": true},
+		"status": map[string]interface{}{"$in": []string{"ACTIVE", "DELINQUENT"}},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	changed := []map[string]interface{}{}
+	for _, record := range records {
+		var loan Loan
+		if json.Unmarshal(record.Value, &loan) != nil || record.Key != loan.LoanID {
+			continue
+		}
+
+		previousStatus, previousDays := loan.Status, loan.DaysPastDue
+		assessLoan(&loan, txTime)
+		if loan.Status == previousStatus && loan.DaysPastDue == previousDays {
+			continue
+		}
+		loan.UpdatedAt = now
+		if err := putLoan(ctx, &loan); err != nil {
+			return "", err
+		}
+
+		if loan.Status != previousStatus {
+			changed = append(changed, map[string]interface{}{
+				"loanId":               loan.LoanID,
+				"campaignId":           loan.CampaignID,
+				"startupId":            loan.StartupID,
+				"previousStatus":       previousStatus,
+				"status":               loan.Status,
+				"daysPastDue":          loan.DaysPastDue,
+				"outstandingPrincipal": loan.OutstandingPrincipal,
+			})
+		}
+	}
+
+	// Emit one event for the whole sweep (Fabric keeps only the last event per transaction)
+	if len(changed) > 0 {
+		eventPayload := map[string]interface{}{
+			"loans":     changed,
+			"channel":   "common-channel",
+			"action":    "LOANS_ASSESSED",
+			"timestamp": now,
+		}
+		eventJSON, _ := json.Marshal(eventPayload)
+		ctx.GetStub().SetEvent("LoansAssessed", eventJSON)
+	}
+
+	response := map[string]interface{}{
+		"message":      fmt.Sprintf("%d loans changed status", len(changed)),
+		"changedCount": len(changed),
+		"loans":        changed,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// REWARD CROWDFUNDING
+// Backers pledge to a campaign's reward tiers; each pledge is held in escrow
+// and released to the startup once the reward is delivered
+// ============================================================================
+
+// fulfillmentOrder is the order pledge fulfillment moves through; statuses never move backwards
+var fulfillmentOrder = map[string]int{"PENDING": 0, "IN_PRODUCTION": 1, "SHIPPED": 2, "DELIVERED": 3}
+
+// RecordRewardTier adds a reward tier to a campaign
+// Invoked by StartupContract.OfferRewardTier on common-channel
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (p *PlatformContract) RecordRewardTier(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	startupID string,
+	tierID string,
+	title string,
+	description string,
+	pledgeAmount float64,
+	quantityLimit int,
+	estimatedDelivery string,
+	shippingRegionsJSON string, // e.g. ["US","EU"] or ["WORLDWIDE"]; empty for digital rewards
+) (string, error) {
+	if err := requireMSP(ctx, "StartupOrgMSP"); err != nil {
+		return "", err
+	}
+	if pledgeAmount <= 0 {
+		return "", fmt.Errorf("pledge amount must be positive")
+	}
+	if quantityLimit < 0 {
+		return "", fmt.Errorf("quantity limit cannot be negative")
+	}
+	if _, err := parseDate(estimatedDelivery); err != nil {
+		return "", fmt.Errorf("invalid estimated delivery date: %v", err)
+	}
+
+	shippingRegions := []string{}
+	if shippingRegionsJSON != "" {
+		var regions []string
+		if err := json.Unmarshal([]byte(shippingRegionsJSON), &regions); err != nil {
+			return "", fmt.Errorf("failed to parse shipping regions: %v", err)
+		}
+		for _, region := range regions {
+			if region = strings.ToUpper(strings.TrimSpace(region)); region != "" {
+				shippingRegions = append(shippingRegions, region)
+			}
+		}
+	}
+
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign PublishedCampaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+	if campaign.StartupID != startupID {
+		return "", fmt.Errorf("campaign %s does not belong to startup %s", campaignID, startupID)
+	}
+	if campaign.Status == "CLOSED" {
+		return "", fmt.Errorf("campaign %s is closed", campaignID)
+	}
+	if campaign.ShareClass.ClassName != "" {
+		return "", fmt.Errorf("campaign %s is an equity offering", campaignID)
+	}
+	loan, err := getLoan(ctx, campaignID)
+	if err != nil {
+		return "", err
+	}
+	if loan != nil {
+		return "", fmt.Errorf("campaign %s is a lending campaign", campaignID)
+	}
+	revenueShare, err := getRevenueShareOffer(ctx, campaignID)
+	if err != nil {
+		return "", err
+	}
+	if revenueShare != nil {
+		return "", fmt.Errorf("campaign %s is a revenue-share campaign", campaignID)
+	}
+
+	tierKey := rewardTierKey(campaignID, tierID)
+	existingJSON, err := ctx.GetStub().GetState(tierKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read reward tier: %v", err)
+	}
+	if existingJSON != nil {
+		return "", fmt.Errorf("reward tier %s already exists", tierID)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	tier := RewardTier{
+		TierID:            tierID,
+		CampaignID:        campaignID,
+		StartupID:         startupID,
+		Title:             title,
+		Description:       description,
+		PledgeAmount:      pledgeAmount,
+		Currency:          campaign.Currency,
+		QuantityLimit:     quantityLimit,
+		QuantityPledged:   0,
+		EstimatedDelivery: estimatedDelivery,
+		ShippingRegions:   shippingRegions,
+		Status:            "AVAILABLE",
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := putRewardTier(ctx, &tier); err != nil {
+		return "", err
+	}
+
+	campaign.RewardTierIDs = append(campaign.RewardTierIDs, tierID)
+	campaign.UpdatedAt = now
+	updatedCampaignJSON, err := json.Marshal(campaign)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(campaignID, updatedCampaignJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"tierId":            tierID,
+		"campaignId":        campaignID,
+		"startupId":         startupID,
+		"pledgeAmount":      pledgeAmount,
+		"quantityLimit":     quantityLimit,
+		"estimatedDelivery": estimatedDelivery,
+		"channel":           "common-channel",
+		"action":            "REWARD_TIER_RECORDED",
+		"timestamp":         now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("RewardTierRecorded", eventJSON)
+
+	response := map[string]interface{}{
+		"message":      "Reward tier recorded",
+		"tierId":       tierID,
+		"campaignId":   campaignID,
+		"pledgeAmount": pledgeAmount,
+		"currency":     campaign.Currency,
+		"status":       tier.Status,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// RecordPledge records a backer's pledge to a reward tier and moves the pledge into escrow
+// Sold-out tiers are rejected; the backer must ship to one of the tier's regions
+// backerID must match the investorId attribute of the caller's identity, and the campaign must be open
+// Invoked by InvestorContract.PledgeToRewardTier on common-channel
+// Channel: common-channel
+// Endorsers: InvestorOrg, PlatformOrg
+func (p *PlatformContract) RecordPledge(
+	ctx contractapi.TransactionContextInterface,
+	pledgeID string,
+	campaignID string,
+	tierID string,
+	backerID string,
+	amount float64,
+	shippingRegion string,
+) (string, error) {
+	if err := requireMSP(ctx, "InvestorOrgMSP"); err != nil {
+		return "", err
+	}
+	// The pledge is paid from the backer's wallet, so only the backer can make it
+	if err := requireInvestorIdentity(ctx, backerID); err != nil {
+		return "", err
+	}
+
+	existingJSON, err := ctx.GetStub().GetState(pledgeID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read pledge: %v", err)
+	}
+	if existingJSON != nil {
+		return "", fmt.Errorf("pledge %s already exists", pledgeID)
+	}
+
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign PublishedCampaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+	if campaign.Status == "CLOSED" {
+		return "", fmt.Errorf("campaign %s is closed", campaignID)
+	}
+
+	// Pledges are taken only inside the campaign's funding window
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	opensAt, closesAt := campaignWindow(campaign.OpenDate, campaign.CloseDate, campaign.DurationDays)
+	if !opensAt.IsZero() && txTime.Before(opensAt) {
+		return "", fmt.Errorf("campaign %s is not open yet, opens at %s", campaignID, opensAt.Format(time.RFC3339))
+	}
+	if !closesAt.IsZero() && !txTime.Before(closesAt) {
+		return "", fmt.Errorf("campaign %s closed at %s", campaignID, closesAt.Format(time.RFC3339))
+	}
+
+	tier, err := getRewardTier(ctx, campaignID, tierID)
+	if err != nil {
+		return "", err
+	}
+	if tier.QuantityLimit > 0 && tier.QuantityPledged >= tier.QuantityLimit {
+		return "", fmt.Errorf("reward tier %s is sold out", tierID)
+	}
+	if amount < tier.PledgeAmount {
+		return "", fmt.Errorf("pledge %.2f is below the %.2f minimum for reward tier %s", amount, tier.PledgeAmount, tierID)
+	}
+
+	shippingRegion = strings.ToUpper(strings.TrimSpace(shippingRegion))
+	if len(tier.ShippingRegions) > 0 {
+		ships := false
+		for _, region := range tier.ShippingRegions {
+			if region == "WORLDWIDE" || region == shippingRegion {
+				ships = true
+				break
+			}
+		}
+		if !ships {
+			return "", fmt.Errorf("reward tier %s does not ship to %s", tierID, shippingRegion)
+		}
+	}
+
+	now := txTime.Format(time.RFC3339)
+
+	// Pledge escrows have their own prefix so they cannot collide with agreement escrows
+	escrowID := pledgeEscrowID(pledgeID)
+	existingEscrowJSON, err := ctx.GetStub().GetState(escrowID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read escrow: %v", err)
+	}
+	if existingEscrowJSON != nil {
+		return "", fmt.Errorf("escrow %s already exists", escrowID)
+	}
+
+	// The pledge is held in escrow like an investment: it counts towards the campaign
+	// total and is refunded if the campaign fails or is cancelled
+	escrow := FundEscrow{
+		EscrowID:          escrowID,
+		CampaignID:        campaignID,
+		InvestorID:        backerID,
+		StartupID:         campaign.StartupID,
+		TotalAmount:       amount,
+		ReleasedAmount:    0,
+		HeldAmount:        amount,
+		PendingReleaseIDs: []string{},
+		Currency:          tier.Currency,
+		Status:            "ACTIVE",
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	escrowJSON, err := json.Marshal(escrow)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(escrow.EscrowID, escrowJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Backer tokens are locked into escrow (fails if the wallet balance is too low)
+	err = postJournalEntry(ctx, "ESCROW_FUNDED", escrow.EscrowID, campaignID, tier.Currency, []JournalLine{
+		{Account: escrowAccount(escrow.EscrowID), Debit: amount},
+		{Account: investorWalletAccount(backerID), Credit: amount},
+	}, fmt.Sprintf("Pledge %s to reward tier %s", pledgeID, tierID), now)
+	if err != nil {
+		return "", err
+	}
+
+	// The campaign lists its pledges so refunds find their escrows by key
+	campaign.PledgeIDs = append(campaign.PledgeIDs, pledgeID)
+	campaign.UpdatedAt = now
+	updatedCampaignJSON, err := json.Marshal(campaign)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(campaignID, updatedCampaignJSON)
+	if err != nil {
+		return "", err
+	}
+
+	tier.QuantityPledged++
+	if tier.QuantityLimit > 0 && tier.QuantityPledged >= tier.QuantityLimit {
+		tier.Status = "SOLD_OUT"
+	}
+	tier.UpdatedAt = now
+	if err := putRewardTier(ctx, tier); err != nil {
+		return "", err
+	}
+
+	pledge := RewardPledge{
+		PledgeID:           pledgeID,
+		CampaignID:         campaignID,
+		TierID:             tierID,
+		StartupID:          campaign.StartupID,
+		BackerID:           backerID,
+		Amount:             amount,
+		Currency:           tier.Currency,
+		ShippingRegion:     shippingRegion,
+		EscrowID:           escrow.EscrowID,
+		Status:             "PLEDGED",
+		FulfillmentStatus:  "PENDING",
+		EstimatedDelivery:  tier.EstimatedDelivery,
+		FulfillmentHistory: []FulfillmentUpdate{},
+		PledgedAt:          now,
+		UpdatedAt:          now,
+	}
+	if err := putRewardPledge(ctx, &pledge); err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"pledgeId":   pledgeID,
+		"campaignId": campaignID,
+		"tierId":     tierID,
+		"backerId":   backerID,
+		"amount":     amount,
+		"escrowId":   escrow.EscrowID,
+		"tierStatus": tier.Status,
+		"channel":    "common-channel",
+		"action":     "PLEDGE_RECORDED",
+		"timestamp":  now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("PledgeRecorded", eventJSON)
+
+	response := map[string]interface{}{
+		"message":           "Pledge recorded. Funds held in escrow until the reward is delivered.",
+		"pledgeId":          pledgeID,
+		"tierId":            tierID,
+		"escrowId":          escrow.EscrowID,
+		"amount":            amount,
+		"estimatedDelivery": tier.EstimatedDelivery,
+		"quantityLimit":     tier.QuantityLimit,
+		"quantityPledged":   tier.QuantityPledged,
+		"tierStatus":        tier.Status,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// RecordRewardFulfillment records the fulfillment status of a backer's reward
+// DELIVERED releases the pledge escrow to the startup; deliveries after the estimate are flagged late
+// The pledge stays PLEDGED until its release executes, so a failed campaign still refunds it
+// Deliveries on an all-or-nothing campaign that has not closed with the goal met defer the release;
+// recording DELIVERED again after the close releases the escrow
+// Invoked by StartupContract.UpdateRewardFulfillment on common-channel
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (p *PlatformContract) RecordRewardFulfillment(
+	ctx contractapi.TransactionContextInterface,
+	pledgeID string,
+	startupID string,
+	fulfillmentStatus string, // IN_PRODUCTION, SHIPPED, DELIVERED
+	trackingReference string,
+) (string, error) {
+	if err := requireMSP(ctx, "StartupOrgMSP"); err != nil {
+		return "", err
+	}
+
+	pledge, err := getRewardPledge(ctx, pledgeID)
+	if err != nil {
+		return "", err
+	}
+	if pledge.StartupID != startupID {
+		return "", fmt.Errorf("pledge %s does not belong to startup %s", pledgeID, startupID)
+	}
+	if pledge.Status != "PLEDGED" {
+		return "", fmt.Errorf("pledge %s is %s and cannot be updated", pledgeID, pledge.Status)
+	}
+	order, valid := fulfillmentOrder[fulfillmentStatus]
+	if !valid || fulfillmentStatus == "PENDING" {
+		return "", fmt.Errorf("invalid fulfillment status: %s. Must be IN_PRODUCTION, SHIPPED or DELIVERED", fulfillmentStatus)
+	}
+	if order < fulfillmentOrder[pledge.FulfillmentStatus] {
+		return "", fmt.Errorf("pledge %s is already %s", pledgeID, pledge.FulfillmentStatus)
+	}
+	if pledge.ReleaseID != "" {
+		return "", fmt.Errorf("pledge %s already has release %s", pledgeID, pledge.ReleaseID)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	pledge.FulfillmentStatus = fulfillmentStatus
+	if trackingReference != "" {
+		pledge.TrackingReference = trackingReference
+	}
+	pledge.FulfillmentHistory = append(pledge.FulfillmentHistory, FulfillmentUpdate{
+		Status:            fulfillmentStatus,
+		TrackingReference: trackingReference,
+		RecordedAt:        now,
+	})
+	pledge.UpdatedAt = now
+
+	releaseStatus := ""
+	deferredReason := ""
+	if fulfillmentStatus == "DELIVERED" {
+		if rewardDeliveryLate(pledge.EstimatedDelivery, txTime) && !pledge.DeliveryLate {
+			pledge.DeliveryLate = true
+			pledge.FlaggedLateAt = now
+		}
+		if pledge.DeliveredAt == "" {
+			pledge.DeliveredAt = now
+		}
+
+		// All-or-nothing campaigns release only after closing with the goal met;
+		// until then the delivery is recorded and the release deferred
+		if err := checkFundingModelAllowsRelease(ctx, pledge.CampaignID); err != nil {
+			releaseStatus = "DEFERRED"
+			deferredReason = err.Error()
+		} else {
+			// The delivered reward releases the pledge escrow to the startup
+			release, err := releasePledgeEscrow(ctx, pledge, now)
+			if err != nil {
+				return "", err
+			}
+			pledge.ReleaseID = release.ReleaseID
+			releaseStatus = release.Status
+			// Releases awaiting approval fulfil the pledge in ApproveFundRelease
+			if release.Status == "RELEASED" {
+				pledge.Status = "FULFILLED"
+			}
+		}
+	}
+
+	if err := putRewardPledge(ctx, pledge); err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"pledgeId":          pledgeID,
+		"campaignId":        pledge.CampaignID,
+		"tierId":            pledge.TierID,
+		"backerId":          pledge.BackerID,
+		"fulfillmentStatus": fulfillmentStatus,
+		"trackingReference": pledge.TrackingReference,
+		"deliveryLate":      pledge.DeliveryLate,
+		"releaseId":         pledge.ReleaseID,
+		"channel":           "common-channel",
+		"action":            "REWARD_FULFILLMENT_RECORDED",
+		"timestamp":         now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("RewardFulfillmentRecorded", eventJSON)
+
+	response := map[string]interface{}{
+		"message":           fmt.Sprintf("Pledge %s marked %s", pledgeID, fulfillmentStatus),
+		"pledgeId":          pledgeID,
+		"fulfillmentStatus": fulfillmentStatus,
+		"deliveryLate":      pledge.DeliveryLate,
+		"releaseId":         pledge.ReleaseID,
+		"releaseStatus":     releaseStatus,
+		"deferredReason":    deferredReason,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// FlagLateRewardDeliveries flags pledges whose reward has not been delivered by the tier's estimated delivery date
+// A single RewardDeliveriesLate event lists the newly flagged pledges
+// Channel: common-channel
+// Endorsers: PlatformOrg (multi-party visibility)
+func (p *PlatformContract) FlagLateRewardDeliveries(ctx contractapi.TransactionContextInterface) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	pledges, err := getRewardPledges(ctx, map[string]interface{}{
+		"status":       "PLEDGED",
+		"deliveryLate": false,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	flagged := []map[string]interface{}{}
+	for idx := range pledges {
+		pledge := &pledges[idx]
+		// Delivered pledges awaiting their release are not late
+		if pledge.FulfillmentStatus == "DELIVERED" || !rewardDeliveryLate(pledge.EstimatedDelivery, txTime) {
+			continue
+		}
+
+		pledge.DeliveryLate = true
+		pledge.FlaggedLateAt = now
+		pledge.UpdatedAt = now
+		if err := putRewardPledge(ctx, pledge); err != nil {
+			return "", err
+		}
+
+		flagged = append(flagged, map[string]interface{}{
+			"pledgeId":          pledge.PledgeID,
+			"campaignId":        pledge.CampaignID,
+			"tierId":            pledge.TierID,
+			"startupId":         pledge.StartupID,
+			"backerId":          pledge.BackerID,
+			"estimatedDelivery": pledge.EstimatedDelivery,
+			"fulfillmentStatus": pledge.FulfillmentStatus,
+		})
+	}
+
+	// Emit one event for the whole sweep (Fabric keeps only the last event per transaction)
+	if len(flagged) > 0 {
+		eventPayload := map[string]interface{}{
+			"pledges":   flagged,
+			"channel":   "common-channel",
+			"action":    "REWARD_DELIVERIES_LATE",
+			"timestamp": now,
+		}
+		eventJSON, _ := json.Marshal(eventPayload)
+		ctx.GetStub().SetEvent("RewardDeliveriesLate", eventJSON)
+	}
+
+	response := map[string]interface{}{
+		"message":      fmt.Sprintf("Flagged %d pledges with late reward deliveries", len(flagged)),
+		"flaggedCount": len(flagged),
+		"pledges":      flagged,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// PLATFORM FEES
+// ============================================================================
+
+// SetFeeSchedule sets the fee charged on fund releases
+// percentageFee and surcharges are percentages; flatFee is charged per release
+// categorySurchargesJSON: {"Technology": 1.0, ...}
+// Channel: common-channel
+// Endorsers: PlatformOrg
+func (p *PlatformContract) SetFeeSchedule(
+	ctx contractapi.TransactionContextInterface,
+	percentageFee float64,
+	flatFee float64,
+	categorySurchargesJSON string,
+	promotionSurcharge float64,
+) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+	if percentageFee < 0 || flatFee < 0 || promotionSurcharge < 0 {
+		return "", fmt.Errorf("fees cannot be negative")
+	}
+
+	categorySurcharges := map[string]float64{}
+	if categorySurchargesJSON != "" {
+		if err := json.Unmarshal([]byte(categorySurchargesJSON), &categorySurcharges); err != nil {
+			return "", fmt.Errorf("failed to parse category surcharges: %v", err)
+		}
+	}
+	for category, surcharge := range categorySurcharges {
+		if surcharge < 0 {
+			return "", fmt.Errorf("surcharge for category %s cannot be negative", category)
+		}
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	schedule := FeeSchedule{
+		ScheduleID:         "FEE_SCHEDULE",
+		PercentageFee:      percentageFee,
+		FlatFee:            flatFee,
+		CategorySurcharges: categorySurcharges,
+		PromotionSurcharge: promotionSurcharge,
+		UpdatedAt:          now,
+	}
+
+	scheduleJSON, err := json.Marshal(schedule)
+	if err != nil {
+		return "", err
+	}
+
+	err = ctx.GetStub().PutState(schedule.ScheduleID, scheduleJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"percentageFee":      percentageFee,
+		"flatFee":            flatFee,
+		"categorySurcharges": categorySurcharges,
+		"promotionSurcharge": promotionSurcharge,
+		"channel":            "common-channel",
+		"action":             "FEE_SCHEDULE_UPDATED",
+		"timestamp":          now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("FeeScheduleUpdated", eventJSON)
+
+	response := map[string]interface{}{
+		"message":     "Fee schedule updated",
+		"feeSchedule": schedule,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// SetCampaignPromotion marks a published campaign as promoted (promotion surcharge applies)
+// Channel: common-channel
+// Endorsers: PlatformOrg
+func (p *PlatformContract) SetCampaignPromotion(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	isPromoted bool,
+) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign PublishedCampaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	campaign.IsPromoted = isPromoted
+	campaign.UpdatedAt = txTime.Format(time.RFC3339)
+
+	updatedCampaignJSON, err := json.Marshal(campaign)
+	if err != nil {
+		return "", err
+	}
+
+	err = ctx.GetStub().PutState(campaignID, updatedCampaignJSON)
+	if err != nil {
+		return "", err
+	}
+
+	response := map[string]interface{}{
+		"message":    "Campaign promotion updated",
+		"campaignId": campaignID,
+		"isPromoted": isPromoted,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// ACCOUNTING JOURNAL
+// ============================================================================
+
+// RecordFundingReceipt posts the startup's receipt of released funds to the journal
+// amount may be the gross release amount or the net amount after fees; the net amount is posted
+// Invoked by StartupContract.ReceiveFunding on common-channel
+// Channel: common-channel
+func (p *PlatformContract) RecordFundingReceipt(
+	ctx contractapi.TransactionContextInterface,
+	releaseID string,
+	startupID string,
+	amount float64,
+) (string, error) {
+	releaseJSON, err := ctx.GetStub().GetState(releaseID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read release: %v", err)
+	}
+	if releaseJSON == nil {
+		return "", fmt.Errorf("release %s does not exist", releaseID)
+	}
+
+	var release FundRelease
+	err = json.Unmarshal(releaseJSON, &release)
+	if err != nil {
+		return "", err
+	}
+
+	if release.Status != "RELEASED" {
+		return "", fmt.Errorf("release %s has not been released (status: %s)", releaseID, release.Status)
+	}
+	if release.StartupID != startupID {
+		return "", fmt.Errorf("release %s was not made to startup %s", releaseID, startupID)
+	}
+	if release.VestingSchedule.Installments > 0 {
+		return "", fmt.Errorf("release %s vests over time; claim vested funds with ClaimVestedFunds", releaseID)
+	}
+	netAmount := releaseNetAmount(release)
+	if math.Abs(amount-netAmount) > journalTolerance && math.Abs(amount-release.Amount) > journalTolerance {
+		return "", fmt.Errorf("received amount %.2f matches neither the released gross amount %.2f nor the net amount %.2f", amount, release.Amount, netAmount)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	err = postJournalEntry(ctx, "FUNDING_RECEIVED", releaseID, release.CampaignID, release.Currency, []JournalLine{
+		{Account: startupCashAccount(startupID), Debit: netAmount},
+		{Account: startupPayableAccount(startupID), Credit: netAmount},
+	}, fmt.Sprintf("%s funds received", releaseLabel(release)), now)
+	if err != nil {
+		return "", err
+	}
+
+	response := map[string]interface{}{
+		"message":     "Funding receipt posted to journal",
+		"entryId":     journalEntryID("FUNDING_RECEIVED", releaseID),
+		"releaseId":   releaseID,
+		"grossAmount": release.Amount,
+		"amount":      netAmount,
+		"currency":    release.Currency,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// SETTLEMENT TOKENS
+// Balances are keyed by journal account; only PlatformOrg can mint, burn or transfer
+// ============================================================================
+
+// Mint issues tokens to a wallet on fiat deposit
+// account: INVESTOR_WALLET:<investorId> or STARTUP_CASH:<startupId>
+// Channel: common-channel
+func (p *PlatformContract) Mint(
+	ctx contractapi.TransactionContextInterface,
+	depositReference string,
+	account string,
+	currency string,
+	amount float64,
+) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+	if err := checkWalletAccount(account); err != nil {
+		return "", err
+	}
+	if amount <= 0 {
+		return "", fmt.Errorf("amount must be positive")
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	err = postJournalEntry(ctx, "TOKENS_MINTED", depositReference, "", currency, []JournalLine{
+		{Account: account, Debit: amount},
+		{Account: fiatClearingAccount(), Credit: amount},
+	}, "Fiat deposit", now)
+	if err != nil {
+		return "", err
+	}
+
+	return tokenMovementResponse(ctx, "TokensMinted", "TOKENS_MINTED", depositReference, "", account, currency, amount, now)
+}
+
+// Burn destroys tokens from a wallet on fiat withdrawal
+// Channel: common-channel
+func (p *PlatformContract) Burn(
+	ctx contractapi.TransactionContextInterface,
+	withdrawalReference string,
+	account string,
+	currency string,
+	amount float64,
+) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+	if err := checkWalletAccount(account); err != nil {
+		return "", err
+	}
+	if amount <= 0 {
+		return "", fmt.Errorf("amount must be positive")
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	err = postJournalEntry(ctx, "TOKENS_BURNED", withdrawalReference, "", currency, []JournalLine{
+		{Account: fiatClearingAccount(), Debit: amount},
+		{Account: account, Credit: amount},
+	}, "Fiat withdrawal", now)
+	if err != nil {
+		return "", err
+	}
+
+	return tokenMovementResponse(ctx, "TokensBurned", "TOKENS_BURNED", withdrawalReference, account, "", currency, amount, now)
+}
+
+// Transfer moves tokens between two wallets on the holder's instruction
+// Channel: common-channel
+func (p *PlatformContract) Transfer(
+	ctx contractapi.TransactionContextInterface,
+	transferID string,
+	fromAccount string,
+	toAccount string,
+	currency string,
+	amount float64,
+) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+	if err := checkWalletAccount(fromAccount); err != nil {
+		return "", err
+	}
+	if err := checkWalletAccount(toAccount); err != nil {
+		return "", err
+	}
+	if fromAccount == toAccount {
+		return "", fmt.Errorf("cannot transfer to the same account")
+	}
+	if amount <= 0 {
+		return "", fmt.Errorf("amount must be positive")
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	err = postJournalEntry(ctx, "TOKENS_TRANSFERRED", transferID, "", currency, []JournalLine{
+		{Account: toAccount, Debit: amount},
+		{Account: fromAccount, Credit: amount},
+	}, "Wallet transfer", now)
+	if err != nil {
+		return "", err
+	}
+
+	return tokenMovementResponse(ctx, "TokensTransferred", "TOKENS_TRANSFERRED", transferID, fromAccount, toAccount, currency, amount, now)
+}
+
+// ============================================================================
+// RELEASE APPROVALS
+// Fund releases above the policy threshold need N-of-M approver signatures
+// ============================================================================
+
+// SetReleaseApprovalPolicy sets the threshold, approvers and signatures required for large releases
+// approversJSON: [{"approverId":"x509::...","mspId":"PlatformOrgMSP","role":"ESCROW_OFFICER"}, ...]
+// requiredSignaturesJSON: {"PlatformOrgMSP": 2, "ValidatorOrgMSP": 1}
+// Channel: common-channel
+func (p *PlatformContract) SetReleaseApprovalPolicy(
+	ctx contractapi.TransactionContextInterface,
+	thresholdAmount float64,
+	approversJSON string,
+	requiredSignaturesJSON string,
+	approvalWindowHours int,
+) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+	if thresholdAmount < 0 {
+		return "", fmt.Errorf("threshold amount cannot be negative")
+	}
+	if approvalWindowHours <= 0 {
+		return "", fmt.Errorf("approval window must be positive")
+	}
+
+	var approvers []ReleaseApprover
+	if err := json.Unmarshal([]byte(approversJSON), &approvers); err != nil {
+		return "", fmt.Errorf("failed to parse approvers: %v", err)
+	}
+	requiredSignatures := map[string]int{}
+	if err := json.Unmarshal([]byte(requiredSignaturesJSON), &requiredSignatures); err != nil {
+		return "", fmt.Errorf("failed to parse required signatures: %v", err)
+	}
+
+	// Every organization must have at least as many distinct approvers as signatures required
+	approversByMSP := map[string]int{}
+	seen := map[string]bool{}
+	for _, approver := range approvers {
+		if approver.ApproverID == "" || approver.MSPID == "" {
+			return "", fmt.Errorf("approvers need approverId and mspId")
+		}
+		key := approver.MSPID + "|" + approver.ApproverID
+		if seen[key] {
+			return "", fmt.Errorf("approver %s is listed more than once", approver.ApproverID)
+		}
+		seen[key] = true
+		approversByMSP[approver.MSPID]++
+	}
+	totalRequired := 0
+	for mspID, count := range requiredSignatures {
+		if count < 0 {
+			return "", fmt.Errorf("required signatures for %s cannot be negative", mspID)
+		}
+		if count > approversByMSP[mspID] {
+			return "", fmt.Errorf("%s requires %d signatures but has %d approvers", mspID, count, approversByMSP[mspID])
+		}
+		totalRequired += count
+	}
+	if thresholdAmount > 0 && totalRequired == 0 {
+		return "", fmt.Errorf("at least one signature must be required")
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	policy := ReleaseApprovalPolicy{
+		PolicyID:            "RELEASE_APPROVAL_POLICY",
+		ThresholdAmount:     thresholdAmount,
+		RequiredSignatures:  requiredSignatures,
+		Approvers:           approvers,
+		ApprovalWindowHours: approvalWindowHours,
+		UpdatedAt:           now,
+	}
+
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return "", err
+	}
+
+	err = ctx.GetStub().PutState(policy.PolicyID, policyJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"thresholdAmount":     thresholdAmount,
+		"requiredSignatures":  requiredSignatures,
+		"approverCount":       len(approvers),
+		"approvalWindowHours": approvalWindowHours,
+		"channel":             "common-channel",
+		"action":              "RELEASE_APPROVAL_POLICY_UPDATED",
+		"timestamp":           now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("ReleaseApprovalPolicyUpdated", eventJSON)
+
+	response := map[string]interface{}{
+		"message": "Release approval policy updated",
+		"policy":  policy,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ApproveFundRelease signs a pending fund release as the calling approver
+// Funds move on the signature that satisfies every organization's requirement
+// Channel: common-channel
+// Endorsers: PlatformOrg, ValidatorOrg
+func (p *PlatformContract) ApproveFundRelease(
+	ctx contractapi.TransactionContextInterface,
+	releaseID string,
+) (string, error) {
+	releaseJSON, err := ctx.GetStub().GetState(releaseID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read release: %v", err)
+	}
+	if releaseJSON == nil {
+		return "", fmt.Errorf("release %s does not exist", releaseID)
+	}
+
+	var release FundRelease
+	err = json.Unmarshal(releaseJSON, &release)
+	if err != nil {
+		return "", err
+	}
+
+	if release.Status != "PENDING_APPROVAL" {
+		return "", fmt.Errorf("release %s is not pending approval (status: %s)", releaseID, release.Status)
+	}
+	if err := checkCampaignNotBlacklisted(ctx, release.CampaignID); err != nil {
+		return "", err
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	if expiresAt, err := time.Parse(time.RFC3339, release.ExpiresAt); err == nil && !txTime.Before(expiresAt) {
+		return "", fmt.Errorf("release %s approval window expired at %s", releaseID, release.ExpiresAt)
+	}
+
+	// Caller must be an authorized approver of their organization
+	mspID, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		return "", fmt.Errorf("failed to read client MSP ID: %v", err)
+	}
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		return "", fmt.Errorf("failed to read client identity: %v", err)
+	}
+
+	policy, err := getReleaseApprovalPolicy(ctx)
+	if err != nil {
+		return "", err
+	}
+	var approver *ReleaseApprover
+	for i := range policy.Approvers {
+		if policy.Approvers[i].ApproverID == clientID && policy.Approvers[i].MSPID == mspID {
+			approver = &policy.Approvers[i]
+			break
+		}
+	}
+	if approver == nil {
+		return "", fmt.Errorf("caller is not an authorized release approver for %s", mspID)
+	}
+	for _, approval := range release.Approvals {
+		if approval.ApproverID == clientID && approval.MSPID == mspID {
+			return "", fmt.Errorf("approver has already signed release %s", releaseID)
+		}
+	}
+
+	now := txTime.Format(time.RFC3339)
+
+	release.Approvals = append(release.Approvals, ReleaseApproval{
+		ApproverID: approver.ApproverID,
+		MSPID:      approver.MSPID,
+		Role:       approver.Role,
+		ApprovedAt: now,
+	})
+
+	remaining := remainingSignatures(release)
+	if len(remaining) > 0 {
+		updatedJSON, err := json.Marshal(release)
+		if err != nil {
+			return "", err
+		}
+		err = ctx.GetStub().PutState(releaseID, updatedJSON)
+		if err != nil {
+			return "", err
+		}
+
+		eventPayload := map[string]interface{}{
+			"releaseId":           releaseID,
+			"campaignId":          release.CampaignID,
+			"approverMspId":       mspID,
+			"approverRole":        approver.Role,
+			"approvalCount":       len(release.Approvals),
+			"remainingSignatures": remaining,
+			"channel":             "common-channel",
+			"action":              "FUND_RELEASE_APPROVED",
+			"timestamp":           now,
+		}
+		eventJSON, _ := json.Marshal(eventPayload)
+		ctx.GetStub().SetEvent("FundReleaseApproved", eventJSON)
+
+		response := map[string]interface{}{
+			"message":             "Approval recorded. Release still pending.",
+			"releaseId":           releaseID,
+			"approvalCount":       len(release.Approvals),
+			"remainingSignatures": remaining,
+			"status":              release.Status,
+		}
+		responseJSON, _ := json.Marshal(response)
+		return string(responseJSON), nil
+	}
+
+	// Final signature: re-check the escrow, which may have changed while pending
+	escrowJSON, err := ctx.GetStub().GetState(release.EscrowID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read escrow: %v", err)
+	}
+	if escrowJSON == nil {
+		return "", fmt.Errorf("escrow %s does not exist", release.EscrowID)
+	}
+
+	var escrow FundEscrow
+	err = json.Unmarshal(escrowJSON, &escrow)
+	if err != nil {
+		return "", err
+	}
+
+	if err := checkEscrowNotFrozen(escrow); err != nil {
+		return "", err
+	}
+	if release.Amount > escrow.HeldAmount {
+		return "", fmt.Errorf("insufficient funds in escrow. Available: %f, Requested: %f", escrow.HeldAmount, release.Amount)
+	}
+	if err := checkFundingModelAllowsRelease(ctx, escrow.CampaignID); err != nil {
+		return "", err
+	}
+	// The release consumes its reservation
+	unreserveEscrow(&escrow, release)
+	// Reward pledge releases are triggered by delivery and carry no milestone evidence
+	if release.MilestoneID != "" {
+		evidence, err := collectReleaseEvidence(ctx, release, escrow, now)
+		if err != nil {
+			return "", err
+		}
+		release.Evidence = *evidence
+	}
+
+	err = executeFundRelease(ctx, &release, &escrow, now)
+	if err != nil {
+		return "", err
+	}
+	// A reward pledge is fulfilled once its release executes
+	if release.PledgeID != "" {
+		if err := fulfillReleasedPledge(ctx, release.PledgeID, now); err != nil {
+			return "", err
+		}
+	}
+
+	eventPayload := fundsReleasedEventPayload(release, now)
+	eventPayload["approvals"] = release.Approvals
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("FundsReleased", eventJSON)
+
+	response := map[string]interface{}{
+		"message":       "Release fully approved. Funds released to startup from escrow",
+		"releaseId":     releaseID,
+		"milestoneId":   release.MilestoneID,
+		"amount":        release.Amount,
+		"feeAmount":     release.FeeAmount,
+		"netAmount":     release.NetAmount,
+		"approvalCount": len(release.Approvals),
+		"escrowBalance": escrow.HeldAmount,
+		"status":        release.Status,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ExpirePendingReleases marks pending releases whose approval window has passed as EXPIRED
+// and returns the escrow they reserved to the available balance
+// Intended to be called periodically by a platform scheduler
+// Channel: common-channel
+// Endorsers: PlatformOrg
+func (p *PlatformContract) ExpirePendingReleases(ctx contractapi.TransactionContextInterface) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	pending, err := getPendingReleases(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// Several releases may reserve the same escrow; GetState does not see this transaction's writes
+	escrows := map[string]*FundEscrow{}
+	expired := []map[string]interface{}{}
+	for _, release := range pending {
+		expiresAt, err := time.Parse(time.RFC3339, release.ExpiresAt)
+		if err != nil || txTime.Before(expiresAt) {
+			continue
+		}
+
+		release.Status = "EXPIRED"
+		releaseJSON, err := json.Marshal(release)
+		if err != nil {
+			return "", err
+		}
+		err = ctx.GetStub().PutState(release.ReleaseID, releaseJSON)
+		if err != nil {
+			return "", err
+		}
+
+		escrow, ok := escrows[release.EscrowID]
+		if !ok {
+			escrowJSON, err := ctx.GetStub().GetState(release.EscrowID)
+			if err != nil {
+				return "", fmt.Errorf("failed to read escrow: %v", err)
+			}
+			if escrowJSON == nil {
+				return "", fmt.Errorf("escrow %s does not exist", release.EscrowID)
+			}
+			escrow = &FundEscrow{}
+			if err := json.Unmarshal(escrowJSON, escrow); err != nil {
+				return "", err
+			}
+			escrows[release.EscrowID] = escrow
+		}
+		unreserveEscrow(escrow, release)
+		escrow.UpdatedAt = now
+		escrowJSON, err := json.Marshal(escrow)
+		if err != nil {
+			return "", err
+		}
+		err = ctx.GetStub().PutState(escrow.EscrowID, escrowJSON)
+		if err != nil {
+			return "", err
+		}
+
+		expired = append(expired, map[string]interface{}{
+			"releaseId":     release.ReleaseID,
+			"campaignId":    release.CampaignID,
+			"amount":        release.Amount,
+			"approvalCount": len(release.Approvals),
+			"expiresAt":     release.ExpiresAt,
+		})
+	}
+
+	// Emit one event for the whole sweep (Fabric keeps only the last event per transaction)
+	if len(expired) > 0 {
+		eventPayload := map[string]interface{}{
+			"releases":  expired,
+			"channel":   "common-channel",
+			"action":    "PENDING_RELEASES_EXPIRED",
+			"timestamp": now,
+		}
+		eventJSON, _ := json.Marshal(eventPayload)
+		ctx.GetStub().SetEvent("PendingReleasesExpired", eventJSON)
+	}
+
+	response := map[string]interface{}{
+		"message":      fmt.Sprintf("Expired %d pending releases", len(expired)),
+		"expiredCount": len(expired),
+		"releases":     expired,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// PAYMENT RECONCILIATION
+// Off-chain bank/processor payments matched to commitments and releases
+// ============================================================================
+
+// RecordInboundPayment records an investor payment received by the processor
+// and matches it to the investor's FundingCommitment
+// Channel: common-channel
+func (p *PlatformContract) RecordInboundPayment(
+	ctx contractapi.TransactionContextInterface,
+	processorReference string,
+	commitmentID string,
+	amount float64,
+	currency string,
+	valueDate string,
+	processorStatus string,
+) (string, error) {
+	return recordPaymentTransaction(ctx, "INBOUND", processorReference, commitmentID, amount, currency, valueDate, processorStatus)
+}
+
+// RecordOutboundDisbursement records a payout sent to a startup by the processor
+// and matches it to the FundRelease it pays
+// Channel: common-channel
+func (p *PlatformContract) RecordOutboundDisbursement(
+	ctx contractapi.TransactionContextInterface,
+	processorReference string,
+	releaseID string,
+	amount float64,
+	currency string,
+	valueDate string,
+	processorStatus string,
+) (string, error) {
+	return recordPaymentTransaction(ctx, "OUTBOUND", processorReference, releaseID, amount, currency, valueDate, processorStatus)
+}
+
+// IngestSettlementFile records every payment in a processor settlement file (CSV) in one transaction
+// Header: direction,processorReference,matchReference,amount,currency,valueDate,status
+// Payments already on the ledger are updated and matched again (e.g. PENDING -> SETTLED)
+// Channel: common-channel
+func (p *PlatformContract) IngestSettlementFile(
+	ctx contractapi.TransactionContextInterface,
+	batchID string,
+	csvContent string,
+) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+
+	batchKey := "SETTLEMENT_BATCH_" + batchID
+	existingJSON, err := ctx.GetStub().GetState(batchKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read settlement batch: %v", err)
+	}
+	if existingJSON != nil {
+		return "", fmt.Errorf("settlement batch %s already ingested", batchID)
+	}
+
+	rows, err := parseSettlementFile(csvContent)
+	if err != nil {
+		return "", err
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	batch := SettlementBatch{
+		BatchID:    batchID,
+		RowCount:   len(rows),
+		PaymentIDs: []string{},
+		IngestedAt: now,
+	}
+	claimed := map[string]string{}
+	seen := map[string]bool{}
+	unreconciled := []map[string]interface{}{}
+
+	for index, row := range rows {
+		if seen[row.ProcessorReference] {
+			return "", fmt.Errorf("row %d: processor reference %s appears more than once", index+2, row.ProcessorReference)
+		}
+		seen[row.ProcessorReference] = true
+
+		payment, err := recordPayment(ctx, row.Direction, row.ProcessorReference, row.MatchReference, row.Amount, row.Currency, row.ValueDate, row.ProcessorStatus, batchID, claimed, now)
+		if err != nil {
+			return "", fmt.Errorf("row %d: %v", index+2, err)
+		}
+
+		batch.PaymentIDs = append(batch.PaymentIDs, payment.PaymentID)
+		if payment.ReconciliationStatus == "MATCHED" {
+			batch.MatchedCount++
+		} else {
+			batch.UnreconciledCount++
+			unreconciled = append(unreconciled, map[string]interface{}{
+				"paymentId":            payment.PaymentID,
+				"reconciliationStatus": payment.ReconciliationStatus,
+				"mismatches":           payment.Mismatches,
+			})
+		}
+	}
+
+	batchJSON, err := json.Marshal(batch)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(batchKey, batchJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// One event for the whole file (only the last SetEvent in a transaction is kept)
+	eventPayload := map[string]interface{}{
+		"batchId":           batchID,
+		"rowCount":          batch.RowCount,
+		"matchedCount":      batch.MatchedCount,
+		"unreconciledCount": batch.UnreconciledCount,
+		"unreconciled":      unreconciled,
+		"channel":           "common-channel",
+		"action":            "SETTLEMENT_FILE_INGESTED",
+		"timestamp":         now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("SettlementFileIngested", eventJSON)
+
+	response := map[string]interface{}{
+		"message":           "Settlement file ingested",
+		"batchId":           batchID,
+		"rowCount":          batch.RowCount,
+		"matchedCount":      batch.MatchedCount,
+		"unreconciledCount": batch.UnreconciledCount,
+		"unreconciled":      unreconciled,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// INVESTOR-PLATFORM-CHANNEL FUNCTIONS
+// Endorsed by: InvestorOrg, PlatformOrg
+// ============================================================================
+
+// RecordInvestorConfirmation records investment confirmation from InvestorOrg
+// Channel: investor-platform-channel
+// Endorsers: InvestorOrg, PlatformOrg
+func (p *PlatformContract) RecordInvestorConfirmation(
+	ctx contractapi.TransactionContextInterface,
+	recordID string,
+	confirmationID string,
+	campaignID string,
+	investorID string,
+	amount float64,
+	currency string,
+) (string, error) {
+	// Create confirmation record
+	record := InvestorConfirmationRecord{
+		RecordID:       recordID,
+		ConfirmationID: confirmationID,
+		CampaignID:     campaignID,
+		InvestorID:     investorID,
+		Amount:         amount,
+		Currency:       currency,
+		RecordedAt:     time.Now().Format(time.RFC3339),
+	}
+
+	recordJSON, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+
+	// Store on investor-platform-channel
+	err = ctx.GetStub().PutState(recordID, recordJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Update campaign funding totals
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err == nil && campaignJSON != nil {
+		var campaign PublishedCampaign
+		json.Unmarshal(campaignJSON, &campaign)
+		campaign.TotalConfirmed += amount
+		campaign.FundsRaisedAmount += amount
+		campaign.InvestorCount++
+		if campaign.GoalAmount > 0 {
+			campaign.FundsRaisedPercent = (campaign.FundsRaisedAmount / campaign.GoalAmount) * 100
+		}
+		campaign.UpdatedAt = time.Now().Format(time.RFC3339)
+		updatedCampaignJSON, _ := json.Marshal(campaign)
+		ctx.GetStub().PutState(campaignID, updatedCampaignJSON)
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"recordId":       recordID,
+		"confirmationId": confirmationID,
+		"campaignId":     campaignID,
+		"investorId":     investorID,
+		"channel":        "investor-platform-channel",
+		"action":         "INVESTOR_CONFIRMATION_RECORDED",
+		"timestamp":      record.RecordedAt,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("InvestorConfirmationRecorded", eventJSON)
+
+	response := map[string]interface{}{
+		"message":    "Investor confirmation recorded",
+		"recordId":   recordID,
+		"campaignId": campaignID,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// VALIDATOR-PLATFORM-CHANNEL FUNCTIONS
+// Endorsed by: ValidatorOrg, PlatformOrg
+// ============================================================================
+
+// RecordValidatorDecision records final validation decision from ValidatorOrg
+// Channel: validator-platform-channel
+// Endorsers: ValidatorOrg, PlatformOrg
+func (p *PlatformContract) RecordValidatorDecision(
+	ctx contractapi.TransactionContextInterface,
+	recordID string,
+	campaignID string,
+	validationID string,
+	approved bool,
+	overallScore float64,
+	reportHash string,
+) (string, error) {
+	// Create decision record
+	record := ValidatorDecisionRecord{
+		RecordID:     recordID,
+		CampaignID:   campaignID,
+		ValidationID: validationID,
+		Approved:     approved,
+		OverallScore: overallScore,
+		ReportHash:   reportHash,
+		RecordedAt:   time.Now().Format(time.RFC3339),
+	}
+
+	recordJSON, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+
+	// Store on validator-platform-channel
+	err = ctx.GetStub().PutState(recordID, recordJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Store by campaign for lookup
+	decisionKey := fmt.Sprintf("DECISION_%s", campaignID)
+	err = ctx.GetStub().PutState(decisionKey, recordJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"recordId":     recordID,
+		"campaignId":   campaignID,
+		"validationId": validationID,
+		"approved":     approved,
+		"overallScore": overallScore,
+		"channel":      "validator-platform-channel",
+		"action":       "VALIDATOR_DECISION_RECORDED",
+		"timestamp":    record.RecordedAt,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("ValidatorDecisionRecorded", eventJSON)
+
+	response := map[string]interface{}{
+		"message":      "Validator decision recorded",
+		"recordId":     recordID,
+		"campaignId":   campaignID,
+		"approved":     approved,
+		"overallScore": overallScore,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// COMMON-CHANNEL FUNCTIONS
+// Read by: All Orgs, Write by: PlatformOrg (aggregated metrics only - privacy preserving)
+// ============================================================================
+
+// PublishGlobalMetrics publishes aggregated system-wide metrics to common-channel
+// Channel: common-channel
+// Purpose: Privacy-preserving global metrics (never raw data)
+func (p *PlatformContract) PublishGlobalMetrics(
+	ctx contractapi.TransactionContextInterface,
+	metricsID string,
+	totalCampaigns int,
+	activeCampaigns int,
+	successfulCampaigns int,
+	totalInvestorCount int,
+) (string, error) {
+	// Generate metrics hash (no sensitive data)
+	metricsData := map[string]interface{}{
+		"metricsId":           metricsID,
+		"totalCampaigns":      totalCampaigns,
+		"activeCampaigns":     activeCampaigns,
+		"successfulCampaigns": successfulCampaigns,
+		"totalInvestorCount":  totalInvestorCount,
+		"timestamp":           time.Now().Format(time.RFC3339),
+	}
+	metricsDataJSON, _ := json.Marshal(metricsData)
+	metricsHash := generateHash(string(metricsDataJSON))
+
+	// Create global metrics
+	metrics := GlobalMetrics{
+		MetricsID:           metricsID,
+		TotalCampaigns:      totalCampaigns,
+		ActiveCampaigns:     activeCampaigns,
+		SuccessfulCampaigns: successfulCampaigns,
+		TotalInvestorCount:  totalInvestorCount,
+		MetricsHash:         metricsHash,
+		PublishedAt:         time.Now().Format(time.RFC3339),
+	}
+
+	metricsJSON, err := json.Marshal(metrics)
+	if err != nil {
+		return "", err
+	}
+
+	// Store on common-channel
+	err = ctx.GetStub().PutState(metricsID, metricsJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Also store as latest metrics
+	err = ctx.GetStub().PutState("COMMON_GLOBAL_METRICS_LATEST", metricsJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"metricsId":           metricsID,
+		"totalCampaigns":      totalCampaigns,
+		"activeCampaigns":     activeCampaigns,
+		"successfulCampaigns": successfulCampaigns,
+		"totalInvestorCount":  totalInvestorCount,
+		"metricsHash":         metricsHash,
+		"channel":             "common-channel",
+		"action":              "GLOBAL_METRICS_PUBLISHED",
+		"timestamp":           metrics.PublishedAt,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("GlobalMetricsPublished", eventJSON)
+
+	response := map[string]interface{}{
+		"message":             "Global metrics published to common channel",
+		"metricsId":           metricsID,
+		"totalCampaigns":      totalCampaigns,
+		"activeCampaigns":     activeCampaigns,
+		"successfulCampaigns": successfulCampaigns,
+		"metricsHash":         metricsHash,
+		"channel":             "common-channel",
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// QUERY FUNCTIONS
+// ============================================================================
+
+// GetPublishedCampaign retrieves published campaign by ID
+func (p *PlatformContract) GetPublishedCampaign(ctx contractapi.TransactionContextInterface, campaignID string) (*PublishedCampaign, error) {
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return nil, fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign PublishedCampaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaign, nil
+}
+
+// GetActiveCampaigns returns all active published campaigns
+func (p *PlatformContract) GetActiveCampaigns(ctx contractapi.TransactionContextInterface) (string, error) {
+	queryString := `{"selector":{"status":"PUBLISHED"}}`
+
+	resultsIterator, err := ctx.GetStub().GetQueryResult(queryString)
+	if err != nil {
+		return "", err
+	}
+	defer resultsIterator.Close()
+
+	var campaigns []map[string]interface{}
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return "", err
+		}
+
+		var campaign PublishedCampaign
+		err = json.Unmarshal(queryResponse.Value, &campaign)
+		if err != nil {
+			continue
+		}
+
+		campaignMap := map[string]interface{}{
+			"Key":    queryResponse.Key,
+			"Record": campaign,
+		}
+		campaigns = append(campaigns, campaignMap)
+	}
+
+	campaignsJSON, err := json.Marshal(campaigns)
+	if err != nil {
+		return "", err
+	}
+
+	return string(campaignsJSON), nil
+}
+
+// GetValidatorDecision retrieves validator decision for a campaign
+func (p *PlatformContract) GetValidatorDecision(ctx contractapi.TransactionContextInterface, campaignID string) (*ValidatorDecisionRecord, error) {
+	decisionKey := fmt.Sprintf("DECISION_%s", campaignID)
+	decisionJSON, err := ctx.GetStub().GetState(decisionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read decision: %v", err)
+	}
+	if decisionJSON == nil {
+		return nil, fmt.Errorf("validator decision for campaign %s does not exist", campaignID)
+	}
+
+	var decision ValidatorDecisionRecord
+	err = json.Unmarshal(decisionJSON, &decision)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decision, nil
+}
+
+// GetLatestGlobalMetrics retrieves the latest global metrics
+func (p *PlatformContract) GetLatestGlobalMetrics(ctx contractapi.TransactionContextInterface) (*GlobalMetrics, error) {
+	metricsJSON, err := ctx.GetStub().GetState("COMMON_GLOBAL_METRICS_LATEST")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metrics: %v", err)
+	}
+	if metricsJSON == nil {
+		return nil, fmt.Errorf("no global metrics published yet")
+	}
+
+	var metrics GlobalMetrics
+	err = json.Unmarshal(metricsJSON, &metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &metrics, nil
+}
+
+// GetEscrow retrieves escrow by ID
+func (p *PlatformContract) GetEscrow(ctx contractapi.TransactionContextInterface, escrowID string) (*FundEscrow, error) {
+	escrowJSON, err := ctx.GetStub().GetState(escrowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read escrow: %v", err)
+	}
+	if escrowJSON == nil {
+		return nil, fmt.Errorf("escrow %s does not exist", escrowID)
+	}
+
+	var escrow FundEscrow
+	err = json.Unmarshal(escrowJSON, &escrow)
+	if err != nil {
+		return nil, err
+	}
+
+	return &escrow, nil
+}
+
+// GetFundRelease retrieves fund release by ID
+func (p *PlatformContract) GetFundRelease(ctx contractapi.TransactionContextInterface, releaseID string) (*FundRelease, error) {
+	releaseJSON, err := ctx.GetStub().GetState(releaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read release: %v", err)
+	}
+	if releaseJSON == nil {
+		return nil, fmt.Errorf("release %s does not exist", releaseID)
+	}
+
+	var release FundRelease
+	err = json.Unmarshal(releaseJSON, &release)
+	if err != nil {
+		return nil, err
+	}
+
+	// Releases made before approvals existed have no signature fields
+	if release.RequiredSignatures == nil {
+		release.RequiredSignatures = map[string]int{}
+	}
+	if release.Approvals == nil {
+		release.Approvals = []ReleaseApproval{}
+	}
+
+	return &release, nil
+}
+
+// GetReleasesByEscrow returns all fund releases made from an escrow
+func (p *PlatformContract) GetReleasesByEscrow(ctx contractapi.TransactionContextInterface, escrowID string) (string, error) {
+	releases, err := getReleasesForEscrows(ctx, []string{escrowID})
+	if err != nil {
+		return "", err
+	}
+
+	releasesJSON, err := json.Marshal(releases[escrowID])
+	if err != nil {
+		return "", err
+	}
+
+	return string(releasesJSON), nil
+}
+
+// GetRefund retrieves escrow refund by ID
+func (p *PlatformContract) GetRefund(ctx contractapi.TransactionContextInterface, refundID string) (*EscrowRefund, error) {
+	refundJSON, err := ctx.GetStub().GetState(refundID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read refund: %v", err)
+	}
+	if refundJSON == nil {
+		return nil, fmt.Errorf("refund %s does not exist", refundID)
+	}
+
+	var refund EscrowRefund
+	err = json.Unmarshal(refundJSON, &refund)
+	if err != nil {
+		return nil, err
+	}
+
+	return &refund, nil
+}
+
+// GetRefundsByCampaign returns all escrow refunds for a campaign
+func (p *PlatformContract) GetRefundsByCampaign(ctx contractapi.TransactionContextInterface, campaignID string) (string, error) {
+	return getRefunds(ctx, map[string]interface{}{"campaignId": campaignID})
+}
+
+// GetRefundsByInvestor returns all escrow refunds paid to an investor
+// Used by InvestorOrg to reconcile refunded commitments
+func (p *PlatformContract) GetRefundsByInvestor(ctx contractapi.TransactionContextInterface, investorID string) (string, error) {
+	return getRefunds(ctx, map[string]interface{}{"investorId": investorID})
+}
+
+// GetEscrowHold retrieves escrow hold by ID
+func (p *PlatformContract) GetEscrowHold(ctx contractapi.TransactionContextInterface, holdID string) (*EscrowHold, error) {
+	holdJSON, err := ctx.GetStub().GetState(holdID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hold: %v", err)
+	}
+	if holdJSON == nil {
+		return nil, fmt.Errorf("hold %s does not exist", holdID)
+	}
+
+	var hold EscrowHold
+	err = json.Unmarshal(holdJSON, &hold)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hold, nil
+}
+
+// GetHoldResolution retrieves the resolution record that lifted escrow holds
+func (p *PlatformContract) GetHoldResolution(ctx contractapi.TransactionContextInterface, resolutionID string) (*HoldResolution, error) {
+	resolutionJSON, err := ctx.GetStub().GetState(resolutionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resolution: %v", err)
+	}
+	if resolutionJSON == nil {
+		return nil, fmt.Errorf("resolution %s does not exist", resolutionID)
+	}
+
+	var resolution HoldResolution
+	err = json.Unmarshal(resolutionJSON, &resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resolution, nil
+}
+
+// GetDisputeExecution retrieves how PlatformOrg executed an arbitration decision
+func (p *PlatformContract) GetDisputeExecution(ctx contractapi.TransactionContextInterface, disputeID string) (*DisputeExecution, error) {
+	executionJSON, err := ctx.GetStub().GetState(fmt.Sprintf("DISPUTE_EXECUTION_%s", disputeID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dispute execution: %v", err)
+	}
+	if executionJSON == nil {
+		return nil, fmt.Errorf("decision of dispute %s has not been executed", disputeID)
+	}
+
+	var execution DisputeExecution
+	err = json.Unmarshal(executionJSON, &execution)
+	if err != nil {
+		return nil, err
+	}
+
+	return &execution, nil
+}
+
+// GetVestingStatus returns the vested, claimed and claimable amounts of a vesting release at transaction time
+func (p *PlatformContract) GetVestingStatus(ctx contractapi.TransactionContextInterface, releaseID string) (string, error) {
+	releaseJSON, err := ctx.GetStub().GetState(releaseID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read release: %v", err)
+	}
+	if releaseJSON == nil {
+		return "", fmt.Errorf("release %s does not exist", releaseID)
+	}
+
+	var release FundRelease
+	err = json.Unmarshal(releaseJSON, &release)
+	if err != nil {
+		return "", err
+	}
+	if release.VestingSchedule.Installments == 0 {
+		return "", fmt.Errorf("release %s has no vesting schedule", releaseID)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	vested, nextUnlockAt, err := vestedAmount(release, txTime)
+	if err != nil {
+		return "", err
+	}
+
+	claimable := math.Max(vested-release.ClaimedAmount, 0)
+	if release.CancelledAmount > 0 {
+		claimable = 0
+	}
+
+	status := map[string]interface{}{
+		"releaseId":       releaseID,
+		"startupId":       release.StartupID,
+		"netAmount":       release.NetAmount,
+		"currency":        release.Currency,
+		"vestingSchedule": release.VestingSchedule,
+		"vestingStartsAt": release.VestingStartsAt,
+		"vestedAmount":    vested,
+		"claimedAmount":   release.ClaimedAmount,
+		"cancelledAmount": release.CancelledAmount,
+		"claimableAmount": claimable,
+		"nextUnlockAt":    formatOptionalTime(nextUnlockAt),
+		"asOf":            txTime.Format(time.RFC3339),
+	}
+	statusJSON, _ := json.Marshal(status)
+	return string(statusJSON), nil
+}
+
+// GetDistribution retrieves a distribution by ID
+func (p *PlatformContract) GetDistribution(ctx contractapi.TransactionContextInterface, distributionID string) (*Distribution, error) {
+	return getDistribution(ctx, distributionID)
+}
+
+// GetDistributionPayouts returns the payout lines of a distribution
+func (p *PlatformContract) GetDistributionPayouts(ctx contractapi.TransactionContextInterface, distributionID string) (string, error) {
+	return getDistributionPayouts(ctx, map[string]interface{}{"distributionId": distributionID})
+}
+
+// GetInvestorPayouts returns an investor's payout history across distributions, oldest first
+func (p *PlatformContract) GetInvestorPayouts(ctx contractapi.TransactionContextInterface, investorID string) (string, error) {
+	return getDistributionPayouts(ctx, map[string]interface{}{"investorId": investorID})
+}
+
+// EvaluateNegotiationTerms normalizes a term sheet, hashes it and diffs it against the previous offer
+// InvestorOrg and StartupOrg call it for every negotiation round, so terms are normalized in one place
+// Channel: common-channel
+func (p *PlatformContract) EvaluateNegotiationTerms(ctx contractapi.TransactionContextInterface, previousTermsJSON string, termsJSON string) (string, error) {
+	terms, err := parseNegotiationTerms(termsJSON)
+	if err != nil {
+		return "", err
+	}
+	terms, termsHash, err := normalizeTerms(terms)
+	if err != nil {
+		return "", err
+	}
+
+	diff := []TermChange{}
+	if previousTermsJSON != "" {
+		previous, err := parseNegotiationTerms(previousTermsJSON)
+		if err != nil {
+			return "", err
+		}
+		// Earlier offers are compared in normalized form, so formatting alone is not a change
+		if normalized, _, err := normalizeTerms(previous); err == nil {
+			previous = normalized
+		}
+		diff = diffTerms(previous, terms)
+	}
+
+	response := map[string]interface{}{
+		"terms":      terms,
+		"termsHash":  termsHash,
+		"instrument": terms.Instrument,
+		"diff":       diff,
+		"changes":    len(diff),
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// GetAgreementAcceptance retrieves the terms each party accepted for an agreement
+func (p *PlatformContract) GetAgreementAcceptance(ctx contractapi.TransactionContextInterface, agreementID string) (string, error) {
+	acceptance, err := getAgreementAcceptance(ctx, agreementID)
+	if err != nil {
+		return "", err
+	}
+	acceptanceJSON, err := json.Marshal(acceptance)
+	if err != nil {
+		return "", err
+	}
+	return string(acceptanceJSON), nil
+}
+
+// GetAgreement retrieves an agreement witnessed by the platform
+func (p *PlatformContract) GetAgreement(ctx contractapi.TransactionContextInterface, agreementID string) (*Agreement, error) {
+	agreementJSON, err := ctx.GetStub().GetState(agreementID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agreement: %v", err)
+	}
+	if agreementJSON == nil {
+		return nil, fmt.Errorf("agreement %s does not exist", agreementID)
+	}
+
+	var agreement Agreement
+	err = json.Unmarshal(agreementJSON, &agreement)
+	if err != nil {
+		return nil, err
+	}
+	if agreement.Milestones == nil {
+		agreement.Milestones = []Milestone{}
+	}
+
+	return &agreement, nil
+}
+
+// GetRevenueReport retrieves a revenue report with its obligations
+func (p *PlatformContract) GetRevenueReport(ctx contractapi.TransactionContextInterface, reportID string) (*RevenueReport, error) {
+	reportJSON, err := ctx.GetStub().GetState(reportID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read revenue report: %v", err)
+	}
+	if reportJSON == nil {
+		return nil, fmt.Errorf("revenue report %s does not exist", reportID)
+	}
+
+	var report RevenueReport
+	err = json.Unmarshal(reportJSON, &report)
+	if err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// GetRevenueShareOffer retrieves a revenue-share campaign's terms
+func (p *PlatformContract) GetRevenueShareOffer(ctx contractapi.TransactionContextInterface, campaignID string) (*RevenueShareOffer, error) {
+	offer, err := getRevenueShareOffer(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if offer == nil {
+		return nil, fmt.Errorf("revenue-share terms for campaign %s do not exist", campaignID)
+	}
+
+	return offer, nil
+}
+
+// GetRevenueShareAgreements returns a campaign's active revenue-share agreements (empty for all campaigns)
+func (p *PlatformContract) GetRevenueShareAgreements(ctx contractapi.TransactionContextInterface, campaignID string) (string, error) {
+	filter := map[string]interface{}{}
+	if campaignID != "" {
+		filter["campaignId"] = campaignID
+	}
+	agreements, err := getRevenueShareAgreements(ctx, filter)
+	if err != nil {
+		return "", err
+	}
+
+	agreementsJSON, err := json.Marshal(agreements)
+	if err != nil {
+		return "", err
+	}
+
+	return string(agreementsJSON), nil
+}
+
+// GetCapTable returns a startup's cap table with each holder's fully diluted ownership
+func (p *PlatformContract) GetCapTable(ctx contractapi.TransactionContextInterface, startupID string) (string, error) {
+	capTable, err := getCapTable(ctx, startupID)
+	if err != nil {
+		return "", err
+	}
+
+	response := map[string]interface{}{
+		"startupId":   startupID,
+		"totalShares": capTable.TotalShares,
+		"holders":     capTableOwnership(capTable),
+		"issuances":   capTable.Issuances,
+		"updatedAt":   capTable.UpdatedAt,
+	}
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return "", err
+	}
+
+	return string(responseJSON), nil
+}
+
+// GetCapTableSnapshot retrieves the fully diluted cap table recorded when a campaign closed
+func (p *PlatformContract) GetCapTableSnapshot(ctx contractapi.TransactionContextInterface, campaignID string) (*CapTableSnapshot, error) {
+	snapshotJSON, err := ctx.GetStub().GetState(capTableSnapshotKey(campaignID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cap table snapshot: %v", err)
+	}
+	if snapshotJSON == nil {
+		return nil, fmt.Errorf("cap table snapshot for campaign %s does not exist", campaignID)
+	}
+
+	var snapshot CapTableSnapshot
+	err = json.Unmarshal(snapshotJSON, &snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+// GetInstrumentConversion retrieves a conversion of SAFEs and convertible notes into shares
+func (p *PlatformContract) GetInstrumentConversion(ctx contractapi.TransactionContextInterface, conversionID string) (*InstrumentConversion, error) {
+	conversionJSON, err := ctx.GetStub().GetState(conversionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conversion: %v", err)
+	}
+	if conversionJSON == nil {
+		return nil, fmt.Errorf("conversion %s does not exist", conversionID)
+	}
+
+	var conversion InstrumentConversion
+	err = json.Unmarshal(conversionJSON, &conversion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &conversion, nil
+}
+
+// GetOutstandingInstruments returns a startup's witnessed SAFEs and convertible notes not yet converted
+func (p *PlatformContract) GetOutstandingInstruments(ctx contractapi.TransactionContextInterface, startupID string) (string, error) {
+	agreements, err := getOutstandingInstruments(ctx, startupID)
+	if err != nil {
+		return "", err
+	}
+
+	agreementsJSON, err := json.Marshal(agreements)
+	if err != nil {
+		return "", err
+	}
+
+	return string(agreementsJSON), nil
+}
+
+// GetLoan retrieves a lending campaign's loan with its lenders and repayment schedule
+func (p *PlatformContract) GetLoan(ctx contractapi.TransactionContextInterface, campaignID string) (*Loan, error) {
+	loan, err := getLoan(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, fmt.Errorf("loan for campaign %s does not exist", campaignID)
+	}
+
+	return loan, nil
+}
+
+// GetLoanRepayments returns the repayments made on a lending campaign's loan
+func (p *PlatformContract) GetLoanRepayments(ctx contractapi.TransactionContextInterface, campaignID string) (string, error) {
+	records, err := getQueryResults(ctx, map[string]interface{}{
+		"repaymentId": map[string]bool{"$exists": true},
+		"loanId":      loanKey(campaignID),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	repayments := []LoanRepayment{}
+	for _, record := range records {
+		var repayment LoanRepayment
+		if json.Unmarshal(record.Value, &repayment) == nil && record.Key == repayment.RepaymentID {
+			repayments = append(repayments, repayment)
+		}
+	}
+	sort.Slice(repayments, func(a, b int) bool { return repayments[a].PaidAt < repayments[b].PaidAt })
+
+	repaymentsJSON, err := json.Marshal(repayments)
+	if err != nil {
+		return "", err
+	}
+
+	return string(repaymentsJSON), nil
+}
+
+// GetInvestorLoanBalances returns an investor's outstanding balance on every loan they funded
+func (p *PlatformContract) GetInvestorLoanBalances(ctx contractapi.TransactionContextInterface, investorID string) (string, error) {
+	records, err := getQueryResults(ctx, map[string]interface{}{
+		"loanId":  map[string]bool{"$exists": true},
+		"lenders": map[string]interface{}{"$elemMatch": map[string]string{"investorId": investorID}},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	balances := []map[string]interface{}{}
+	totalOutstanding := map[string]float64{}
+	for _, record := range records {
+		var loan Loan
+		if json.Unmarshal(record.Value, &loan) != nil || record.Key != loan.LoanID {
+			continue
+		}
+		for _, lender := range loan.Lenders {
+			if lender.InvestorID != investorID {
+				continue
+			}
+			balances = append(balances, map[string]interface{}{
+				"loanId":               loan.LoanID,
+				"campaignId":           loan.CampaignID,
+				"startupId":            loan.StartupID,
+				"currency":             loan.Currency,
+				"principal":            lender.Principal,
+				"principalRepaid":      lender.PrincipalRepaid,
+				"interestPaid":         lender.InterestPaid,
+				"outstandingPrincipal": lender.OutstandingPrincipal,
+				"loanStatus":           loan.Status,
+				"daysPastDue":          loan.DaysPastDue,
+			})
+			totalOutstanding[loan.Currency] += lender.OutstandingPrincipal
+		}
+	}
+
+	response := map[string]interface{}{
+		"investorId":       investorID,
+		"loans":            balances,
+		"totalOutstanding": totalOutstanding,
+	}
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return "", err
+	}
+
+	return string(responseJSON), nil
+}
+
+// GetRewardTiers returns a campaign's reward tiers, cheapest first
+func (p *PlatformContract) GetRewardTiers(ctx contractapi.TransactionContextInterface, campaignID string) (string, error) {
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign PublishedCampaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+
+	tiers := []RewardTier{}
+	for _, tierID := range campaign.RewardTierIDs {
+		tier, err := getRewardTier(ctx, campaignID, tierID)
+		if err != nil {
+			return "", err
+		}
+		tiers = append(tiers, *tier)
+	}
+	sort.SliceStable(tiers, func(a, b int) bool { return tiers[a].PledgeAmount < tiers[b].PledgeAmount })
+
+	tiersJSON, err := json.Marshal(tiers)
+	if err != nil {
+		return "", err
+	}
+
+	return string(tiersJSON), nil
+}
+
+// GetRewardPledge retrieves a backer's pledge with its fulfillment history
+func (p *PlatformContract) GetRewardPledge(ctx contractapi.TransactionContextInterface, pledgeID string) (*RewardPledge, error) {
+	return getRewardPledge(ctx, pledgeID)
+}
+
+// GetCampaignPledges returns a campaign's pledges, optionally filtered by fulfillment status
+// fulfillmentStatus: PENDING, IN_PRODUCTION, SHIPPED, DELIVERED, CANCELLED (empty for all)
+func (p *PlatformContract) GetCampaignPledges(ctx contractapi.TransactionContextInterface, campaignID string, fulfillmentStatus string) (string, error) {
+	filter := map[string]interface{}{"campaignId": campaignID}
+	if fulfillmentStatus != "" {
+		filter["fulfillmentStatus"] = fulfillmentStatus
+	}
+
+	pledges, err := getRewardPledges(ctx, filter)
+	if err != nil {
+		return "", err
+	}
+
+	pledgesJSON, err := json.Marshal(pledges)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pledgesJSON), nil
+}
+
+// GetBackerPledges returns every pledge a backer has made
+func (p *PlatformContract) GetBackerPledges(ctx contractapi.TransactionContextInterface, backerID string) (string, error) {
+	pledges, err := getRewardPledges(ctx, map[string]interface{}{"backerId": backerID})
+	if err != nil {
+		return "", err
+	}
+
+	pledgesJSON, err := json.Marshal(pledges)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pledgesJSON), nil
+}
+
+// GetClawback retrieves the clawback of a blacklisted campaign
+func (p *PlatformContract) GetClawback(ctx contractapi.TransactionContextInterface, campaignID string) (*Clawback, error) {
+	return getClawback(ctx, campaignID)
+}
+
+// GetClawbackRecoveries returns the recovery payments of a campaign's clawback, oldest first
+func (p *PlatformContract) GetClawbackRecoveries(ctx contractapi.TransactionContextInterface, campaignID string) (string, error) {
+	records, err := getQueryResults(ctx, map[string]interface{}{
+		"recoveryId": map[string]bool{"$exists": true},
+		"clawbackId": fmt.Sprintf("CLAWBACK_%s", campaignID),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	recoveries := []ClawbackRecovery{}
+	for _, record := range records {
+		var recovery ClawbackRecovery
+		if json.Unmarshal(record.Value, &recovery) != nil {
+			continue
+		}
+		recoveries = append(recoveries, recovery)
+	}
+	sort.Slice(recoveries, func(a, b int) bool { return recoveries[a].RecordedAt < recoveries[b].RecordedAt })
+
+	recoveriesJSON, err := json.Marshal(recoveries)
+	if err != nil {
+		return "", err
+	}
+
+	return string(recoveriesJSON), nil
+}
+
+// GetFrozenEscrows returns frozen escrows with their active holds, optionally for one campaign (empty for all)
+func (p *PlatformContract) GetFrozenEscrows(ctx contractapi.TransactionContextInterface, campaignID string) (string, error) {
+	filter := map[string]interface{}{"status": "FROZEN"}
+	if campaignID != "" {
+		filter["campaignId"] = campaignID
+	}
+
+	escrows, err := getEscrows(ctx, filter)
+	if err != nil {
+		return "", err
+	}
+
+	frozen := []FrozenEscrow{}
+	for _, escrow := range escrows {
+		entry := FrozenEscrow{Escrow: escrow}
+		holdJSON, err := ctx.GetStub().GetState(escrow.ActiveHoldID)
+		if err != nil {
+			return "", fmt.Errorf("failed to read hold: %v", err)
+		}
+		if holdJSON != nil {
+			json.Unmarshal(holdJSON, &entry.Hold)
+		}
+		frozen = append(frozen, entry)
+	}
+	sort.Slice(frozen, func(a, b int) bool {
+		if frozen[a].Hold.OpenedAt != frozen[b].Hold.OpenedAt {
+			return frozen[a].Hold.OpenedAt < frozen[b].Hold.OpenedAt
+		}
+		return frozen[a].Escrow.EscrowID < frozen[b].Escrow.EscrowID
+	})
+
+	frozenJSON, err := json.Marshal(frozen)
+	if err != nil {
+		return "", err
+	}
+
+	return string(frozenJSON), nil
+}
+
+// GetFeeSchedule returns the current fee schedule (zero fees if none has been set)
+func (p *PlatformContract) GetFeeSchedule(ctx contractapi.TransactionContextInterface) (*FeeSchedule, error) {
+	return getFeeSchedule(ctx)
+}
+
+// GetReleaseFeeBreakdown returns the fee breakdown recorded for a fund release
+// Queried by startups and investors to see what was deducted
+func (p *PlatformContract) GetReleaseFeeBreakdown(ctx contractapi.TransactionContextInterface, releaseID string) (*FeeEntry, error) {
+	feeEntryJSON, err := ctx.GetStub().GetState(fmt.Sprintf("FEE_%s", releaseID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fee entry: %v", err)
+	}
+	if feeEntryJSON == nil {
+		return nil, fmt.Errorf("no fee entry recorded for release %s", releaseID)
+	}
+
+	var feeEntry FeeEntry
+	err = json.Unmarshal(feeEntryJSON, &feeEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &feeEntry, nil
+}
+
+// GetFeeLedger returns the platform revenue ledger, optionally for one campaign (empty for all)
+func (p *PlatformContract) GetFeeLedger(ctx contractapi.TransactionContextInterface, campaignID string) (*FeeLedger, error) {
+	now, err := getTxTime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := map[string]interface{}{
+		"feeEntryId": map[string]bool{"$exists": true},
+	}
+	if campaignID != "" {
+		selector["campaignId"] = campaignID
+	}
+
+	records, err := getQueryResults(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger := &FeeLedger{
+		TotalsByCurrency: map[string]float64{},
+		Entries:          []FeeEntry{},
+		GeneratedAt:      now.Format(time.RFC3339),
+	}
+	for _, record := range records {
+		var feeEntry FeeEntry
+		if json.Unmarshal(record.Value, &feeEntry) != nil {
+			continue
+		}
+		ledger.TotalsByCurrency[feeEntry.Currency] += feeEntry.TotalFee
+		ledger.Entries = append(ledger.Entries, feeEntry)
+	}
+	sort.Slice(ledger.Entries, func(a, b int) bool {
+		if ledger.Entries[a].RecordedAt != ledger.Entries[b].RecordedAt {
+			return ledger.Entries[a].RecordedAt < ledger.Entries[b].RecordedAt
+		}
+		return ledger.Entries[a].FeeEntryID < ledger.Entries[b].FeeEntryID
+	})
+
+	return ledger, nil
+}
+
+// BalanceOf returns the token balance of a journal account
+func (p *PlatformContract) BalanceOf(ctx contractapi.TransactionContextInterface, account string, currency string) (*TokenBalance, error) {
+	return getTokenBalance(ctx, account, currency)
+}
+
+// TotalSupply returns the tokens in circulation for a currency (minted minus burned)
+func (p *PlatformContract) TotalSupply(ctx contractapi.TransactionContextInterface, currency string) (*TokenSupply, error) {
+	clearing, err := getTokenBalance(ctx, fiatClearingAccount(), currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenSupply{Currency: currency, TotalSupply: -clearing.Balance}, nil
+}
+
+// GetReleaseApprovalPolicy returns the release approval policy (threshold 0 if none has been set)
+func (p *PlatformContract) GetReleaseApprovalPolicy(ctx contractapi.TransactionContextInterface) (*ReleaseApprovalPolicy, error) {
+	return getReleaseApprovalPolicy(ctx)
+}
+
+// GetPendingReleases returns fund releases awaiting approval, oldest first
+func (p *PlatformContract) GetPendingReleases(ctx contractapi.TransactionContextInterface) (string, error) {
+	releases, err := getPendingReleases(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	releasesJSON, err := json.Marshal(releases)
+	if err != nil {
+		return "", err
+	}
+
+	return string(releasesJSON), nil
+}
+
+// GetCallerIdentity returns the caller's MSP ID and client identity ID
+// Used to register release approvers
+func (p *PlatformContract) GetCallerIdentity(ctx contractapi.TransactionContextInterface) (*ReleaseApprover, error) {
+	mspID, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client MSP ID: %v", err)
+	}
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client identity: %v", err)
+	}
+
+	return &ReleaseApprover{ApproverID: clientID, MSPID: mspID}, nil
+}
+
+// GetPayment retrieves a reconciled payment by processor reference
+func (p *PlatformContract) GetPayment(ctx contractapi.TransactionContextInterface, processorReference string) (*PaymentRecord, error) {
+	paymentJSON, err := ctx.GetStub().GetState(paymentID(processorReference))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payment: %v", err)
+	}
+	if paymentJSON == nil {
+		return nil, fmt.Errorf("payment %s does not exist", processorReference)
+	}
+
+	var payment PaymentRecord
+	err = json.Unmarshal(paymentJSON, &payment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+// GetUnreconciledPayments returns payments that are not MATCHED, oldest value date first
+// direction: INBOUND, OUTBOUND or empty for both
+func (p *PlatformContract) GetUnreconciledPayments(ctx contractapi.TransactionContextInterface, direction string) (string, error) {
+	filter := map[string]interface{}{
+		"reconciliationStatus": map[string]string{"$ne": "MATCHED"},
+	}
+	if direction != "" {
+		if direction != "INBOUND" && direction != "OUTBOUND" {
+			return "", fmt.Errorf("invalid direction: %s. Must be INBOUND or OUTBOUND", direction)
+		}
+		filter["direction"] = direction
+	}
+
+	payments, err := getPayments(ctx, filter)
+	if err != nil {
+		return "", err
+	}
+
+	paymentsJSON, err := json.Marshal(payments)
+	if err != nil {
+		return "", err
+	}
+
+	return string(paymentsJSON), nil
+}
+
+// GetSettlementBatch retrieves an ingested settlement file summary
+func (p *PlatformContract) GetSettlementBatch(ctx contractapi.TransactionContextInterface, batchID string) (*SettlementBatch, error) {
+	batchJSON, err := ctx.GetStub().GetState("SETTLEMENT_BATCH_" + batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settlement batch: %v", err)
+	}
+	if batchJSON == nil {
+		return nil, fmt.Errorf("settlement batch %s does not exist", batchID)
+	}
+
+	var batch SettlementBatch
+	err = json.Unmarshal(batchJSON, &batch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batch, nil
+}
+
+// GetJournalEntries returns journal entries, optionally for one campaign (empty for all), oldest first
+func (p *PlatformContract) GetJournalEntries(ctx contractapi.TransactionContextInterface, campaignID string) (string, error) {
+	entries, err := getJournalEntries(ctx, campaignID)
+	if err != nil {
+		return "", err
+	}
+
+	entriesJSON, err := json.Marshal(entries)
+	if err != nil {
+		return "", err
+	}
+
+	return string(entriesJSON), nil
+}
+
+// GetTrialBalance totals every journal account and proves debits equal credits per currency
+func (p *PlatformContract) GetTrialBalance(ctx contractapi.TransactionContextInterface) (*TrialBalance, error) {
+	now, err := getTxTime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := getJournalEntries(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := map[string]*TrialBalanceAccount{}
+	trialBalance := &TrialBalance{
+		Accounts:    []TrialBalanceAccount{},
+		Totals:      map[string]*TrialBalanceTotals{},
+		EntryCount:  len(entries),
+		Balanced:    true,
+		GeneratedAt: now.Format(time.RFC3339),
+	}
+
+	for _, entry := range entries {
+		if trialBalance.Totals[entry.Currency] == nil {
+			trialBalance.Totals[entry.Currency] = &TrialBalanceTotals{Currency: entry.Currency}
+		}
+		for _, line := range entry.Lines {
+			key := entry.Currency + "|" + line.Account
+			if accounts[key] == nil {
+				accounts[key] = &TrialBalanceAccount{Account: line.Account, Currency: entry.Currency}
+			}
+			accounts[key].Debits += line.Debit
+			accounts[key].Credits += line.Credit
+			trialBalance.Totals[entry.Currency].Debits += line.Debit
+			trialBalance.Totals[entry.Currency].Credits += line.Credit
+		}
+	}
+
+	for _, account := range accounts {
+		account.Balance = account.Debits - account.Credits
+		trialBalance.Accounts = append(trialBalance.Accounts, *account)
+	}
+	sort.Slice(trialBalance.Accounts, func(a, b int) bool {
+		if trialBalance.Accounts[a].Currency != trialBalance.Accounts[b].Currency {
+			return trialBalance.Accounts[a].Currency < trialBalance.Accounts[b].Currency
+		}
+		return trialBalance.Accounts[a].Account < trialBalance.Accounts[b].Account
+	})
+
+	for _, totals := range trialBalance.Totals {
+		totals.Balanced = math.Abs(totals.Debits-totals.Credits) <= journalTolerance
+		if !totals.Balanced {
+			trialBalance.Balanced = false
+		}
+	}
+
+	return trialBalance, nil
+}
+
+// GetCampaignTreasuryReport returns escrow, release and refund totals for one campaign
+func (p *PlatformContract) GetCampaignTreasuryReport(ctx contractapi.TransactionContextInterface, campaignID string) (*TreasuryReport, error) {
+	return buildTreasuryReport(ctx, "CAMPAIGN", campaignID, map[string]interface{}{"campaignId": campaignID})
+}
+
+// GetStartupTreasuryReport returns escrow, release and refund totals across a startup's campaigns
+func (p *PlatformContract) GetStartupTreasuryReport(ctx contractapi.TransactionContextInterface, startupID string) (*TreasuryReport, error) {
+	return buildTreasuryReport(ctx, "STARTUP", startupID, map[string]interface{}{"startupId": startupID})
+}
+
+// GetPlatformTreasuryReport returns platform-wide escrow, release and refund totals
+// Used by finance for the daily bank reconciliation
+func (p *PlatformContract) GetPlatformTreasuryReport(ctx contractapi.TransactionContextInterface) (*TreasuryReport, error) {
+	return buildTreasuryReport(ctx, "PLATFORM", "", map[string]interface{}{})
+}
+
+// ============================================================================
+// LEDGER HELPERS
+// ============================================================================
+
+// queryRecord is a single key/value returned by a rich query
+type queryRecord struct {
+	Key   string
+	Value []byte
+}
+
+// getQueryResults runs a CouchDB selector query and collects all matching records
+func getQueryResults(ctx contractapi.TransactionContextInterface, selector map[string]interface{}) ([]queryRecord, error) {
+	queryJSON, err := json.Marshal(map[string]interface{}{"selector": selector})
+	if err != nil {
+		return nil, err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetQueryResult(string(queryJSON))
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	var records []queryRecord
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, queryRecord{Key: queryResponse.Key, Value: queryResponse.Value})
+	}
+
+	return records, nil
+}
+
+// getTxTime returns the transaction timestamp so every endorser sees the same time
+func getTxTime(ctx contractapi.TransactionContextInterface) (time.Time, error) {
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read transaction timestamp: %v", err)
+	}
+	return txTimestamp.AsTime().UTC(), nil
+}
+
+// parseDate parses dates given as YYYY-MM-DD or RFC3339
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// ============================================================================
+// CROSS-CHANNEL INVOCATION HELPER FUNCTIONS
+// ============================================================================
+
+// InvokeStartupOrgGetCampaign reads full campaign data from StartupOrg
+// Cross-channel READ from startup-platform-channel
+func (p *PlatformContract) InvokeStartupOrgGetCampaign(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+) (string, error) {
+	args := [][]byte{
+		[]byte("GetCampaign"),
+		[]byte(campaignID),
+	}
+
+	response := ctx.GetStub().InvokeChaincode(
+		"startuporg",
+		args,
+		"startup-platform-channel",
+	)
+
+	if response.Status != 200 {
+		return "", fmt.Errorf("cross-channel query to StartupOrg failed: %s", response.Message)
+	}
+
+	return string(response.Payload), nil
+}
+
+// InvokeValidatorOrgGetValidation reads validation data from ValidatorOrg
+// Cross-channel READ from validator-platform-channel
+func (p *PlatformContract) InvokeValidatorOrgGetValidation(
+	ctx contractapi.TransactionContextInterface,
+	validationID string,
+) (string, error) {
+	args := [][]byte{
+		[]byte("GetValidation"),
+		[]byte(validationID),
+	}
+
+	response := ctx.GetStub().InvokeChaincode(
+		"validatororg",
+		args,
+		"validator-platform-channel",
+	)
+
+	if response.Status != 200 {
+		return "", fmt.Errorf("cross-channel query to ValidatorOrg failed: %s", response.Message)
+	}
+
+	return string(response.Payload), nil
+}
+
+// InvokeStartupOrgNotifyFundRelease notifies StartupOrg about fund release
+// Cross-channel call to common-channel
+func (p *PlatformContract) InvokeStartupOrgNotifyFundRelease(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	amount string,
+	status string,
+) (string, error) {
+	args := [][]byte{
+		[]byte("ReceiveFunding"),
+		[]byte(campaignID),
+		[]byte(amount),
+		[]byte(status),
+	}
+
+	response := ctx.GetStub().InvokeChaincode(
+		"startuporg",
+		args,
+		"common-channel",
+	)
+
+	if response.Status != 200 {
+		return "", fmt.Errorf("cross-channel invoke to StartupOrg failed: %s", response.Message)
+	}
+
+	// Emit cross-channel event
+	eventPayload := map[string]interface{}{
+		"campaignId":     campaignID,
+		"targetChannel":  "common-channel",
+		"targetContract": "startuporg",
+		"action":         "CROSS_CHANNEL_FUND_RELEASE_NOTIFY",
+		"timestamp":      time.Now().Format(time.RFC3339),
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("CrossChannelInvoke", eventJSON)
+
+	return string(response.Payload), nil
+}
+
+// InvokeCommonChannelPublish publishes to common-channel (all orgs can read)
+func (p *PlatformContract) InvokeCommonChannelPublish(
+	ctx contractapi.TransactionContextInterface,
+	metricsID string,
+	metricsHash string,
+) (string, error) {
+	// Common channel is directly accessible, but this shows cross-channel pattern
+	args := [][]byte{
+		[]byte("PublishGlobalMetrics"),
+		[]byte(metricsID),
+		[]byte(metricsHash),
+	}
+
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg", // same contract but different channel
+		args,
+		"common-channel",
+	)
+
+	if response.Status != 200 {
+		return "", fmt.Errorf("cross-channel invoke to common-channel failed: %s", response.Message)
+	}
+
+	return string(response.Payload), nil
+}
+
+// generateHash generates SHA256 hash
+func generateHash(data string) string {
+	hash := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(hash[:])
+}
+
+// parseNegotiationTerms parses a term sheet
+func parseNegotiationTerms(termsJSON string) (NegotiationTerms, error) {
+	var terms NegotiationTerms
+	if err := json.Unmarshal([]byte(termsJSON), &terms); err != nil {
+		return terms, fmt.Errorf("failed to parse negotiation terms: %v", err)
+	}
+	return terms, nil
+}
+
+// normalizeTerms canonicalizes a term sheet and returns it with its hash
+// Amounts are rounded to cents, codes upper-cased and milestones ordered by ID
+func normalizeTerms(terms NegotiationTerms) (NegotiationTerms, string, error) {
+	terms.Amount = math.Round(terms.Amount*100) / 100
+	terms.Currency = strings.ToUpper(strings.TrimSpace(terms.Currency))
+	terms.PreMoneyValuation = math.Round(terms.PreMoneyValuation*100) / 100
+	terms.EquityPercent = math.Round(terms.EquityPercent*10000) / 10000
+	terms.BoardRights = strings.ToUpper(strings.TrimSpace(terms.BoardRights))
+	terms.Notes = strings.TrimSpace(terms.Notes)
+	if terms.BoardRights == "" {
+		terms.BoardRights = "NONE"
+	}
+
+	if terms.Amount <= 0 {
+		return terms, "", fmt.Errorf("terms amount must be positive")
+	}
+	if terms.PreMoneyValuation < 0 || terms.EquityPercent < 0 || terms.EquityPercent > 100 {
+		return terms, "", fmt.Errorf("valuation cannot be negative and equity must be between 0 and 100 percent")
+	}
+	validBoardRights := map[string]bool{"NONE": true, "OBSERVER": true, "SEAT": true}
+	if !validBoardRights[terms.BoardRights] {
+		return terms, "", fmt.Errorf("invalid board rights: %s. Must be NONE, OBSERVER or SEAT", terms.BoardRights)
+	}
+	if terms.Instrument != (InstrumentTerms{}) {
+		if err := validateInstrumentTerms(terms.Instrument); err != nil {
+			return terms, "", err
+		}
+	}
+
+	milestones := []TermsMilestone{}
+	for _, milestone := range terms.Milestones {
+		milestones = append(milestones, TermsMilestone{
+			MilestoneID:    strings.TrimSpace(milestone.MilestoneID),
+			Title:          strings.TrimSpace(milestone.Title),
+			TargetDate:     strings.TrimSpace(milestone.TargetDate),
+			FundPercentage: math.Round(milestone.FundPercentage*10000) / 10000,
+		})
+	}
+	sort.Slice(milestones, func(a, b int) bool { return milestones[a].MilestoneID < milestones[b].MilestoneID })
+	terms.Milestones = milestones
+
+	termsJSON, err := json.Marshal(terms)
+	if err != nil {
+		return terms, "", err
+	}
+	return terms, generateHash(string(termsJSON)), nil
+}
+
+// diffTerms lists the fields that differ between two term sheets, ordered by field path
+// A list entry added or removed as a whole is reported once, with the entry as its value
+func diffTerms(previous NegotiationTerms, next NegotiationTerms) []TermChange {
+	before, after := map[string]string{}, map[string]string{}
+	beforeEntries, afterEntries := map[string]string{}, map[string]string{}
+	flattenTerms(previous, "", before, beforeEntries)
+	flattenTerms(next, "", after, afterEntries)
+
+	// Entries present on one side only replace their individual fields
+	for entry, entryJSON := range beforeEntries {
+		if _, found := afterEntries[entry]; !found {
+			before[entry] = entryJSON
+		}
+	}
+	for entry, entryJSON := range afterEntries {
+		if _, found := beforeEntries[entry]; !found {
+			after[entry] = entryJSON
+		}
+	}
+	wholeEntry := func(field string) bool {
+		end := strings.LastIndex(field, "]")
+		if end < 0 || end == len(field)-1 {
+			return false
+		}
+		_, inBefore := beforeEntries[field[:end+1]]
+		_, inAfter := afterEntries[field[:end+1]]
+		return inBefore != inAfter
+	}
+
+	fields := []string{}
+	for field := range before {
+		fields = append(fields, field)
+	}
+	for field := range after {
+		if _, found := before[field]; !found {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := []TermChange{}
+	for _, field := range fields {
+		if before[field] != after[field] && !wholeEntry(field) {
+			changes = append(changes, TermChange{Field: field, From: before[field], To: after[field]})
+		}
+	}
+	return changes
+}
+
+// flattenTerms maps every leaf field of a term sheet to its JSON value, and every list entry to its JSON
+// List entries are keyed by milestoneId when they have one, otherwise by position
+func flattenTerms(value interface{}, path string, fields map[string]string, entries map[string]string) {
+	if path == "" {
+		valueJSON, _ := json.Marshal(value)
+		var generic interface{}
+		json.Unmarshal(valueJSON, &generic)
+		value = generic
+	}
+
+	switch node := value.(type) {
+	case map[string]interface{}:
+		for key, child := range node {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			flattenTerms(child, childPath, fields, entries)
+		}
+	case []interface{}:
+		for idx, child := range node {
+			key := strconv.Itoa(idx)
+			if entry, ok := child.(map[string]interface{}); ok {
+				if id, ok := entry["milestoneId"].(string); ok && id != "" {
+					key = id
+				}
+			}
+			entryPath := fmt.Sprintf("%s[%s]", path, key)
+			entryJSON, _ := json.Marshal(child)
+			entries[entryPath] = string(entryJSON)
+			flattenTerms(child, entryPath, fields, entries)
+		}
+	default:
+		leafJSON, _ := json.Marshal(node)
+		fields[path] = string(leafJSON)
+	}
+}
+
+// acceptedTerms returns the term sheet both parties accepted, normalized again with its recomputed hash
+// It rejects terms the parties accepted under different hashes, or that differ from the witnessed amount
+func acceptedTerms(ctx contractapi.TransactionContextInterface, agreementID string, investmentAmount float64, currency string) (*AgreementAcceptance, NegotiationTerms, string, error) {
+	var terms NegotiationTerms
+	acceptance, err := getAgreementAcceptance(ctx, agreementID)
+	if err != nil {
+		return nil, terms, "", err
+	}
+	if acceptance.InvestorTermsHash == "" || acceptance.StartupTermsHash == "" {
+		return nil, terms, "", fmt.Errorf("both startup and investor must publish their accepted terms before Platform can witness agreement %s. Startup: %v, Investor: %v", agreementID, acceptance.StartupTermsHash != "", acceptance.InvestorTermsHash != "")
+	}
+
+	terms, termsHash, err := normalizeTerms(acceptance.InvestorTerms)
+	if err != nil {
+		return nil, terms, "", err
+	}
+	if termsHash != acceptance.InvestorTermsHash || termsHash != acceptance.StartupTermsHash {
+		return nil, terms, "", fmt.Errorf("accepted terms do not match: investor accepted %s, startup accepted %s, terms hash to %s", acceptance.InvestorTermsHash, acceptance.StartupTermsHash, termsHash)
+	}
+	if terms.Amount != math.Round(investmentAmount*100)/100 {
+		return nil, terms, "", fmt.Errorf("negotiated amount %.2f does not match investment amount %.2f", terms.Amount, investmentAmount)
+	}
+	if terms.Currency != strings.ToUpper(currency) {
+		return nil, terms, "", fmt.Errorf("negotiated currency %s does not match %s", terms.Currency, currency)
+	}
+	return acceptance, terms, termsHash, nil
+}
+
+// getAgreementAcceptance returns the parties' accepted terms, empty when neither has published yet
+func getAgreementAcceptance(ctx contractapi.TransactionContextInterface, agreementID string) (*AgreementAcceptance, error) {
+	acceptanceJSON, err := ctx.GetStub().GetState(agreementAcceptanceKey(agreementID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agreement acceptance: %v", err)
+	}
+	acceptance := AgreementAcceptance{AgreementID: agreementID}
+	if acceptanceJSON == nil {
+		return &acceptance, nil
+	}
+	if err := json.Unmarshal(acceptanceJSON, &acceptance); err != nil {
+		return nil, err
+	}
+	return &acceptance, nil
+}
+
+func agreementAcceptanceKey(agreementID string) string { return "AGREEMENT_ACCEPTANCE_" + agreementID }
+
+// validateInstrumentTerms checks SAFE or convertible note terms
+// It is the one validator of instrument terms; InvestorOrg and StartupOrg pass them through as raw JSON
+func validateInstrumentTerms(terms InstrumentTerms) error {
+	if terms.ValuationCap < 0 || terms.DiscountPercent < 0 || terms.DiscountPercent >= 100 {
+		return fmt.Errorf("valuation cap cannot be negative and discount must be below 100 percent")
+	}
+
+	switch terms.InstrumentType {
+	case "SAFE":
+		if terms.InterestRate != 0 || terms.MaturityDate != "" {
+			return fmt.Errorf("SAFEs carry no interest rate or maturity date")
+		}
+		if terms.ValuationCap == 0 && terms.DiscountPercent == 0 && !terms.MostFavoredNation {
+			return fmt.Errorf("SAFE needs a valuation cap, a discount or MFN")
+		}
+	case "CONVERTIBLE_NOTE":
+		if terms.InterestRate < 0 {
+			return fmt.Errorf("note interest rate cannot be negative")
+		}
+		if terms.MostFavoredNation {
+			return fmt.Errorf("MFN applies to SAFEs only")
+		}
+		if _, err := parseDate(terms.MaturityDate); err != nil {
+			return fmt.Errorf("invalid note maturity date: %v", err)
+		}
+	default:
+		return fmt.Errorf("invalid instrument type: %s. Must be SAFE or CONVERTIBLE_NOTE", terms.InstrumentType)
+	}
+	return nil
+}
+
+// getEscrows returns all escrows matching the extra selector fields
+func getEscrows(ctx contractapi.TransactionContextInterface, filter map[string]interface{}) ([]FundEscrow, error) {
+	selector := map[string]interface{}{
+		"escrowId":   map[string]bool{"$exists": true},
+		"heldAmount": map[string]bool{"$exists": true},
+	}
+	for field, value := range filter {
+		selector[field] = value
+	}
+
+	records, err := getQueryResults(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	escrows := []FundEscrow{}
+	for _, record := range records {
+		var escrow FundEscrow
+		if json.Unmarshal(record.Value, &escrow) != nil {
+			continue
+		}
+		escrows = append(escrows, escrow)
+	}
+	sort.Slice(escrows, func(a, b int) bool { return escrows[a].EscrowID < escrows[b].EscrowID })
+	return escrows, nil
+}
+
+// getReleasesForEscrows returns fund releases grouped by escrow ID, oldest first
+func getReleasesForEscrows(ctx contractapi.TransactionContextInterface, escrowIDs []string) (map[string][]FundRelease, error) {
+	releases := map[string][]FundRelease{}
+	for _, escrowID := range escrowIDs {
+		releases[escrowID] = []FundRelease{}
+	}
+	if len(escrowIDs) == 0 {
+		return releases, nil
+	}
+
+	records, err := getQueryResults(ctx, map[string]interface{}{
+		"releaseId": map[string]bool{"$exists": true},
+		"escrowId":  map[string]interface{}{"$in": escrowIDs},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		var release FundRelease
+		if json.Unmarshal(record.Value, &release) != nil {
+			continue
+		}
+		releases[release.EscrowID] = append(releases[release.EscrowID], release)
+	}
+	for escrowID := range releases {
+		list := releases[escrowID]
+		sort.Slice(list, func(a, b int) bool { return list[a].ReleasedAt < list[b].ReleasedAt })
+	}
+	return releases, nil
+}
+
+// closeCampaign stores the closure record, refunds failed or cancelled campaigns and marks the campaign CLOSED
+// It emits no event so callers can report single and swept closures in one event
+func closeCampaign(
+	ctx contractapi.TransactionContextInterface,
+	closureID string,
+	campaignID string,
+	finalStatus string,
+	finalAmount float64,
+	finalInvestorCount int,
+	closureReason string,
+	now string,
+) (*CampaignClosure, []EscrowRefund, error) {
+	// Create closure record
+	closure := CampaignClosure{
+		ClosureID:          closureID,
+		CampaignID:         campaignID,
+		FinalStatus:        finalStatus,
+		FinalAmount:        finalAmount,
+		ReportedAmount:     finalAmount,
+		FinalInvestorCount: finalInvestorCount,
+		ClosureReason:      closureReason,
+		ClosedAt:           now,
+	}
+
+	// Final amount and investor count come from the escrows, not the caller
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON != nil {
+		var campaign PublishedCampaign
+		if err := json.Unmarshal(campaignJSON, &campaign); err != nil {
+			return nil, nil, err
+		}
+		if campaign.Status == "CLOSED" {
+			return nil, nil, fmt.Errorf("campaign %s is already closed", campaignID)
+		}
+		closure.FinalAmount, closure.FinalInvestorCount, err = campaignEscrowTotals(ctx, campaign)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	closureJSON, err := json.Marshal(closure)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Store closure record
+	err = ctx.GetStub().PutState(closureID, closureJSON)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Failed or cancelled campaigns return all held funds to investors
+	refunds := []EscrowRefund{}
+	if finalStatus == "FAILED" || finalStatus == "CANCELLED" {
+		refunds, err = refundCampaignEscrows(ctx, campaignID, "CAMPAIGN_"+finalStatus, now)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := cancelRefundedPledges(ctx, campaignID, refunds, now); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Update campaign status (re-read: refunds do not touch the campaign record)
+	if campaignJSON != nil {
+		var campaign PublishedCampaign
+		json.Unmarshal(campaignJSON, &campaign)
+		campaign.Status = "CLOSED"
+		campaign.FinalStatus = finalStatus
+		campaign.FundsRaisedAmount = closure.FinalAmount
+		campaign.InvestorCount = closure.FinalInvestorCount
+		if campaign.GoalAmount > 0 {
+			campaign.FundsRaisedPercent = (closure.FinalAmount / campaign.GoalAmount) * 100
+		}
+		campaign.UpdatedAt = now
+		updatedCampaignJSON, _ := json.Marshal(campaign)
+		ctx.GetStub().PutState(campaignID, updatedCampaignJSON)
+
+		indexKey, err := ctx.GetStub().CreateCompositeKey(openCampaignIndex, []string{campaignID})
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := ctx.GetStub().DelState(indexKey); err != nil {
+			return nil, nil, err
+		}
+
+		// Equity campaigns record the fully diluted cap table as of the closure
+		if campaign.ShareClass.ClassName != "" {
+			if err := snapshotCapTable(ctx, campaign, closure); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return &closure, refunds, nil
+}
+
+// openCampaignIndex is the composite key index of published campaigns that are not yet closed
+const openCampaignIndex = "campaign~open"
+
+// putOpenCampaignIndex adds a campaign to the open campaign index
+func putOpenCampaignIndex(ctx contractapi.TransactionContextInterface, campaignID string) error {
+	indexKey, err := ctx.GetStub().CreateCompositeKey(openCampaignIndex, []string{campaignID})
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(indexKey, []byte{0x00})
+}
+
+// getOpenCampaignIDs lists the open campaign index in campaign ID order
+func getOpenCampaignIDs(ctx contractapi.TransactionContextInterface) ([]string, error) {
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(openCampaignIndex, []string{})
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	campaignIDs := []string{}
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		_, attributes, err := ctx.GetStub().SplitCompositeKey(queryResponse.Key)
+		if err != nil {
+			return nil, err
+		}
+		campaignIDs = append(campaignIDs, attributes[0])
+	}
+	return campaignIDs, nil
+}
+
+// campaignEscrows reads the escrows of a campaign's agreements and reward pledges by key
+func campaignEscrows(ctx contractapi.TransactionContextInterface, campaign PublishedCampaign) ([]FundEscrow, error) {
+	escrowIDs := []string{}
+	for _, agreementID := range campaign.AgreementIDs {
+		escrowIDs = append(escrowIDs, fmt.Sprintf("ESCROW_%s", agreementID))
+	}
+	for _, pledgeID := range campaign.PledgeIDs {
+		escrowIDs = append(escrowIDs, pledgeEscrowID(pledgeID))
+	}
+	sort.Strings(escrowIDs)
+
+	escrows := []FundEscrow{}
+	for _, escrowID := range escrowIDs {
+		escrowJSON, err := ctx.GetStub().GetState(escrowID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read escrow: %v", err)
+		}
+		if escrowJSON == nil {
+			continue
+		}
+		var escrow FundEscrow
+		if err := json.Unmarshal(escrowJSON, &escrow); err != nil {
+			return nil, err
+		}
+		escrows = append(escrows, escrow)
+	}
+	return escrows, nil
+}
+
+// campaignEscrowTotals returns the amount escrowed in the campaign currency (excluding refunds) and the investor count
+func campaignEscrowTotals(ctx contractapi.TransactionContextInterface, campaign PublishedCampaign) (float64, int, error) {
+	escrows, err := campaignEscrows(ctx, campaign)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	raised := 0.0
+	investors := map[string]bool{}
+	for _, escrow := range escrows {
+		if escrow.Currency != campaign.Currency || escrow.Status == "REFUNDED" {
+			continue
+		}
+		raised += escrow.TotalAmount - escrow.RefundedAmount
+		investors[escrow.InvestorID] = true
+	}
+	return raised, len(investors), nil
+}
+
+// journalTolerance absorbs float rounding when comparing debits and credits
+const journalTolerance = 0.005
+
+func investorWalletAccount(investorID string) string { return "INVESTOR_WALLET:" + investorID }
+func escrowAccount(escrowID string) string           { return "ESCROW:" + escrowID }
+func startupPayableAccount(startupID string) string  { return "STARTUP_PAYABLE:" + startupID }
+func startupCashAccount(startupID string) string     { return "STARTUP_CASH:" + startupID }
+func vestingAccount(releaseID string) string         { return "VESTING:" + releaseID }
+func platformFeesAccount() string                    { return "PLATFORM_FEES" }
+func fiatClearingAccount() string                    { return "FIAT_CLEARING" }
+
+func journalEntryID(entryType string, reference string) string {
+	return fmt.Sprintf("JOURNAL_%s_%s", entryType, reference)
+}
+
+// postJournalEntry stores a balanced journal entry; zero lines are dropped
+// Each (entryType, reference) pair can be posted only once
+func postJournalEntry(
+	ctx contractapi.TransactionContextInterface,
+	entryType string,
+	reference string,
+	campaignID string,
+	currency string,
+	lines []JournalLine,
+	memo string,
+	now string,
+) error {
+	entry := JournalEntry{
+		EntryID:    journalEntryID(entryType, reference),
+		EntryType:  entryType,
+		Reference:  reference,
+		CampaignID: campaignID,
+		Currency:   currency,
+		Lines:      []JournalLine{},
+		Memo:       memo,
+		PostedAt:   now,
+	}
+
+	debits, credits := 0.0, 0.0
+	for _, line := range lines {
+		if line.Debit < 0 || line.Credit < 0 {
+			return fmt.Errorf("journal entry %s has a negative line for %s", entry.EntryID, line.Account)
+		}
+		if line.Debit == 0 && line.Credit == 0 {
+			continue
+		}
+		debits += line.Debit
+		credits += line.Credit
+		entry.Lines = append(entry.Lines, line)
+	}
+	if math.Abs(debits-credits) > journalTolerance {
+		return fmt.Errorf("journal entry %s does not balance: debits %.2f, credits %.2f", entry.EntryID, debits, credits)
+	}
+
+	existing, err := ctx.GetStub().GetState(entry.EntryID)
+	if err != nil {
+		return fmt.Errorf("failed to read journal entry: %v", err)
+	}
+	if existing != nil {
+		return fmt.Errorf("journal entry %s already posted", entry.EntryID)
+	}
+
+	if err := applyTokenMovements(ctx, entry, now); err != nil {
+		return err
+	}
+
+	entryJSON, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(entry.EntryID, entryJSON)
+}
+
+// applyTokenMovements moves settlement tokens for every line of a journal entry
+// Only FIAT_CLEARING may go negative; any other account without enough tokens fails the entry
+func applyTokenMovements(ctx contractapi.TransactionContextInterface, entry JournalEntry, now string) error {
+	balances := map[string]*TokenBalance{}
+	var accounts []string
+	for _, line := range entry.Lines {
+		if balances[line.Account] == nil {
+			balance, err := getTokenBalance(ctx, line.Account, entry.Currency)
+			if err != nil {
+				return err
+			}
+			balances[line.Account] = balance
+			accounts = append(accounts, line.Account)
+		}
+		balances[line.Account].Balance += line.Debit - line.Credit
+	}
+
+	for _, account := range accounts {
+		if account != fiatClearingAccount() && balances[account].Balance < -journalTolerance {
+			return fmt.Errorf("insufficient %s tokens in %s for %s", entry.Currency, account, entry.EntryID)
+		}
+	}
+
+	for _, account := range accounts {
+		balance := balances[account]
+		balance.UpdatedAt = now
+
+		balanceJSON, err := json.Marshal(balance)
+		if err != nil {
+			return err
+		}
+		if err := ctx.GetStub().PutState(tokenBalanceKey(account, entry.Currency), balanceJSON); err != nil {
+			return err
+		}
+		if platformCtx, ok := ctx.(*PlatformTransactionContext); ok {
+			if platformCtx.tokenBalances == nil {
+				platformCtx.tokenBalances = map[string]*TokenBalance{}
+			}
+			cached := *balance
+			platformCtx.tokenBalances[tokenBalanceKey(account, entry.Currency)] = &cached
+		}
+	}
+	return nil
+}
+
+func tokenBalanceKey(account string, currency string) string {
+	return fmt.Sprintf("TOKEN_%s_%s", currency, account)
+}
+
+// getTokenBalance returns an account's token balance (zero if it has never held tokens)
+func getTokenBalance(ctx contractapi.TransactionContextInterface, account string, currency string) (*TokenBalance, error) {
+	if platformCtx, ok := ctx.(*PlatformTransactionContext); ok {
+		if cached, found := platformCtx.tokenBalances[tokenBalanceKey(account, currency)]; found {
+			balance := *cached
+			return &balance, nil
+		}
+	}
+
+	balanceJSON, err := ctx.GetStub().GetState(tokenBalanceKey(account, currency))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token balance: %v", err)
+	}
+
+	balance := TokenBalance{Account: account, Currency: currency}
+	if balanceJSON != nil {
+		if err := json.Unmarshal(balanceJSON, &balance); err != nil {
+			return nil, err
+		}
+	}
+	return &balance, nil
+}
+
+// checkWalletAccount allows mint, burn and transfer only on investor wallets and startup cash accounts
+func checkWalletAccount(account string) error {
+	if strings.HasPrefix(account, "INVESTOR_WALLET:") || strings.HasPrefix(account, "STARTUP_CASH:") {
+		return nil
+	}
+	return fmt.Errorf("invalid wallet account: %s. Must be INVESTOR_WALLET:<investorId> or STARTUP_CASH:<startupId>", account)
+}
+
+// requireMSP rejects callers outside the given organization
+func requireMSP(ctx contractapi.TransactionContextInterface, mspID string) error {
+	clientMSPID, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		return fmt.Errorf("failed to read client MSP ID: %v", err)
+	}
+	if clientMSPID != mspID {
+		return fmt.Errorf("only %s can perform this action, caller is %s", mspID, clientMSPID)
+	}
+	return nil
+}
+
+// tokenMovementResponse emits the token event and builds the response with resulting balances
+func tokenMovementResponse(
+	ctx contractapi.TransactionContextInterface,
+	eventName string,
+	action string,
+	reference string,
+	fromAccount string,
+	toAccount string,
+	currency string,
+	amount float64,
+	now string,
+) (string, error) {
+	eventPayload := map[string]interface{}{
+		"reference":   reference,
+		"fromAccount": fromAccount,
+		"toAccount":   toAccount,
+		"currency":    currency,
+		"amount":      amount,
+		"channel":     "common-channel",
+		"action":      action,
+		"timestamp":   now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent(eventName, eventJSON)
+
+	balances := map[string]float64{}
+	for _, account := range []string{fromAccount, toAccount} {
+		if account == "" {
+			continue
+		}
+		balance, err := getTokenBalance(ctx, account, currency)
+		if err != nil {
+			return "", err
+		}
+		balances[account] = balance.Balance
+	}
+
+	response := map[string]interface{}{
+		"message":   fmt.Sprintf("%.2f %s tokens: %s", amount, currency, action),
+		"reference": reference,
+		"currency":  currency,
+		"amount":    amount,
+		"balances":  balances,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// getJournalEntries returns journal entries, optionally for one campaign, oldest first
+func getJournalEntries(ctx contractapi.TransactionContextInterface, campaignID string) ([]JournalEntry, error) {
+	selector := map[string]interface{}{
+		"entryId":   map[string]bool{"$exists": true},
+		"entryType": map[string]bool{"$exists": true},
+	}
+	if campaignID != "" {
+		selector["campaignId"] = campaignID
+	}
+
+	records, err := getQueryResults(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []JournalEntry{}
+	for _, record := range records {
+		var entry JournalEntry
+		if json.Unmarshal(record.Value, &entry) != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(a, b int) bool {
+		if entries[a].PostedAt != entries[b].PostedAt {
+			return entries[a].PostedAt < entries[b].PostedAt
+		}
+		return entries[a].EntryID < entries[b].EntryID
+	})
+
+	return entries, nil
+}
+
+// releaseNetAmount returns what the startup received from a release
+// Releases made before platform fees existed paid out the full amount
+func releaseNetAmount(release FundRelease) float64 {
+	if release.NetAmount == 0 && release.FeeAmount == 0 {
+		return release.Amount
+	}
+	return release.NetAmount
+}
+
+// executeFundRelease moves a release's funds out of escrow: fee, journal, escrow and campaign
+// It emits no event so callers can report direct and approved releases differently
+func executeFundRelease(ctx contractapi.TransactionContextInterface, release *FundRelease, escrow *FundEscrow, now string) error {
+	// Deduct the platform fee from the released amount
+	feeEntry, err := calculateReleaseFee(ctx, release.ReleaseID, *escrow, release.Amount)
+	if err != nil {
+		return err
+	}
+	feeEntry.RecordedAt = now
+
+	release.FeeAmount = feeEntry.TotalFee
+	release.NetAmount = feeEntry.NetAmount
+	release.Status = "RELEASED"
+	release.ReleasedAt = now
+
+	// Vesting releases hold the net amount until the startup claims it
+	payableAccount := startupPayableAccount(escrow.StartupID)
+	if escrow.VestingSchedule.Installments > 0 {
+		txTime, err := getTxTime(ctx)
+		if err != nil {
+			return err
+		}
+		release.VestingSchedule = escrow.VestingSchedule
+		release.VestingStartsAt = txTime.Format(time.RFC3339)
+		payableAccount = vestingAccount(release.ReleaseID)
+	}
+
+	releaseJSON, err := json.Marshal(release)
+	if err != nil {
+		return err
+	}
+
+	// Store release record
+	err = ctx.GetStub().PutState(release.ReleaseID, releaseJSON)
+	if err != nil {
+		return err
+	}
+
+	// Record fee in the platform revenue ledger
+	feeEntryJSON, err := json.Marshal(feeEntry)
+	if err != nil {
+		return err
+	}
+	err = ctx.GetStub().PutState(feeEntry.FeeEntryID, feeEntryJSON)
+	if err != nil {
+		return err
+	}
+
+	// Released amount leaves escrow: net to the startup, fee to the platform
+	err = postJournalEntry(ctx, "FUNDS_RELEASED", release.ReleaseID, escrow.CampaignID, escrow.Currency, []JournalLine{
+		{Account: payableAccount, Debit: release.NetAmount},
+		{Account: platformFeesAccount(), Debit: release.FeeAmount},
+		{Account: escrowAccount(escrow.EscrowID), Credit: release.Amount},
+	}, fmt.Sprintf("%s release", releaseLabel(*release)), now)
+	if err != nil {
+		return err
+	}
+
+	// Update escrow
+	escrow.ReleasedAmount += release.Amount
+	escrow.HeldAmount -= release.Amount
+	escrow.UpdatedAt = now
+	if escrow.HeldAmount <= 0 {
+		escrow.Status = "FULLY_RELEASED"
+	} else {
+		escrow.Status = "PARTIALLY_RELEASED"
+	}
+
+	updatedEscrowJSON, _ := json.Marshal(escrow)
+	ctx.GetStub().PutState(escrow.EscrowID, updatedEscrowJSON)
+
+	// Mark the released milestone; funds raised come from the escrows and the status from closure,
+	// so a release changes neither
+	if release.MilestoneID == "" {
+		return nil
+	}
+	campaignJSON, err := ctx.GetStub().GetState(escrow.CampaignID)
+	if err == nil && campaignJSON != nil {
+		var campaign PublishedCampaign
+		json.Unmarshal(campaignJSON, &campaign)
+
+		// Update milestone status
+		for i, m := range campaign.Milestones {
+			if m.MilestoneID == release.MilestoneID {
+				campaign.Milestones[i].FundsReleased = true
+				campaign.Milestones[i].ReleasedAt = now
+				campaign.Milestones[i].Status = "VERIFIED"
+				break
+			}
+		}
+
+		campaign.UpdatedAt = now
+		updatedCampaignJSON, _ := json.Marshal(campaign)
+		ctx.GetStub().PutState(escrow.CampaignID, updatedCampaignJSON)
+	}
+
+	return nil
+}
+
+// collectReleaseEvidence reads the milestone approvals a release requires from common-channel:
+// the validator's attested MilestoneValidation and the escrow investor's MilestoneVerification
+// Releases without approved evidence from both fail
+func collectReleaseEvidence(ctx contractapi.TransactionContextInterface, release FundRelease, escrow FundEscrow, now string) (*ReleaseEvidence, error) {
+	response := ctx.GetStub().InvokeChaincode(
+		"validatororg",
+		[][]byte{[]byte("GetMilestoneAttestation"), []byte(escrow.CampaignID), []byte(release.MilestoneID)},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return nil, fmt.Errorf("milestone %s has no validator approval on common-channel: %s", release.MilestoneID, response.Message)
+	}
+
+	var attestation struct {
+		VerificationID      string `json:"verificationId"`
+		MilestoneID         string `json:"milestoneId"`
+		CampaignID          string `json:"campaignId"`
+		MilestoneReportHash string `json:"milestoneReportHash"`
+		Approved            bool   `json:"approved"`
+		ValidationHash      string `json:"validationHash"`
+	}
+	if err := json.Unmarshal(response.Payload, &attestation); err != nil {
+		return nil, fmt.Errorf("failed to parse validator attestation: %v", err)
+	}
+	if attestation.MilestoneID != release.MilestoneID || attestation.CampaignID != escrow.CampaignID {
+		return nil, fmt.Errorf("validator attestation %s is not for milestone %s of campaign %s", attestation.VerificationID, release.MilestoneID, escrow.CampaignID)
+	}
+	if !attestation.Approved {
+		return nil, fmt.Errorf("validator did not approve milestone %s", release.MilestoneID)
+	}
+	if attestation.ValidationHash == "" {
+		return nil, fmt.Errorf("validator attestation for milestone %s has no validation hash", release.MilestoneID)
+	}
+
+	// The investor who funded this escrow must have approved the milestone
+	investorResponse := ctx.GetStub().InvokeChaincode(
+		"investororg",
+		[][]byte{[]byte("GetMilestoneVerification"), []byte(escrow.AgreementID), []byte(release.MilestoneID)},
+		"common-channel",
+	)
+	if investorResponse.Status != 200 {
+		return nil, fmt.Errorf("milestone %s has no investor approval for agreement %s: %s", release.MilestoneID, escrow.AgreementID, investorResponse.Message)
+	}
+
+	var verification struct {
+		VerificationID string `json:"verificationId"`
+		MilestoneID    string `json:"milestoneId"`
+		AgreementID    string `json:"agreementId"`
+		InvestorID     string `json:"investorId"`
+		Approved       bool   `json:"approved"`
+	}
+	if err := json.Unmarshal(investorResponse.Payload, &verification); err != nil {
+		return nil, fmt.Errorf("failed to parse investor verification: %v", err)
+	}
+	if verification.InvestorID != escrow.InvestorID {
+		return nil, fmt.Errorf("milestone %s was verified by %s, not escrow investor %s", release.MilestoneID, verification.InvestorID, escrow.InvestorID)
+	}
+	if !verification.Approved {
+		return nil, fmt.Errorf("investor %s did not approve milestone %s", escrow.InvestorID, release.MilestoneID)
+	}
+
+	return &ReleaseEvidence{
+		ValidatorVerificationID:  attestation.VerificationID,
+		ValidatorValidationHash:  attestation.ValidationHash,
+		ValidatorAttestationHash: generateHash(string(response.Payload)),
+		MilestoneReportHash:      attestation.MilestoneReportHash,
+		InvestorVerificationID:   verification.VerificationID,
+		InvestorID:               verification.InvestorID,
+		InvestorVerificationHash: generateHash(string(investorResponse.Payload)),
+		CheckedAt:                now,
+	}, nil
+}
+
+// fundsReleasedEventPayload builds the FundsReleased event for a completed release
+func fundsReleasedEventPayload(release FundRelease, now string) map[string]interface{} {
+	return map[string]interface{}{
+		"releaseId":     release.ReleaseID,
+		"escrowId":      release.EscrowID,
+		"agreementId":   release.AgreementID,
+		"campaignId":    release.CampaignID,
+		"milestoneId":   release.MilestoneID,
+		"startupId":     release.StartupID,
+		"amount":        release.Amount,
+		"feeAmount":     release.FeeAmount,
+		"netAmount":     release.NetAmount,
+		"triggerReason": release.TriggerReason,
+		"channel":       "common-channel",
+		"action":        "FUNDS_RELEASED",
+		"timestamp":     now,
+	}
+}
+
+// requestReleaseApproval stores a release as PENDING_APPROVAL; no funds move until approved,
+// but the amount is reserved on the escrow so other releases cannot spend it meanwhile
+func requestReleaseApproval(ctx contractapi.TransactionContextInterface, release *FundRelease, escrow *FundEscrow, policy *ReleaseApprovalPolicy) (string, error) {
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	if release.Amount > availableEscrowAmount(*escrow) {
+		return "", fmt.Errorf("insufficient funds in escrow. Available: %f, Requested: %f", availableEscrowAmount(*escrow), release.Amount)
+	}
+
+	release.Status = "PENDING_APPROVAL"
+	release.RequiredSignatures = map[string]int{}
+	for mspID, count := range policy.RequiredSignatures {
+		if count > 0 {
+			release.RequiredSignatures[mspID] = count
+		}
+	}
+	release.Approvals = []ReleaseApproval{}
+	release.ExpiresAt = txTime.Add(time.Duration(policy.ApprovalWindowHours) * time.Hour).Format(time.RFC3339)
+
+	releaseJSON, err := json.Marshal(release)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(release.ReleaseID, releaseJSON)
+	if err != nil {
+		return "", err
+	}
+
+	escrow.ReservedAmount += release.Amount
+	escrow.PendingReleaseIDs = append(escrow.PendingReleaseIDs, release.ReleaseID)
+	escrow.UpdatedAt = release.RequestedAt
+	escrowJSON, err := json.Marshal(escrow)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(escrow.EscrowID, escrowJSON)
+	if err != nil {
+		return "", err
+	}
+
+	eventPayload := map[string]interface{}{
+		"releaseId":          release.ReleaseID,
+		"escrowId":           release.EscrowID,
+		"campaignId":         release.CampaignID,
+		"milestoneId":        release.MilestoneID,
+		"amount":             release.Amount,
+		"thresholdAmount":    policy.ThresholdAmount,
+		"requiredSignatures": release.RequiredSignatures,
+		"expiresAt":          release.ExpiresAt,
+		"channel":            "common-channel",
+		"action":             "FUND_RELEASE_PENDING_APPROVAL",
+		"timestamp":          release.RequestedAt,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("FundReleasePendingApproval", eventJSON)
+
+	response := map[string]interface{}{
+		"message":            "Release exceeds approval threshold. Awaiting approver signatures.",
+		"releaseId":          release.ReleaseID,
+		"milestoneId":        release.MilestoneID,
+		"amount":             release.Amount,
+		"requiredSignatures": release.RequiredSignatures,
+		"expiresAt":          release.ExpiresAt,
+		"status":             release.Status,
+		"nextStep":           "Approvers call ApproveFundRelease before the release expires",
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// availableEscrowAmount is what an escrow holds beyond the releases pending approval
+func availableEscrowAmount(escrow FundEscrow) float64 {
+	return escrow.HeldAmount - escrow.ReservedAmount
+}
+
+// unreserveEscrow drops a pending release's reservation from its escrow (the caller stores the escrow)
+func unreserveEscrow(escrow *FundEscrow, release FundRelease) {
+	pending := []string{}
+	for _, releaseID := range escrow.PendingReleaseIDs {
+		if releaseID != release.ReleaseID {
+			pending = append(pending, releaseID)
+		}
+	}
+	if len(pending) == len(escrow.PendingReleaseIDs) {
+		return
+	}
+	escrow.PendingReleaseIDs = pending
+	escrow.ReservedAmount -= release.Amount
+	if escrow.ReservedAmount < journalTolerance {
+		escrow.ReservedAmount = 0
+	}
+}
+
+// remainingSignatures returns the approvals still needed per organization
+func remainingSignatures(release FundRelease) map[string]int {
+	signed := map[string]int{}
+	for _, approval := range release.Approvals {
+		signed[approval.MSPID]++
+	}
+
+	remaining := map[string]int{}
+	for mspID, count := range release.RequiredSignatures {
+		if signed[mspID] < count {
+			remaining[mspID] = count - signed[mspID]
+		}
+	}
+	return remaining
+}
+
+// getReleaseApprovalPolicy returns the stored policy, or a disabled policy if none has been set
+func getReleaseApprovalPolicy(ctx contractapi.TransactionContextInterface) (*ReleaseApprovalPolicy, error) {
+	policyJSON, err := ctx.GetStub().GetState("RELEASE_APPROVAL_POLICY")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read release approval policy: %v", err)
+	}
+
+	policy := ReleaseApprovalPolicy{
+		PolicyID:           "RELEASE_APPROVAL_POLICY",
+		RequiredSignatures: map[string]int{},
+		Approvers:          []ReleaseApprover{},
+	}
+	if policyJSON != nil {
+		if err := json.Unmarshal(policyJSON, &policy); err != nil {
+			return nil, err
+		}
+	}
+	return &policy, nil
+}
+
+// getPendingReleases returns releases in PENDING_APPROVAL, oldest request first
+func getPendingReleases(ctx contractapi.TransactionContextInterface) ([]FundRelease, error) {
+	records, err := getQueryResults(ctx, map[string]interface{}{
+		"releaseId": map[string]bool{"$exists": true},
+		"escrowId":  map[string]bool{"$exists": true},
+		"status":    "PENDING_APPROVAL",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	releases := []FundRelease{}
+	for _, record := range records {
+		var release FundRelease
+		if json.Unmarshal(record.Value, &release) != nil || record.Key != release.ReleaseID {
+			continue
+		}
+		releases = append(releases, release)
+	}
+	sort.Slice(releases, func(a, b int) bool {
+		if releases[a].RequestedAt != releases[b].RequestedAt {
+			return releases[a].RequestedAt < releases[b].RequestedAt
+		}
+		return releases[a].ReleaseID < releases[b].ReleaseID
+	})
+
+	return releases, nil
+}
+
+// settlementRow is one parsed line of a processor settlement file
+type settlementRow struct {
+	Direction          string
+	ProcessorReference string
+	MatchReference     string
+	Amount             float64
+	Currency           string
+	ValueDate          string
+	ProcessorStatus    string
+}
+
+// paymentID returns the ledger key of a payment; processor references are unique per payment
+func paymentID(processorReference string) string {
+	return "PAYMENT_" + processorReference
+}
+
+// recordPaymentTransaction records a single payment and emits its event
+func recordPaymentTransaction(
+	ctx contractapi.TransactionContextInterface,
+	direction string,
+	processorReference string,
+	matchReference string,
+	amount float64,
+	currency string,
+	valueDate string,
+	processorStatus string,
+) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	payment, err := recordPayment(ctx, direction, processorReference, matchReference, amount, currency, valueDate, processorStatus, "", map[string]string{}, now)
+	if err != nil {
+		return "", err
+	}
+
+	eventPayload := map[string]interface{}{
+		"paymentId":            payment.PaymentID,
+		"direction":            payment.Direction,
+		"matchReference":       payment.MatchReference,
+		"campaignId":           payment.CampaignID,
+		"amount":               payment.Amount,
+		"currency":             payment.Currency,
+		"reconciliationStatus": payment.ReconciliationStatus,
+		"mismatches":           payment.Mismatches,
+		"channel":              "common-channel",
+		"action":               "PAYMENT_RECORDED",
+		"timestamp":            now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("PaymentRecorded", eventJSON)
+
+	response := map[string]interface{}{
+		"message":              "Payment recorded",
+		"paymentId":            payment.PaymentID,
+		"matchReference":       payment.MatchReference,
+		"expectedAmount":       payment.ExpectedAmount,
+		"reconciliationStatus": payment.ReconciliationStatus,
+		"mismatches":           payment.Mismatches,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// recordPayment validates, matches and stores a payment, updating it if the reference is already recorded
+// claimed maps direction:matchReference to the payment that paid it earlier in the same transaction
+func recordPayment(
+	ctx contractapi.TransactionContextInterface,
+	direction string,
+	processorReference string,
+	matchReference string,
+	amount float64,
+	currency string,
+	valueDate string,
+	processorStatus string,
+	batchID string,
+	claimed map[string]string,
+	now string,
+) (*PaymentRecord, error) {
+	if direction != "INBOUND" && direction != "OUTBOUND" {
+		return nil, fmt.Errorf("invalid direction: %s. Must be INBOUND or OUTBOUND", direction)
+	}
+	validStatuses := map[string]bool{"PENDING": true, "SETTLED": true, "FAILED": true, "RETURNED": true}
+	if !validStatuses[processorStatus] {
+		return nil, fmt.Errorf("invalid processor status: %s. Must be PENDING, SETTLED, FAILED or RETURNED", processorStatus)
+	}
+	if processorReference == "" || matchReference == "" {
+		return nil, fmt.Errorf("processor reference and match reference are required")
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+	if _, err := parseDate(valueDate); err != nil {
+		return nil, fmt.Errorf("invalid value date %s: %v", valueDate, err)
+	}
+
+	id := paymentID(processorReference)
+	payment := PaymentRecord{RecordedAt: now}
+
+	existingJSON, err := ctx.GetStub().GetState(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payment: %v", err)
+	}
+	if existingJSON != nil {
+		err = json.Unmarshal(existingJSON, &payment)
+		if err != nil {
+			return nil, err
+		}
+		if payment.Direction != direction || payment.MatchReference != matchReference {
+			return nil, fmt.Errorf("processor reference %s is already recorded as %s payment for %s", processorReference, payment.Direction, payment.MatchReference)
+		}
+	}
+
+	payment.PaymentID = id
+	payment.ProcessorReference = processorReference
+	payment.Direction = direction
+	payment.MatchReference = matchReference
+	payment.Amount = amount
+	payment.Currency = currency
+	payment.ValueDate = valueDate
+	payment.ProcessorStatus = processorStatus
+	if batchID != "" {
+		payment.BatchID = batchID
+	}
+	payment.UpdatedAt = now
+
+	err = matchPayment(ctx, &payment, claimed)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentJSON, err := json.Marshal(payment)
+	if err != nil {
+		return nil, err
+	}
+	err = ctx.GetStub().PutState(id, paymentJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+// matchPayment compares a payment with the commitment or release it refers to and sets its reconciliation status
+func matchPayment(ctx contractapi.TransactionContextInterface, payment *PaymentRecord, claimed map[string]string) error {
+	payment.Mismatches = []string{}
+	payment.ExpectedAmount = 0
+	payment.ExpectedCurrency = ""
+
+	found := false
+	if payment.Direction == "INBOUND" {
+		// Commitments live on InvestorOrg's ledger
+		response := ctx.GetStub().InvokeChaincode(
+			"investororg",
+			[][]byte{[]byte("GetFundingCommitment"), []byte(payment.MatchReference)},
+			"investor-platform-channel",
+		)
+		if response.Status == 200 {
+			var commitment struct {
+				CampaignID string  `json:"campaignId"`
+				InvestorID string  `json:"investorId"`
+				Amount     float64 `json:"amount"`
+				Currency   string  `json:"currency"`
+			}
+			if err := json.Unmarshal(response.Payload, &commitment); err != nil {
+				return fmt.Errorf("failed to parse commitment %s: %v", payment.MatchReference, err)
+			}
+			found = true
+			payment.CampaignID = commitment.CampaignID
+			payment.CounterpartyID = commitment.InvestorID
+			payment.ExpectedAmount = commitment.Amount
+			payment.ExpectedCurrency = commitment.Currency
+		} else {
+			payment.Mismatches = append(payment.Mismatches, fmt.Sprintf("commitment %s not found", payment.MatchReference))
+		}
+	} else {
+		releaseJSON, err := ctx.GetStub().GetState(payment.MatchReference)
+		if err != nil {
+			return fmt.Errorf("failed to read release: %v", err)
+		}
+		var release FundRelease
+		if releaseJSON != nil && json.Unmarshal(releaseJSON, &release) == nil && release.ReleaseID == payment.MatchReference {
+			found = true
+			payment.CampaignID = release.CampaignID
+			payment.CounterpartyID = release.StartupID
+			payment.ExpectedAmount = releaseNetAmount(release)
+			payment.ExpectedCurrency = release.Currency
+			if release.Status != "RELEASED" {
+				payment.Mismatches = append(payment.Mismatches, fmt.Sprintf("release %s is %s", release.ReleaseID, release.Status))
+			}
+		} else {
+			payment.Mismatches = append(payment.Mismatches, fmt.Sprintf("release %s not found", payment.MatchReference))
+		}
+	}
+
+	if found {
+		if math.Abs(payment.Amount-payment.ExpectedAmount) > journalTolerance {
+			payment.Mismatches = append(payment.Mismatches, fmt.Sprintf("amount %.2f does not match expected %.2f", payment.Amount, payment.ExpectedAmount))
+		}
+		if payment.Currency != payment.ExpectedCurrency {
+			payment.Mismatches = append(payment.Mismatches, fmt.Sprintf("currency %s does not match expected %s", payment.Currency, payment.ExpectedCurrency))
+		}
+	}
+	if payment.ProcessorStatus == "FAILED" || payment.ProcessorStatus == "RETURNED" {
+		payment.Mismatches = append(payment.Mismatches, "processor reported payment "+payment.ProcessorStatus)
+	}
+
+	// A commitment or release should be paid once; flag any other live payment against it
+	if payment.ProcessorStatus != "FAILED" && payment.ProcessorStatus != "RETURNED" {
+		claimKey := payment.Direction + ":" + payment.MatchReference
+		if other, ok := claimed[claimKey]; ok && other != payment.PaymentID {
+			payment.Mismatches = append(payment.Mismatches, "duplicate of payment "+other)
+		} else {
+			others, err := getPayments(ctx, map[string]interface{}{
+				"direction":      payment.Direction,
+				"matchReference": payment.MatchReference,
+			})
+			if err != nil {
+				return err
+			}
+			for _, other := range others {
+				if other.PaymentID == payment.PaymentID || other.ProcessorStatus == "FAILED" || other.ProcessorStatus == "RETURNED" {
+					continue
+				}
+				payment.Mismatches = append(payment.Mismatches, "duplicate of payment "+other.PaymentID)
+				break
+			}
+			claimed[claimKey] = payment.PaymentID
+		}
+	}
+
+	switch {
+	case !found:
+		payment.ReconciliationStatus = "UNMATCHED"
+	case len(payment.Mismatches) > 0:
+		payment.ReconciliationStatus = "MISMATCHED"
+	case payment.ProcessorStatus == "PENDING":
+		payment.ReconciliationStatus = "PENDING_SETTLEMENT"
+	default:
+		payment.ReconciliationStatus = "MATCHED"
+	}
+
+	return nil
+}
+
+// getPayments returns payments matching the extra selector fields, oldest value date first
+func getPayments(ctx contractapi.TransactionContextInterface, filter map[string]interface{}) ([]PaymentRecord, error) {
+	selector := map[string]interface{}{
+		"paymentId":          map[string]bool{"$exists": true},
+		"processorReference": map[string]bool{"$exists": true},
+	}
+	for field, value := range filter {
+		selector[field] = value
+	}
+
+	records, err := getQueryResults(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	payments := []PaymentRecord{}
+	for _, record := range records {
+		var payment PaymentRecord
+		if json.Unmarshal(record.Value, &payment) != nil {
+			continue
+		}
+		payments = append(payments, payment)
+	}
+	sort.Slice(payments, func(a, b int) bool {
+		if payments[a].ValueDate != payments[b].ValueDate {
+			return payments[a].ValueDate < payments[b].ValueDate
+		}
+		return payments[a].PaymentID < payments[b].PaymentID
+	})
+
+	return payments, nil
+}
+
+// parseSettlementFile reads a processor settlement CSV; columns are located by header name
+func parseSettlementFile(csvContent string) ([]settlementRow, error) {
+	reader := csv.NewReader(strings.NewReader(csvContent))
+	reader.TrimLeadingSpace = true
+	lines, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse settlement file: %v", err)
+	}
+	if len(lines) < 2 {
+		return nil, fmt.Errorf("settlement file has no payment rows")
+	}
+
+	columns := map[string]int{}
+	for index, name := range lines[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = index
+	}
+	required := []string{"direction", "processorreference", "matchreference", "amount", "currency", "valuedate", "status"}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("settlement file is missing column %s", name)
+		}
+	}
+
+	rows := []settlementRow{}
+	for index, line := range lines[1:] {
+		field := func(name string) string {
+			return strings.TrimSpace(line[columns[name]])
+		}
+		amount, err := strconv.ParseFloat(field("amount"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid amount %s", index+2, field("amount"))
+		}
+		rows = append(rows, settlementRow{
+			Direction:          strings.ToUpper(field("direction")),
+			ProcessorReference: field("processorreference"),
+			MatchReference:     field("matchreference"),
+			Amount:             amount,
+			Currency:           field("currency"),
+			ValueDate:          field("valuedate"),
+			ProcessorStatus:    strings.ToUpper(field("status")),
+		})
+	}
+
+	return rows, nil
+}
+
+// getFeeSchedule returns the stored fee schedule, or a zero schedule if none has been set
+func getFeeSchedule(ctx contractapi.TransactionContextInterface) (*FeeSchedule, error) {
+	scheduleJSON, err := ctx.GetStub().GetState("FEE_SCHEDULE")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fee schedule: %v", err)
+	}
+
+	schedule := FeeSchedule{ScheduleID: "FEE_SCHEDULE", CategorySurcharges: map[string]float64{}}
+	if scheduleJSON != nil {
+		if err := json.Unmarshal(scheduleJSON, &schedule); err != nil {
+			return nil, err
+		}
+	}
+	return &schedule, nil
+}
+
+// calculateReleaseFee builds the fee entry for releasing amount from an escrow
+// The total fee never exceeds the released amount
+func calculateReleaseFee(ctx contractapi.TransactionContextInterface, releaseID string, escrow FundEscrow, amount float64) (*FeeEntry, error) {
+	schedule, err := getFeeSchedule(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var campaign PublishedCampaign
+	campaignJSON, err := ctx.GetStub().GetState(escrow.CampaignID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON != nil {
+		if err := json.Unmarshal(campaignJSON, &campaign); err != nil {
+			return nil, err
+		}
+	}
+
+	entry := &FeeEntry{
+		FeeEntryID:            fmt.Sprintf("FEE_%s", releaseID),
+		ReleaseID:             releaseID,
+		EscrowID:              escrow.EscrowID,
+		CampaignID:            escrow.CampaignID,
+		StartupID:             escrow.StartupID,
+		InvestorID:            escrow.InvestorID,
+		Category:              campaign.Category,
+		Currency:              escrow.Currency,
+		GrossAmount:           amount,
+		PercentageRate:        schedule.PercentageFee,
+		PercentageAmount:      amount * schedule.PercentageFee / 100,
+		FlatFee:               schedule.FlatFee,
+		CategorySurchargeRate: schedule.CategorySurcharges[campaign.Category],
+	}
+	entry.CategorySurchargeAmount = amount * entry.CategorySurchargeRate / 100
+	if campaign.IsPromoted {
+		entry.PromotionSurchargeRate = schedule.PromotionSurcharge
+		entry.PromotionSurchargeAmount = amount * schedule.PromotionSurcharge / 100
+	}
+
+	entry.TotalFee = entry.PercentageAmount + entry.FlatFee + entry.CategorySurchargeAmount + entry.PromotionSurchargeAmount
+	if entry.TotalFee > amount {
+		// Scale every component down pro rata so the recorded components still sum to the capped total
+		scale := amount / entry.TotalFee
+		entry.PercentageAmount *= scale
+		entry.FlatFee *= scale
+		entry.CategorySurchargeAmount *= scale
+		entry.PromotionSurchargeAmount *= scale
+		entry.TotalFee = amount
+	}
+	entry.NetAmount = amount - entry.TotalFee
+
+	return entry, nil
+}
+
+// isAllOrNothing reports whether a campaign uses the all-or-nothing funding model
+// Campaigns published before funding models existed are keep-it-all
+func isAllOrNothing(campaign PublishedCampaign) bool {
+	return campaign.FundingModel == "ALL_OR_NOTHING"
+}
+
+// normalizeFundingModel validates a funding model; empty means KEEP_IT_ALL
+func normalizeFundingModel(fundingModel string) (string, error) {
+	switch fundingModel {
+	case "":
+		return "KEEP_IT_ALL", nil
+	case "ALL_OR_NOTHING", "KEEP_IT_ALL":
+		return fundingModel, nil
+	}
+	return "", fmt.Errorf("invalid funding model: %s. Must be ALL_OR_NOTHING or KEEP_IT_ALL", fundingModel)
+}
+
+// checkFundingModelAllowsRelease blocks releases from all-or-nothing campaigns until
+// the funding window has closed with escrowed funds at or above the goal
+func checkFundingModelAllowsRelease(ctx contractapi.TransactionContextInterface, campaignID string) error {
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return nil
+	}
+
+	var campaign PublishedCampaign
+	if err := json.Unmarshal(campaignJSON, &campaign); err != nil {
+		return err
+	}
+	if !isAllOrNothing(campaign) {
+		return nil
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return err
+	}
+	_, closesAt := campaignWindow(campaign.OpenDate, campaign.CloseDate, campaign.DurationDays)
+	if campaign.Status != "CLOSED" && (closesAt.IsZero() || txTime.Before(closesAt)) {
+		return fmt.Errorf("all-or-nothing campaign %s has not closed; funds stay in escrow until the close date", campaignID)
+	}
+
+	raised, _, err := campaignEscrowTotals(ctx, campaign)
+	if err != nil {
+		return err
+	}
+	if raised < campaign.GoalAmount {
+		return fmt.Errorf("all-or-nothing campaign %s raised %.2f of %.2f; funds will be refunded", campaignID, raised, campaign.GoalAmount)
+	}
+
+	return nil
+}
+
+// checkCampaignAllowsRefund blocks refunds of escrows whose campaign closed SUCCESSFUL or PARTIALLY_FUNDED
+// Investors may withdraw while the campaign is open; failed and cancelled campaigns stay refundable
+// for escrows that were frozen when the campaign closed
+func checkCampaignAllowsRefund(ctx contractapi.TransactionContextInterface, campaignID string) error {
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return nil
+	}
+
+	var campaign PublishedCampaign
+	if err := json.Unmarshal(campaignJSON, &campaign); err != nil {
+		return err
+	}
+	if campaign.Status == "CLOSED" && (campaign.FinalStatus == "SUCCESSFUL" || campaign.FinalStatus == "PARTIALLY_FUNDED") {
+		return fmt.Errorf("campaign %s closed %s; its escrows cannot be refunded", campaignID, campaign.FinalStatus)
+	}
+	return nil
+}
+
+// campaignWindow returns when a campaign opens and closes (zero when unknown)
+// A date-only CloseDate closes at the end of that day; without one, OpenDate + DurationDays is used
+func campaignWindow(openDate string, closeDate string, durationDays int) (time.Time, time.Time) {
+	var opensAt, closesAt time.Time
+	if t, err := parseDate(openDate); err == nil {
+		opensAt = t
+	}
+	if t, err := time.Parse(time.RFC3339, closeDate); err == nil {
+		closesAt = t
+	} else if t, err := time.Parse("2006-01-02", closeDate); err == nil {
+		closesAt = t.AddDate(0, 0, 1)
+	} else if !opensAt.IsZero() && durationDays > 0 {
+		closesAt = opensAt.AddDate(0, 0, durationDays)
+	}
+	return opensAt, closesAt
+}
+
+// getRefundsForEscrows returns escrow refunds grouped by escrow ID
+func getRefundsForEscrows(ctx contractapi.TransactionContextInterface, escrowIDs []string) (map[string][]EscrowRefund, error) {
+	refunds := map[string][]EscrowRefund{}
+	for _, escrowID := range escrowIDs {
+		refunds[escrowID] = []EscrowRefund{}
+	}
+	if len(escrowIDs) == 0 {
+		return refunds, nil
+	}
+
+	records, err := getQueryResults(ctx, map[string]interface{}{
+		"refundId": map[string]bool{"$exists": true},
+		"escrowId": map[string]interface{}{"$in": escrowIDs},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		var refund EscrowRefund
+		if json.Unmarshal(record.Value, &refund) != nil {
+			continue
+		}
+		refunds[refund.EscrowID] = append(refunds[refund.EscrowID], refund)
+	}
+	return refunds, nil
+}
+
+// refundEscrow moves everything still held in an escrow back to the investor and stores the refund record
+// It emits no event so callers can report single and bulk refunds in one event
+func refundEscrow(ctx contractapi.TransactionContextInterface, escrow *FundEscrow, refundID string, reason string, now string) (*EscrowRefund, error) {
+	if err := checkEscrowNotFrozen(*escrow); err != nil {
+		return nil, err
+	}
+	if escrow.Status == "REFUNDED" || escrow.Status == "FULLY_RELEASED" {
+		return nil, fmt.Errorf("escrow %s cannot be refunded, current status: %s", escrow.EscrowID, escrow.Status)
+	}
+	if escrow.HeldAmount <= 0 {
+		return nil, fmt.Errorf("escrow %s holds no funds to refund", escrow.EscrowID)
+	}
+
+	existing, err := ctx.GetStub().GetState(refundID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read refund: %v", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("refund %s already exists", refundID)
+	}
+
+	refund := EscrowRefund{
+		RefundID:    refundID,
+		EscrowID:    escrow.EscrowID,
+		AgreementID: escrow.AgreementID,
+		CampaignID:  escrow.CampaignID,
+		InvestorID:  escrow.InvestorID,
+		StartupID:   escrow.StartupID,
+		Amount:      escrow.HeldAmount,
+		Currency:    escrow.Currency,
+		Reason:      reason,
+		Status:      "REFUNDED",
+		RefundedAt:  now,
+	}
+
+	refundJSON, err := json.Marshal(refund)
+	if err != nil {
+		return nil, err
+	}
+	err = ctx.GetStub().PutState(refundID, refundJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	// Held funds leave escrow straight back to the investor wallet
+	err = postJournalEntry(ctx, "ESCROW_REFUNDED", refundID, escrow.CampaignID, escrow.Currency, []JournalLine{
+		{Account: investorWalletAccount(escrow.InvestorID), Debit: escrow.HeldAmount},
+		{Account: escrowAccount(escrow.EscrowID), Credit: escrow.HeldAmount},
+	}, reason, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Releases pending approval can no longer be paid from a refunded escrow
+	for _, releaseID := range escrow.PendingReleaseIDs {
+		releaseJSON, err := ctx.GetStub().GetState(releaseID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read release: %v", err)
+		}
+		var release FundRelease
+		if releaseJSON == nil || json.Unmarshal(releaseJSON, &release) != nil || release.Status != "PENDING_APPROVAL" {
+			continue
+		}
+		release.Status = "CANCELLED"
+		releaseJSON, err = json.Marshal(release)
+		if err != nil {
+			return nil, err
+		}
+		err = ctx.GetStub().PutState(releaseID, releaseJSON)
+		if err != nil {
+			return nil, err
+		}
+	}
+	escrow.PendingReleaseIDs = []string{}
+	escrow.ReservedAmount = 0
+
+	escrow.RefundedAmount += escrow.HeldAmount
+	escrow.HeldAmount = 0
+	escrow.Status = "REFUNDED"
+	escrow.UpdatedAt = now
+
+	escrowJSON, err := json.Marshal(escrow)
+	if err != nil {
+		return nil, err
+	}
+	err = ctx.GetStub().PutState(escrow.EscrowID, escrowJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return &refund, nil
+}
+
+// revenuePeriodKey is the key recording which report covered a campaign's revenue period
+func revenuePeriodKey(campaignID string, currency string, periodStart string, periodEnd string) string {
+	return fmt.Sprintf("REVENUE_PERIOD_%s_%s_%s_%s", campaignID, currency, periodStart, periodEnd)
+}
+
+// requireInvestorIdentity rejects callers whose certificate does not carry the given investorId attribute
+func requireInvestorIdentity(ctx contractapi.TransactionContextInterface, investorID string) error {
+	callerInvestorID, found, err := ctx.GetClientIdentity().GetAttributeValue("investorId")
+	if err != nil {
+		return fmt.Errorf("failed to read client investorId attribute: %v", err)
+	}
+	if !found {
+		return fmt.Errorf("caller identity has no investorId attribute")
+	}
+	if callerInvestorID != investorID {
+		return fmt.Errorf("caller is investor %s, not %s", callerInvestorID, investorID)
+	}
+	return nil
+}
+
+// getCampaignBlacklist reads a campaign's blacklist status published by ValidatorOrg on common-channel
+func getCampaignBlacklist(ctx contractapi.TransactionContextInterface, campaignID string) (*CampaignBlacklist, error) {
+	response := ctx.GetStub().InvokeChaincode(
+		"validatororg",
+		[][]byte{[]byte("IsCampaignBlacklisted"), []byte(campaignID)},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return nil, fmt.Errorf("failed to check blacklist: %s", response.Message)
+	}
+
+	var blacklist CampaignBlacklist
+	if err := json.Unmarshal(response.Payload, &blacklist); err != nil {
+		return nil, fmt.Errorf("failed to parse blacklist status: %v", err)
+	}
+	return &blacklist, nil
+}
+
+// checkCampaignNotBlacklisted blocks releases and vesting claims for blacklisted campaigns
+func checkCampaignNotBlacklisted(ctx contractapi.TransactionContextInterface, campaignID string) error {
+	blacklist, err := getCampaignBlacklist(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+	if blacklist.Blacklisted {
+		return fmt.Errorf("campaign %s is blacklisted: %s", campaignID, blacklist.Reason)
+	}
+	return nil
+}
+
+// cancelReleaseVesting returns the unclaimed balance of a vesting release to the investor
+// and returns the amount; the startup can claim nothing more from the release
+func cancelReleaseVesting(ctx contractapi.TransactionContextInterface, releaseID string, investorID string, now string) (float64, error) {
+	releaseJSON, err := ctx.GetStub().GetState(releaseID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read release: %v", err)
+	}
+	if releaseJSON == nil {
+		return 0, fmt.Errorf("release %s does not exist", releaseID)
+	}
+
+	var release FundRelease
+	if err := json.Unmarshal(releaseJSON, &release); err != nil {
+		return 0, err
+	}
+	unclaimed := release.NetAmount - release.ClaimedAmount
+	if unclaimed <= journalTolerance || release.CancelledAmount > 0 {
+		return 0, nil
+	}
+
+	err = postJournalEntry(ctx, "VESTING_CANCELLED", releaseID, release.CampaignID, release.Currency, []JournalLine{
+		{Account: investorWalletAccount(investorID), Debit: unclaimed},
+		{Account: vestingAccount(releaseID), Credit: unclaimed},
+	}, fmt.Sprintf("Unclaimed vesting funds of release %s returned on clawback", releaseID), now)
+	if err != nil {
+		return 0, err
+	}
+
+	release.CancelledAmount = unclaimed
+	updatedJSON, err := json.Marshal(release)
+	if err != nil {
+		return 0, err
+	}
+	if err := ctx.GetStub().PutState(releaseID, updatedJSON); err != nil {
+		return 0, err
+	}
+	return unclaimed, nil
+}
+
+// checkEscrowNotFrozen blocks releases and refunds from frozen escrows
+func checkEscrowNotFrozen(escrow FundEscrow) error {
+	if escrow.Status == "FROZEN" {
+		return fmt.Errorf("escrow %s is frozen by hold %s", escrow.EscrowID, escrow.ActiveHoldID)
+	}
+	return nil
+}
+
+// freezeEscrow stores an ACTIVE hold and marks the escrow FROZEN
+// It emits no event so callers can report single and campaign-wide freezes in one event
+func freezeEscrow(ctx contractapi.TransactionContextInterface, escrow *FundEscrow, holdID string, reason string, openedBy string, mspID string, now string) (*EscrowHold, error) {
+	if escrow.Status == "FROZEN" {
+		return nil, fmt.Errorf("escrow %s is already frozen by hold %s", escrow.EscrowID, escrow.ActiveHoldID)
+	}
+	if escrow.Status == "REFUNDED" || escrow.Status == "FULLY_RELEASED" {
+		return nil, fmt.Errorf("escrow %s cannot be frozen, current status: %s", escrow.EscrowID, escrow.Status)
+	}
+	if reason == "" || openedBy == "" {
+		return nil, fmt.Errorf("a hold needs a reason and an opening party")
+	}
+
+	existing, err := ctx.GetStub().GetState(holdID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hold: %v", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("hold %s already exists", holdID)
+	}
+
+	hold := EscrowHold{
+		HoldID:      holdID,
+		EscrowID:    escrow.EscrowID,
+		CampaignID:  escrow.CampaignID,
+		InvestorID:  escrow.InvestorID,
+		Reason:      reason,
+		OpenedBy:    openedBy,
+		OpenedByMSP: mspID,
+		Status:      "ACTIVE",
+		OpenedAt:    now,
+	}
+
+	holdJSON, err := json.Marshal(hold)
+	if err != nil {
+		return nil, err
+	}
+	err = ctx.GetStub().PutState(holdID, holdJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	escrow.FrozenFromStatus = escrow.Status
+	escrow.Status = "FROZEN"
+	escrow.ActiveHoldID = holdID
+	escrow.UpdatedAt = now
+
+	escrowJSON, err := json.Marshal(escrow)
+	if err != nil {
+		return nil, err
+	}
+	err = ctx.GetStub().PutState(escrow.EscrowID, escrowJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hold, nil
+}
+
+// resolveEscrowHolds stores the resolution record, resolves each escrow's active hold and restores its status
+func resolveEscrowHolds(
+	ctx contractapi.TransactionContextInterface,
+	resolutionID string,
+	campaignID string,
+	escrows []FundEscrow,
+	resolution string,
+	resolvedBy string,
+) (string, error) {
+	if err := requireMSP(ctx, "PlatformOrgMSP"); err != nil {
+		return "", err
+	}
+	if resolution == "" || resolvedBy == "" {
+		return "", fmt.Errorf("unfreezing needs a resolution and the resolving party")
+	}
+
+	existing, err := ctx.GetStub().GetState(resolutionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read resolution: %v", err)
+	}
+	if existing != nil {
+		return "", fmt.Errorf("resolution %s already exists", resolutionID)
+	}
+
+	mspID, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		return "", fmt.Errorf("failed to read client MSP ID: %v", err)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	record := HoldResolution{
+		ResolutionID:  resolutionID,
+		CampaignID:    campaignID,
+		HoldIDs:       []string{},
+		EscrowIDs:     []string{},
+		Resolution:    resolution,
+		ResolvedBy:    resolvedBy,
+		ResolvedByMSP: mspID,
+		ResolvedAt:    now,
+	}
+
+	holds := []EscrowHold{}
+	for idx := range escrows {
+		escrow := &escrows[idx]
+		hold, err := liftEscrowHold(ctx, escrow, resolutionID, now)
+		if err != nil {
+			return "", err
+		}
+
+		record.HoldIDs = append(record.HoldIDs, hold.HoldID)
+		record.EscrowIDs = append(record.EscrowIDs, escrow.EscrowID)
+		holds = append(holds, *hold)
+	}
+
+	recordJSON, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(resolutionID, recordJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit one event for all lifted holds (Fabric keeps only the last event per transaction)
+	eventPayload := map[string]interface{}{
+		"resolutionId": resolutionID,
+		"campaignId":   campaignID,
+		"resolution":   resolution,
+		"resolvedBy":   resolvedBy,
+		"holds":        holdEventEntries(holds),
+		"channel":      "common-channel",
+		"action":       "ESCROW_UNFROZEN",
+		"timestamp":    now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("EscrowUnfrozen", eventJSON)
+
+	response := map[string]interface{}{
+		"message":       fmt.Sprintf("Unfroze %d escrows", len(holds)),
+		"resolutionId":  resolutionID,
+		"campaignId":    campaignID,
+		"unfrozenCount": len(holds),
+		"escrowIds":     record.EscrowIDs,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// liftEscrowHold resolves an escrow's active hold and restores the escrow's status from before the freeze
+func liftEscrowHold(ctx contractapi.TransactionContextInterface, escrow *FundEscrow, resolutionID string, now string) (*EscrowHold, error) {
+	if escrow.Status != "FROZEN" {
+		return nil, fmt.Errorf("escrow %s is not frozen", escrow.EscrowID)
+	}
+
+	holdJSON, err := ctx.GetStub().GetState(escrow.ActiveHoldID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hold: %v", err)
+	}
+	if holdJSON == nil {
+		return nil, fmt.Errorf("hold %s does not exist", escrow.ActiveHoldID)
+	}
+
+	var hold EscrowHold
+	err = json.Unmarshal(holdJSON, &hold)
+	if err != nil {
+		return nil, err
+	}
+
+	hold.Status = "RESOLVED"
+	hold.ResolutionID = resolutionID
+	hold.ResolvedAt = now
+	updatedHoldJSON, err := json.Marshal(hold)
+	if err != nil {
+		return nil, err
+	}
+	err = ctx.GetStub().PutState(hold.HoldID, updatedHoldJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	escrow.Status = escrow.FrozenFromStatus
+	if escrow.Status == "" {
+		escrow.Status = "ACTIVE"
+	}
+	escrow.FrozenFromStatus = ""
+	escrow.ActiveHoldID = ""
+	escrow.UpdatedAt = now
+	escrowJSON, err := json.Marshal(escrow)
+	if err != nil {
+		return nil, err
+	}
+	err = ctx.GetStub().PutState(escrow.EscrowID, escrowJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hold, nil
+}
+
+// vestedAmount returns how much of a release's net amount has vested at a time,
+// and when the next installment unlocks (zero once fully vested)
+func vestedAmount(release FundRelease, at time.Time) (float64, time.Time, error) {
+	schedule := release.VestingSchedule
+	start, err := time.Parse(time.RFC3339, release.VestingStartsAt)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("release %s has an invalid vesting start: %v", release.ReleaseID, err)
+	}
+
+	day := 24 * time.Hour
+	cliffAt := start.Add(time.Duration(schedule.CliffDays) * day)
+	interval := time.Duration(schedule.IntervalDays) * day
+
+	installments := 0
+	if !at.Before(cliffAt) {
+		installments = int(at.Sub(start) / interval)
+		if installments > schedule.Installments {
+			installments = schedule.Installments
+		}
+	}
+
+	if installments == schedule.Installments {
+		return release.NetAmount, time.Time{}, nil
+	}
+
+	// The next installment unlocks one interval on, but not before the cliff
+	nextUnlockAt := start.Add(time.Duration(installments+1) * interval)
+	if nextUnlockAt.Before(cliffAt) {
+		nextUnlockAt = cliffAt
+	}
+
+	vested := math.Round(release.NetAmount*float64(installments)/float64(schedule.Installments)*100) / 100
+	return vested, nextUnlockAt, nil
+}
+
+// formatOptionalTime formats a time as RFC3339, or empty for the zero time
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// nextReportingPeriodEnd returns when a reporting period starting at start ends
+func nextReportingPeriodEnd(start time.Time, frequency string) (time.Time, error) {
+	switch frequency {
+	case "MONTHLY":
+		return start.AddDate(0, 1, 0), nil
+	case "QUARTERLY":
+		return start.AddDate(0, 3, 0), nil
+	case "ANNUAL":
+		return start.AddDate(1, 0, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid reporting frequency: %s. Must be MONTHLY, QUARTERLY or ANNUAL", frequency)
+}
+
+// getRevenueShareAgreements returns active revenue-share agreements matching the extra selector fields
+func getRevenueShareAgreements(ctx contractapi.TransactionContextInterface, filter map[string]interface{}) ([]Agreement, error) {
+	selector := map[string]interface{}{
+		"agreementId":         map[string]bool{"$exists": true},
+		"instrumentType":      "REVENUE_SHARE",
+		"revenueShare.status": "ACTIVE",
+	}
+	for field, value := range filter {
+		selector[field] = value
+	}
+
+	records, err := getQueryResults(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	agreements := []Agreement{}
+	for _, record := range records {
+		var agreement Agreement
+		if json.Unmarshal(record.Value, &agreement) != nil || record.Key != agreement.AgreementID {
+			continue
+		}
+		if agreement.Milestones == nil {
+			agreement.Milestones = []Milestone{}
+		}
+		agreements = append(agreements, agreement)
+	}
+	sort.Slice(agreements, func(a, b int) bool { return agreements[a].AgreementID < agreements[b].AgreementID })
+	return agreements, nil
+}
+
+func capTableKey(startupID string) string { return "CAP_TABLE_" + startupID }
+
+func capTableSnapshotKey(campaignID string) string { return "CAP_TABLE_SNAPSHOT_" + campaignID }
+
+// getCapTable returns a startup's cap table, empty if no shares have been issued
+func getCapTable(ctx contractapi.TransactionContextInterface, startupID string) (*CapTable, error) {
+	if platformCtx, ok := ctx.(*PlatformTransactionContext); ok {
+		if cached, found := platformCtx.capTables[startupID]; found {
+			capTable := *cached
+			capTable.Issuances = append([]ShareIssuance{}, cached.Issuances...)
+			return &capTable, nil
+		}
+	}
+
+	capTableJSON, err := ctx.GetStub().GetState(capTableKey(startupID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cap table: %v", err)
+	}
+
+	capTable := CapTable{StartupID: startupID, Issuances: []ShareIssuance{}}
+	if capTableJSON != nil {
+		if err := json.Unmarshal(capTableJSON, &capTable); err != nil {
+			return nil, err
+		}
+	}
+	return &capTable, nil
+}
+
+// putCapTable recomputes the fully diluted share count and stores the cap table
+func putCapTable(ctx contractapi.TransactionContextInterface, capTable *CapTable, now string) error {
+	capTable.TotalShares = 0
+	for _, issuance := range capTable.Issuances {
+		capTable.TotalShares += issuance.Shares
+	}
+	capTable.UpdatedAt = now
+
+	capTableJSON, err := json.Marshal(capTable)
+	if err != nil {
+		return err
+	}
+	if err := ctx.GetStub().PutState(capTableKey(capTable.StartupID), capTableJSON); err != nil {
+		return err
+	}
+
+	if platformCtx, ok := ctx.(*PlatformTransactionContext); ok {
+		if platformCtx.capTables == nil {
+			platformCtx.capTables = map[string]*CapTable{}
+		}
+		cached := *capTable
+		cached.Issuances = append([]ShareIssuance{}, capTable.Issuances...)
+		platformCtx.capTables[capTable.StartupID] = &cached
+	}
+	return nil
+}
+
+// issueAgreementShares issues the shares an investment buys in the campaign's share class
+// The caller stores the updated agreement and campaign; campaigns without a share class,
+// or agreements already issued shares, are left unchanged
+func issueAgreementShares(ctx contractapi.TransactionContextInterface, agreement *Agreement, campaign *PublishedCampaign, now string) error {
+	shareClass := campaign.ShareClass
+	if shareClass.ClassName == "" || agreement.SharesIssued > 0 || agreement.InstrumentType != "" {
+		return nil
+	}
+
+	shares := int(math.Floor(agreement.InvestmentAmount/shareClass.PricePerShare + 1e-9))
+	if shares < shareClass.MinShares || shares > shareClass.MaxShares {
+		return fmt.Errorf("investment of %.2f buys %d shares; campaign %s allows %d to %d per investor", agreement.InvestmentAmount, shares, campaign.CampaignID, shareClass.MinShares, shareClass.MaxShares)
+	}
+
+	capTable, err := getCapTable(ctx, campaign.StartupID)
+	if err != nil {
+		return err
+	}
+	capTable.Issuances = append(capTable.Issuances, ShareIssuance{
+		HolderID:      agreement.InvestorID,
+		ShareClass:    shareClass.ClassName,
+		Shares:        shares,
+		PricePerShare: shareClass.PricePerShare,
+		CampaignID:    campaign.CampaignID,
+		AgreementID:   agreement.AgreementID,
+		IssuedAt:      now,
+	})
+	if err := putCapTable(ctx, capTable, now); err != nil {
+		return err
+	}
+
+	campaign.ShareClass.SharesIssued += shares
+	agreement.ShareClass = shareClass.ClassName
+	agreement.SharesIssued = shares
+	return nil
+}
+
+// snapshotCapTable records the cap table when a share-class campaign closes
+// Failed or cancelled campaigns first cancel the shares their agreements were issued
+func snapshotCapTable(ctx contractapi.TransactionContextInterface, campaign PublishedCampaign, closure CampaignClosure) error {
+	capTable, err := getCapTable(ctx, campaign.StartupID)
+	if err != nil {
+		return err
+	}
+
+	if closure.FinalStatus == "FAILED" || closure.FinalStatus == "CANCELLED" {
+		kept := []ShareIssuance{}
+		for _, issuance := range capTable.Issuances {
+			if issuance.CampaignID == campaign.CampaignID && issuance.AgreementID != "" {
+				continue
+			}
+			kept = append(kept, issuance)
+		}
+		capTable.Issuances = kept
+		if err := putCapTable(ctx, capTable, closure.ClosedAt); err != nil {
+			return err
+		}
+	}
+
+	snapshot := CapTableSnapshot{
+		StartupID:   campaign.StartupID,
+		CampaignID:  campaign.CampaignID,
+		ClosureID:   closure.ClosureID,
+		FinalStatus: closure.FinalStatus,
+		TotalShares: capTable.TotalShares,
+		Holders:     capTableOwnership(capTable),
+		TakenAt:     closure.ClosedAt,
+	}
+	snapshotJSON, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(capTableSnapshotKey(campaign.CampaignID), snapshotJSON)
+}
+
+// capTableOwnership aggregates issuances per holder, largest holding first
+func capTableOwnership(capTable *CapTable) []CapTableOwnership {
+	byHolder := map[string]*CapTableOwnership{}
+	for _, issuance := range capTable.Issuances {
+		holder := byHolder[issuance.HolderID]
+		if holder == nil {
+			holder = &CapTableOwnership{HolderID: issuance.HolderID, ShareClasses: []string{}}
+			byHolder[issuance.HolderID] = holder
+		}
+		holder.Shares += issuance.Shares
+		hasClass := false
+		for _, class := range holder.ShareClasses {
+			hasClass = hasClass || class == issuance.ShareClass
+		}
+		if !hasClass {
+			holder.ShareClasses = append(holder.ShareClasses, issuance.ShareClass)
+		}
+	}
+
+	holders := []CapTableOwnership{}
+	for _, holder := range byHolder {
+		if capTable.TotalShares > 0 {
+			holder.OwnershipPercent = math.Round(float64(holder.Shares)/float64(capTable.TotalShares)*1000000) / 10000
+		}
+		holders = append(holders, *holder)
+	}
+	sort.Slice(holders, func(a, b int) bool {
+		if holders[a].Shares != holders[b].Shares {
+			return holders[a].Shares > holders[b].Shares
+		}
+		return holders[a].HolderID < holders[b].HolderID
+	})
+	return holders
+}
+
+// getOutstandingInstruments returns a startup's witnessed, unconverted SAFEs and convertible notes
+func getOutstandingInstruments(ctx contractapi.TransactionContextInterface, startupID string) ([]Agreement, error) {
+	records, err := getQueryResults(ctx, map[string]interface{}{
+		"agreementId":       map[string]bool{"$exists": true},
+		"startupId":         startupID,
+		"platformWitnessed": true,
+		"sharesIssued":      0,
+		"instrumentType":    map[string]interface{}{"$in": []string{"SAFE", "CONVERTIBLE_NOTE"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	agreements := []Agreement{}
+	for _, record := range records {
+		var agreement Agreement
+		if json.Unmarshal(record.Value, &agreement) != nil || record.Key != agreement.AgreementID {
+			continue
+		}
+		if agreement.Status == "CANCELLED" {
+			continue
+		}
+		if agreement.Milestones == nil {
+			agreement.Milestones = []Milestone{}
+		}
+		agreements = append(agreements, agreement)
+	}
+	sort.Slice(agreements, func(a, b int) bool { return agreements[a].AgreementID < agreements[b].AgreementID })
+	return agreements, nil
+}
+
+func loanKey(campaignID string) string { return "LOAN_" + campaignID }
+
+func revenueShareOfferKey(campaignID string) string { return "REVENUE_SHARE_" + campaignID }
+
+// getRevenueShareOffer returns a campaign's revenue-share terms, or nil for campaigns that are not revenue-share campaigns
+func getRevenueShareOffer(ctx contractapi.TransactionContextInterface, campaignID string) (*RevenueShareOffer, error) {
+	offerJSON, err := ctx.GetStub().GetState(revenueShareOfferKey(campaignID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read revenue-share terms: %v", err)
+	}
+	if offerJSON == nil {
+		return nil, nil
+	}
+
+	var offer RevenueShareOffer
+	if err := json.Unmarshal(offerJSON, &offer); err != nil {
+		return nil, err
+	}
+	return &offer, nil
+}
+
+// getLoan returns a campaign's loan, or nil for campaigns that are not lending campaigns
+func getLoan(ctx contractapi.TransactionContextInterface, campaignID string) (*Loan, error) {
+	loanJSON, err := ctx.GetStub().GetState(loanKey(campaignID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read loan: %v", err)
+	}
+	if loanJSON == nil {
+		return nil, nil
+	}
+
+	var loan Loan
+	if err := json.Unmarshal(loanJSON, &loan); err != nil {
+		return nil, err
+	}
+	return &loan, nil
+}
+
+func putLoan(ctx contractapi.TransactionContextInterface, loan *Loan) error {
+	loanJSON, err := json.Marshal(loan)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(loan.LoanID, loanJSON)
+}
+
+// buildLoanSchedule generates monthly installments rounded to cents; the last installment
+// takes the remaining principal
+func buildLoanSchedule(principal float64, interestRate float64, termMonths int, amortizationType string, firstDueAt time.Time) []LoanInstallment {
+	monthlyRate := interestRate / 100 / 12
+	payment := principal / float64(termMonths)
+	if amortizationType == "EQUAL_PAYMENT" && monthlyRate > 0 {
+		payment = principal * monthlyRate / (1 - math.Pow(1+monthlyRate, -float64(termMonths)))
+	}
+
+	schedule := []LoanInstallment{}
+	balance := principal
+	for number := 1; number <= termMonths; number++ {
+		interest := math.Round(balance*monthlyRate*100) / 100
+		principalDue := 0.0
+		switch {
+		case number == termMonths:
+			principalDue = balance
+		case amortizationType == "EQUAL_PAYMENT":
+			principalDue = math.Round((payment-interest)*100) / 100
+		case amortizationType == "EQUAL_PRINCIPAL":
+			principalDue = math.Round(payment*100) / 100
+		}
+		balance = math.Round((balance-principalDue)*100) / 100
+
+		// Month-end due dates stay at month end (Jan 31, Feb 28, Mar 31)
+		dueAt := firstDueAt.AddDate(0, number-1, 0)
+		if dueAt.Day() != firstDueAt.Day() {
+			dueAt = dueAt.AddDate(0, 0, -dueAt.Day())
+		}
+
+		schedule = append(schedule, LoanInstallment{
+			Number:    number,
+			DueDate:   dueAt.Format("2006-01-02"),
+			Principal: principalDue,
+			Interest:  interest,
+			Status:    "PENDING",
+		})
+	}
+	return schedule
+}
+
+// assessLoan sets a loan's days past due from its oldest unpaid installment and derives its status
+// Defaults are not cured by later repayments
+func assessLoan(loan *Loan, at time.Time) {
+	loan.DaysPastDue = 0
+	allPaid := true
+	for _, installment := range loan.Schedule {
+		if installment.Status == "PAID" {
+			continue
+		}
+		allPaid = false
+		dueAt, err := parseDate(installment.DueDate)
+		if err == nil && at.After(dueAt) {
+			loan.DaysPastDue = int(at.Sub(dueAt).Hours() / 24)
+		}
+		break
+	}
+
+	switch {
+	case allPaid:
+		loan.Status = "REPAID"
+	case loan.Status == "DEFAULTED" || loan.DaysPastDue >= loanDefaultDays:
+		loan.Status = "DEFAULTED"
+	case loan.DaysPastDue > 0:
+		loan.Status = "DELINQUENT"
+	default:
+		loan.Status = "ACTIVE"
+	}
+}
+
+func rewardTierKey(campaignID string, tierID string) string {
+	return fmt.Sprintf("REWARD_TIER_%s_%s", campaignID, tierID)
+}
+
+// getRewardTier returns a campaign's reward tier
+func getRewardTier(ctx contractapi.TransactionContextInterface, campaignID string, tierID string) (*RewardTier, error) {
+	tierJSON, err := ctx.GetStub().GetState(rewardTierKey(campaignID, tierID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reward tier: %v", err)
+	}
+	if tierJSON == nil {
+		return nil, fmt.Errorf("reward tier %s does not exist for campaign %s", tierID, campaignID)
+	}
+
+	var tier RewardTier
+	if err := json.Unmarshal(tierJSON, &tier); err != nil {
+		return nil, err
+	}
+	if tier.ShippingRegions == nil {
+		tier.ShippingRegions = []string{}
+	}
+	return &tier, nil
+}
+
+func putRewardTier(ctx contractapi.TransactionContextInterface, tier *RewardTier) error {
+	tierJSON, err := json.Marshal(tier)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(rewardTierKey(tier.CampaignID, tier.TierID), tierJSON)
+}
+
+func pledgeEscrowID(pledgeID string) string { return "PLEDGE_ESCROW_" + pledgeID }
+
+// getRewardPledge returns a backer's pledge
+func getRewardPledge(ctx contractapi.TransactionContextInterface, pledgeID string) (*RewardPledge, error) {
+	pledgeJSON, err := ctx.GetStub().GetState(pledgeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pledge: %v", err)
+	}
+	if pledgeJSON == nil {
+		return nil, fmt.Errorf("pledge %s does not exist", pledgeID)
+	}
+
+	var pledge RewardPledge
+	if err := json.Unmarshal(pledgeJSON, &pledge); err != nil {
+		return nil, err
+	}
+	if pledge.FulfillmentHistory == nil {
+		pledge.FulfillmentHistory = []FulfillmentUpdate{}
+	}
+	return &pledge, nil
+}
+
+func putRewardPledge(ctx contractapi.TransactionContextInterface, pledge *RewardPledge) error {
+	pledgeJSON, err := json.Marshal(pledge)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(pledge.PledgeID, pledgeJSON)
+}
+
+// getRewardPledges returns pledges matching the extra selector fields, oldest first
+func getRewardPledges(ctx contractapi.TransactionContextInterface, filter map[string]interface{}) ([]RewardPledge, error) {
+	selector := map[string]interface{}{
+		"pledgeId": map[string]bool{"$exists": true},
+		"tierId":   map[string]bool{"$exists": true},
+	}
+	for field, value := range filter {
+		selector[field] = value
+	}
+
+	records, err := getQueryResults(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	pledges := []RewardPledge{}
+	for _, record := range records {
+		var pledge RewardPledge
+		if json.Unmarshal(record.Value, &pledge) != nil || record.Key != pledge.PledgeID {
+			continue
+		}
+		if pledge.FulfillmentHistory == nil {
+			pledge.FulfillmentHistory = []FulfillmentUpdate{}
+		}
+		pledges = append(pledges, pledge)
+	}
+	sort.Slice(pledges, func(a, b int) bool {
+		if pledges[a].PledgedAt != pledges[b].PledgedAt {
+			return pledges[a].PledgedAt < pledges[b].PledgedAt
+		}
+		return pledges[a].PledgeID < pledges[b].PledgeID
+	})
+	return pledges, nil
+}
+
+// rewardDeliveryLate reports whether the estimated delivery date has passed (the whole day counts)
+func rewardDeliveryLate(estimatedDelivery string, at time.Time) bool {
+	deliveryDate, err := parseDate(estimatedDelivery)
+	if err != nil {
+		return false
+	}
+	return !at.Before(deliveryDate.AddDate(0, 0, 1))
+}
+
+// releaseLabel names a release in journal memos: its milestone, or its escrow for reward pledge releases
+func releaseLabel(release FundRelease) string {
+	if release.MilestoneID == "" {
+		return "Escrow " + release.EscrowID
+	}
+	return "Milestone " + release.MilestoneID
+}
+
+// releasePledgeEscrow releases a delivered pledge's escrow to the startup
+// Releases above the approval policy threshold wait in PENDING_APPROVAL like milestone releases
+// Callers check the campaign's funding model first
+func releasePledgeEscrow(ctx contractapi.TransactionContextInterface, pledge *RewardPledge, now string) (*FundRelease, error) {
+	escrowJSON, err := ctx.GetStub().GetState(pledge.EscrowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read escrow: %v", err)
+	}
+	if escrowJSON == nil {
+		return nil, fmt.Errorf("escrow %s does not exist", pledge.EscrowID)
+	}
+
+	var escrow FundEscrow
+	if err := json.Unmarshal(escrowJSON, &escrow); err != nil {
+		return nil, err
+	}
+	if err := checkEscrowNotFrozen(escrow); err != nil {
+		return nil, err
+	}
+	if availableEscrowAmount(escrow) <= 0 {
+		return nil, fmt.Errorf("escrow %s holds no funds to release", escrow.EscrowID)
+	}
+
+	release := FundRelease{
+		ReleaseID:          fmt.Sprintf("RELEASE_%s", pledge.PledgeID),
+		EscrowID:           escrow.EscrowID,
+		PledgeID:           pledge.PledgeID,
+		CampaignID:         escrow.CampaignID,
+		StartupID:          escrow.StartupID,
+		Amount:             escrow.HeldAmount,
+		Currency:           escrow.Currency,
+		TriggerReason:      "REWARD_DELIVERED",
+		RequiredSignatures: map[string]int{},
+		Approvals:          []ReleaseApproval{},
+		RequestedAt:        now,
+	}
+
+	policy, err := getReleaseApprovalPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if policy.ThresholdAmount > 0 && release.Amount > policy.ThresholdAmount {
+		if _, err := requestReleaseApproval(ctx, &release, &escrow, policy); err != nil {
+			return nil, err
+		}
+		return &release, nil
+	}
+
+	if err := executeFundRelease(ctx, &release, &escrow, now); err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// fulfillReleasedPledge marks a reward pledge FULFILLED once the release of its escrow has executed
+func fulfillReleasedPledge(ctx contractapi.TransactionContextInterface, pledgeID string, now string) error {
+	pledge, err := getRewardPledge(ctx, pledgeID)
+	if err != nil {
+		return err
+	}
+	pledge.Status = "FULFILLED"
+	pledge.UpdatedAt = now
+	return putRewardPledge(ctx, pledge)
+}
+
+// cancelRefundedPledges marks a campaign's open pledges refunded once their escrows were refunded
+func cancelRefundedPledges(ctx contractapi.TransactionContextInterface, campaignID string, refunds []EscrowRefund, now string) error {
+	refunded := map[string]bool{}
+	for _, refund := range refunds {
+		refunded[refund.EscrowID] = true
+	}
+
+	// Pledges are read by the keys recorded on the campaign, like their escrows
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return nil
+	}
+	var campaign PublishedCampaign
+	if err := json.Unmarshal(campaignJSON, &campaign); err != nil {
+		return err
+	}
+
+	for _, pledgeID := range campaign.PledgeIDs {
+		pledge, err := getRewardPledge(ctx, pledgeID)
+		if err != nil {
+			return err
+		}
+		if pledge.Status != "PLEDGED" || !refunded[pledge.EscrowID] {
+			continue
+		}
+		pledge.Status = "REFUNDED"
+		pledge.FulfillmentStatus = "CANCELLED"
+		pledge.UpdatedAt = now
+		if err := putRewardPledge(ctx, pledge); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// investorStake is an investor's invested amount in a campaign under witnessed agreements
+type investorStake struct {
+	InvestorID   string
+	AgreementIDs []string
+	Invested     float64
+}
+
+// getInvestorStakes returns each investor's invested amount (less refunds) under the campaign's
+// witnessed agreements with a startup in a currency, ordered by investor ID, and the total
+func getInvestorStakes(ctx contractapi.TransactionContextInterface, campaignID string, startupID string, currency string) ([]investorStake, float64, error) {
+	records, err := getQueryResults(ctx, map[string]interface{}{
+		"agreementId":       map[string]bool{"$exists": true},
+		"investmentAmount":  map[string]bool{"$exists": true},
+		"campaignId":        campaignID,
+		"platformWitnessed": true,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	stakesByInvestor := map[string]*investorStake{}
+	for _, record := range records {
+		var agreement Agreement
+		if json.Unmarshal(record.Value, &agreement) != nil || record.Key != agreement.AgreementID {
+			continue
+		}
+		if agreement.StartupID != startupID || agreement.Currency != currency || agreement.Status == "CANCELLED" {
+			continue
+		}
+
+		invested, err := agreementInvestedAmount(ctx, agreement)
+		if err != nil {
+			return nil, 0, err
+		}
+		if invested <= 0 {
+			continue
+		}
+
+		stake := stakesByInvestor[agreement.InvestorID]
+		if stake == nil {
+			stake = &investorStake{InvestorID: agreement.InvestorID, AgreementIDs: []string{}}
+			stakesByInvestor[agreement.InvestorID] = stake
+		}
+		stake.AgreementIDs = append(stake.AgreementIDs, agreement.AgreementID)
+		stake.Invested += invested
+	}
+
+	stakes := []investorStake{}
+	total := 0.0
+	for _, stake := range stakesByInvestor {
+		sort.Strings(stake.AgreementIDs)
+		stakes = append(stakes, *stake)
+		total += stake.Invested
+	}
+	sort.Slice(stakes, func(a, b int) bool { return stakes[a].InvestorID < stakes[b].InvestorID })
+	return stakes, total, nil
+}
+
+// agreementInvestedAmount returns an agreement's investment less what its escrow refunded
+func agreementInvestedAmount(ctx contractapi.TransactionContextInterface, agreement Agreement) (float64, error) {
+	escrowJSON, err := ctx.GetStub().GetState(fmt.Sprintf("ESCROW_%s", agreement.AgreementID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read escrow: %v", err)
+	}
+	if escrowJSON == nil {
+		return agreement.InvestmentAmount, nil
+	}
+
+	var escrow FundEscrow
+	if err := json.Unmarshal(escrowJSON, &escrow); err != nil {
+		return 0, err
+	}
+	return agreement.InvestmentAmount - escrow.RefundedAmount, nil
+}
+
+// splitProRata splits an amount by ratios, rounded to cents; the last share takes the rounding remainder
+func splitProRata(amount float64, ratios []float64) []float64 {
+	amounts := make([]float64, len(ratios))
+	remaining := amount
+	for idx, ratio := range ratios {
+		share := math.Round(amount*ratio*100) / 100
+		if idx == len(ratios)-1 || share > remaining {
+			share = math.Round(remaining*100) / 100
+		}
+		remaining -= share
+		amounts[idx] = share
+	}
+	return amounts
+}
+
+func distributionPayoutID(distributionID string, investorID string) string {
+	return fmt.Sprintf("DISTRIBUTION_PAYOUT_%s_%s", distributionID, investorID)
+}
+
+// recordDistributionPayouts stores one PENDING payout per investor stake with the given amounts
+func recordDistributionPayouts(ctx contractapi.TransactionContextInterface, distribution Distribution, stakes []investorStake, amounts []float64) ([]DistributionPayout, error) {
+	payouts := []DistributionPayout{}
+	for idx, stake := range stakes {
+		payout := DistributionPayout{
+			PayoutID:         distributionPayoutID(distribution.DistributionID, stake.InvestorID),
+			DistributionID:   distribution.DistributionID,
+			CampaignID:       distribution.CampaignID,
+			InvestorID:       stake.InvestorID,
+			AgreementIDs:     stake.AgreementIDs,
+			InvestedAmount:   stake.Invested,
+			ShareRatio:       amounts[idx] / distribution.TotalAmount,
+			Amount:           amounts[idx],
+			Currency:         distribution.Currency,
+			DistributionType: distribution.DistributionType,
+			Status:           "PENDING",
+			DeclaredAt:       distribution.DeclaredAt,
+		}
+		payoutJSON, err := json.Marshal(payout)
+		if err != nil {
+			return nil, err
+		}
+		err = ctx.GetStub().PutState(payout.PayoutID, payoutJSON)
+		if err != nil {
+			return nil, err
+		}
+		payouts = append(payouts, payout)
+	}
+	return payouts, nil
+}
+
+// payoutEventEntries lists payouts in event payloads
+func payoutEventEntries(payouts []DistributionPayout) []map[string]interface{} {
+	entries := []map[string]interface{}{}
+	for _, payout := range payouts {
+		entries = append(entries, map[string]interface{}{
+			"payoutId":   payout.PayoutID,
+			"investorId": payout.InvestorID,
+			"amount":     payout.Amount,
+		})
+	}
+	return entries
+}
+
+// getDistribution reads a distribution
+func getDistribution(ctx contractapi.TransactionContextInterface, distributionID string) (*Distribution, error) {
+	distributionJSON, err := ctx.GetStub().GetState(distributionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read distribution: %v", err)
+	}
+	if distributionJSON == nil {
+		return nil, fmt.Errorf("distribution %s does not exist", distributionID)
+	}
+
+	var distribution Distribution
+	err = json.Unmarshal(distributionJSON, &distribution)
+	if err != nil {
+		return nil, err
+	}
+
+	return &distribution, nil
+}
+
+// getDistributionPayouts returns payouts matching the extra selector fields as JSON, oldest first
+func getDistributionPayouts(ctx contractapi.TransactionContextInterface, filter map[string]interface{}) (string, error) {
+	selector := map[string]interface{}{
+		"payoutId":       map[string]bool{"$exists": true},
+		"distributionId": map[string]bool{"$exists": true},
+	}
+	for field, value := range filter {
+		selector[field] = value
+	}
+
+	records, err := getQueryResults(ctx, selector)
+	if err != nil {
+		return "", err
+	}
+
+	payouts := []DistributionPayout{}
+	for _, record := range records {
+		var payout DistributionPayout
+		if json.Unmarshal(record.Value, &payout) != nil {
+			continue
+		}
+		payouts = append(payouts, payout)
+	}
+	sort.Slice(payouts, func(a, b int) bool {
+		if payouts[a].DeclaredAt != payouts[b].DeclaredAt {
+			return payouts[a].DeclaredAt < payouts[b].DeclaredAt
+		}
+		return payouts[a].PayoutID < payouts[b].PayoutID
+	})
+
+	payoutsJSON, err := json.Marshal(payouts)
+	if err != nil {
+		return "", err
+	}
+
+	return string(payoutsJSON), nil
+}
+
+// getClawback reads the clawback of a campaign
+func getClawback(ctx contractapi.TransactionContextInterface, campaignID string) (*Clawback, error) {
+	clawbackJSON, err := ctx.GetStub().GetState(fmt.Sprintf("CLAWBACK_%s", campaignID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read clawback: %v", err)
+	}
+	if clawbackJSON == nil {
+		return nil, fmt.Errorf("campaign %s has no clawback", campaignID)
+	}
+
+	var clawback Clawback
+	err = json.Unmarshal(clawbackJSON, &clawback)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clawback, nil
+}
+
+// holdEventEntries lists holds in event payloads
+func holdEventEntries(holds []EscrowHold) []map[string]interface{} {
+	entries := []map[string]interface{}{}
+	for _, hold := range holds {
+		entries = append(entries, map[string]interface{}{
+			"holdId":     hold.HoldID,
+			"escrowId":   hold.EscrowID,
+			"investorId": hold.InvestorID,
+			"status":     hold.Status,
+		})
+	}
+	return entries
+}
+
+// refundCampaignEscrows refunds every escrow of a campaign that still holds funds
+// Escrows are read by the keys recorded on the campaign, not by rich query, so the
+// refunds are validated against the escrows' current versions at commit
+func refundCampaignEscrows(ctx contractapi.TransactionContextInterface, campaignID string, reason string, now string) ([]EscrowRefund, error) {
+	campaignJSON, err := ctx.GetStub().GetState(campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read campaign: %v", err)
+	}
+	refunds := []EscrowRefund{}
+	if campaignJSON == nil {
+		return refunds, nil
+	}
+	var campaign PublishedCampaign
+	if err := json.Unmarshal(campaignJSON, &campaign); err != nil {
+		return nil, err
+	}
+
+	escrows, err := campaignEscrows(ctx, campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range escrows {
+		escrow := &escrows[idx]
+		// Frozen escrows stay frozen; refund them once their hold is resolved
+		if escrow.HeldAmount <= 0 || escrow.Status == "REFUNDED" || escrow.Status == "FULLY_RELEASED" || escrow.Status == "FROZEN" {
+			continue
+		}
+		refund, err := refundEscrow(ctx, escrow, fmt.Sprintf("REFUND_%s", escrow.EscrowID), reason, now)
+		if err != nil {
+			return nil, err
+		}
+		refunds = append(refunds, *refund)
+	}
+
+	return refunds, nil
+}
+
+// refundEventEntries lists refunds in event payloads for InvestorOrg reconciliation
+func refundEventEntries(refunds []EscrowRefund) []map[string]interface{} {
+	entries := []map[string]interface{}{}
+	for _, refund := range refunds {
+		entries = append(entries, map[string]interface{}{
+			"refundId":   refund.RefundID,
+			"escrowId":   refund.EscrowID,
+			"investorId": refund.InvestorID,
+			"amount":     refund.Amount,
+			"currency":   refund.Currency,
+		})
+	}
+	return entries
+}
+
+// getRefunds returns escrow refunds matching the extra selector fields, oldest first
+func getRefunds(ctx contractapi.TransactionContextInterface, filter map[string]interface{}) (string, error) {
+	selector := map[string]interface{}{
+		"refundId": map[string]bool{"$exists": true},
+		"escrowId": map[string]bool{"$exists": true},
+	}
+	for field, value := range filter {
+		selector[field] = value
+	}
+
+	records, err := getQueryResults(ctx, selector)
+	if err != nil {
+		return "", err
+	}
+
+	refunds := []EscrowRefund{}
+	for _, record := range records {
+		var refund EscrowRefund
+		if json.Unmarshal(record.Value, &refund) != nil {
+			continue
+		}
+		refunds = append(refunds, refund)
+	}
+	sort.Slice(refunds, func(a, b int) bool {
+		if refunds[a].RefundedAt != refunds[b].RefundedAt {
+			return refunds[a].RefundedAt < refunds[b].RefundedAt
+		}
+		return refunds[a].RefundID < refunds[b].RefundID
+	})
+
+	refundsJSON, err := json.Marshal(refunds)
+	if err != nil {
+		return "", err
+	}
+
+	return string(refundsJSON), nil
+}
+
+// addToTreasuryTotals adds one escrow to a totals bucket
+func addToTreasuryTotals(totals *TreasuryTotals, escrow FundEscrow) {
+	totals.EscrowCount++
+	totals.TotalEscrowed += escrow.TotalAmount
+	totals.TotalReleased += escrow.ReleasedAmount
+	totals.TotalRefunded += escrow.RefundedAmount
+	totals.TotalHeld += escrow.HeldAmount
+}
+
+// buildTreasuryReport aggregates escrows and their releases by currency and status
+func buildTreasuryReport(ctx contractapi.TransactionContextInterface, scope string, scopeID string, filter map[string]interface{}) (*TreasuryReport, error) {
+	now, err := getTxTime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	escrows, err := getEscrows(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	escrowIDs := make([]string, 0, len(escrows))
+	for _, escrow := range escrows {
+		escrowIDs = append(escrowIDs, escrow.EscrowID)
+	}
+	releases, err := getReleasesForEscrows(ctx, escrowIDs)
+	if err != nil {
+		return nil, err
+	}
+	refunds, err := getRefundsForEscrows(ctx, escrowIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TreasuryReport{
+		Scope:               scope,
+		ScopeID:             scopeID,
+		ByCurrency:          map[string]*TreasuryTotals{},
+		ByCurrencyAndStatus: map[string]map[string]*TreasuryTotals{},
+		Escrows:             []EscrowStatement{},
+		GeneratedAt:         now.Format(time.RFC3339),
+	}
+
+	for _, escrow := range escrows {
+		if report.ByCurrency[escrow.Currency] == nil {
+			report.ByCurrency[escrow.Currency] = &TreasuryTotals{Currency: escrow.Currency}
+			report.ByCurrencyAndStatus[escrow.Currency] = map[string]*TreasuryTotals{}
+		}
+		if report.ByCurrencyAndStatus[escrow.Currency][escrow.Status] == nil {
+			report.ByCurrencyAndStatus[escrow.Currency][escrow.Status] = &TreasuryTotals{Currency: escrow.Currency}
+		}
+		addToTreasuryTotals(report.ByCurrency[escrow.Currency], escrow)
+		addToTreasuryTotals(report.ByCurrencyAndStatus[escrow.Currency][escrow.Status], escrow)
+
+		report.Escrows = append(report.Escrows, EscrowStatement{
+			Escrow:   escrow,
+			Releases: releases[escrow.EscrowID],
+			Refunds:  refunds[escrow.EscrowID],
+		})
+	}
+
+	return report, nil
+}
+
+func main() {
+	platformContract := &PlatformContract{}
+	platformContract.TransactionContextHandler = new(PlatformTransactionContext)
+
+	platformChaincode, err := contractapi.NewChaincode(platformContract)
 	if err != nil {
 		fmt.Printf("Error creating PlatformOrg chaincode: %v\n", err)
 		return
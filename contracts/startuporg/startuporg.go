@@ -5,6 +5,9 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
@@ -118,30 +121,35 @@ type Milestone struct {
 
 // Agreement represents investment agreement between startup and investor
 type Agreement struct {
-	AgreementID        string       `json:"agreementId"`
-	CampaignID         string       `json:"campaignId"`
-	StartupID          string       `json:"startupId"`
-	InvestorID         string       `json:"investorId"`
-	InvestmentAmount   float64      `json:"investmentAmount"`
-	Currency           string       `json:"currency"`
-	Milestones         []Milestone  `json:"milestones"`
-	Terms              string       `json:"terms"`
-	Status             string       `json:"status"` // PROPOSED, NEGOTIATING, ACCEPTED, ACTIVE, COMPLETED, CANCELLED
-	StartupAccepted    bool         `json:"startupAccepted"`
-	InvestorAccepted   bool         `json:"investorAccepted"`
-	PlatformWitnessed  bool         `json:"platformWitnessed"`
+	AgreementID        string             `json:"agreementId"`
+	CampaignID         string             `json:"campaignId"`
+	StartupID          string             `json:"startupId"`
+	InvestorID         string             `json:"investorId"`
+	InvestmentAmount   float64            `json:"investmentAmount"`
+	Currency           string             `json:"currency"`
+	Milestones         []Milestone        `json:"milestones"`
+	Terms              string             `json:"terms"`
+	NegotiatedTerms    json.RawMessage    `json:"negotiatedTerms"` // Term sheet as normalized by PlatformOrg
+	TermsHash          string             `json:"termsHash"`       // Hash of the normalized terms
+	Status             string             `json:"status"`    // PROPOSED, NEGOTIATING, ACCEPTED, ACTIVE, COMPLETED, CANCELLED
+	StartupAccepted    bool               `json:"startupAccepted"`
+	InvestorAccepted   bool               `json:"investorAccepted"`
+	PlatformWitnessed  bool               `json:"platformWitnessed"`
 	NegotiationHistory []NegotiationEntry `json:"negotiationHistory"`
-	CreatedAt          string       `json:"createdAt"`
-	AcceptedAt         string       `json:"acceptedAt"`
+	CreatedAt          string             `json:"createdAt"`
+	AcceptedAt         string             `json:"acceptedAt"`
 }
 
 // NegotiationEntry tracks agreement negotiations
 type NegotiationEntry struct {
-	EntryID   string `json:"entryId"`
-	FromOrg   string `json:"fromOrg"` // STARTUP or INVESTOR
-	Action    string `json:"action"`  // PROPOSE, COUNTER, ACCEPT, REJECT, MODIFY
-	Changes   string `json:"changes"`
-	Timestamp string `json:"timestamp"`
+	EntryID         string          `json:"entryId"`
+	FromOrg         string          `json:"fromOrg"` // STARTUP or INVESTOR
+	Action          string          `json:"action"`  // PROPOSE, COUNTER, ACCEPT, REJECT, MODIFY
+	Changes         string          `json:"changes"`
+	StructuredTerms json.RawMessage `json:"structuredTerms"`
+	TermsHash       string          `json:"termsHash"`
+	Diff            json.RawMessage `json:"diff"` // Field-level changes from the previous offer
+	Timestamp       string          `json:"timestamp"`
 }
 
 // MilestoneReport for progress reporting
@@ -699,7 +707,7 @@ func (s *StartupContract) RespondToInvestmentProposal(
 	ctx contractapi.TransactionContextInterface,
 	agreementID string,
 	action string, // ACCEPT, REJECT, COUNTER
-	counterTerms string,
+	counterTerms string, // Free text, or a JSON term sheet; free text keeps the current terms as their notes
 	milestonesJSON string,
 ) (string, error) {
 	// Retrieve agreement
@@ -717,15 +725,43 @@ func (s *StartupContract) RespondToInvestmentProposal(
 		return "", err
 	}
 
+	// Counters produce a new term sheet; ACCEPT and REJECT respond to the current one
+	previousTerms, err := agreementTerms(agreement)
+	if err != nil {
+		return "", err
+	}
+	offerTerms := ""
+	if action == "COUNTER" {
+		offerTerms = counterTerms
+	}
+	document, err := termsDocument(offerTerms, previousTerms)
+	if err != nil {
+		return "", err
+	}
+	if _, found := document["amount"]; !found {
+		document["amount"] = agreement.InvestmentAmount
+	}
+	if _, found := document["currency"]; !found {
+		document["currency"] = agreement.Currency
+	}
+	terms, err := evaluateNegotiationTerms(ctx, previousTerms, document)
+	if err != nil {
+		return "", err
+	}
+	termsHash := terms.TermsHash
+
 	now := time.Now().Format(time.RFC3339)
 
 	// Create negotiation entry
 	negotiationEntry := NegotiationEntry{
-		EntryID:   fmt.Sprintf("NEG_%s_%d", agreementID, len(agreement.NegotiationHistory)+1),
-		FromOrg:   "STARTUP",
-		Action:    action,
-		Changes:   counterTerms,
-		Timestamp: now,
+		EntryID:         fmt.Sprintf("NEG_%s_%d", agreementID, len(agreement.NegotiationHistory)+1),
+		FromOrg:         "STARTUP",
+		Action:          action,
+		Changes:         counterTerms,
+		StructuredTerms: terms.Terms,
+		TermsHash:       termsHash,
+		Diff:            terms.Diff,
+		Timestamp:       now,
 	}
 	agreement.NegotiationHistory = append(agreement.NegotiationHistory, negotiationEntry)
 
@@ -748,6 +784,15 @@ func (s *StartupContract) RespondToInvestmentProposal(
 		if counterTerms != "" {
 			agreement.Terms = counterTerms
 		}
+		var counterOffer struct {
+			Amount   float64 `json:"amount"`
+			Currency string  `json:"currency"`
+		}
+		if err := json.Unmarshal(terms.Terms, &counterOffer); err != nil {
+			return "", fmt.Errorf("failed to parse evaluated terms: %v", err)
+		}
+		agreement.InvestmentAmount = counterOffer.Amount
+		agreement.Currency = counterOffer.Currency
 		if milestonesJSON != "" {
 			var milestones []Milestone
 			if err := json.Unmarshal([]byte(milestonesJSON), &milestones); err == nil {
@@ -757,6 +802,8 @@ func (s *StartupContract) RespondToInvestmentProposal(
 	default:
 		return "", fmt.Errorf("invalid action: %s. Must be ACCEPT, REJECT, or COUNTER", action)
 	}
+	agreement.NegotiatedTerms = terms.Terms
+	agreement.TermsHash = termsHash
 
 	updatedAgreementJSON, err := json.Marshal(agreement)
 	if err != nil {
@@ -775,6 +822,9 @@ func (s *StartupContract) RespondToInvestmentProposal(
 		"action":          action,
 		"startupAccepted": agreement.StartupAccepted,
 		"status":          agreement.Status,
+		"instrument":      terms.Instrument,
+		"termsHash":       termsHash,
+		"changes":         terms.Changes,
 		"channel":         "startup-platform-channel",
 		"timestamp":       now,
 	}
@@ -785,6 +835,8 @@ func (s *StartupContract) RespondToInvestmentProposal(
 		"message":     fmt.Sprintf("Startup %s the investment proposal", action),
 		"agreementId": agreementID,
 		"status":      agreement.Status,
+		"termsHash":   termsHash,
+		"diff":        negotiationEntry.Diff,
 	}
 	responseJSON, _ := json.Marshal(response)
 	return string(responseJSON), nil
@@ -878,20 +930,649 @@ func (s *StartupContract) SubmitMilestoneReport(
 		"status":      "SUBMITTED",
 		"nextStep":    "Awaiting Validator verification, then fund release on common-channel",
 	}
-	responseJSON, _ := json.Marshal(response)
-	return string(responseJSON), nil
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ReceiveFunding records funding received for a milestone
+// Step 13: Startup receives funding from Platform escrow on common-channel
+// Channel: common-channel
+// Endorsers: All Organizations
+func (s *StartupContract) ReceiveFunding(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	milestoneID string,
+	amount float64,
+	releaseID string,
+) (string, error) {
+	// Retrieve campaign
+	platformKey := fmt.Sprintf("PLATFORM_%s", campaignID)
+	campaignJSON, err := ctx.GetStub().GetState(platformKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign Campaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+
+	// Post the receipt to PlatformOrg's journal (same channel, so the write is committed)
+	receipt := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{[]byte("RecordFundingReceipt"), []byte(releaseID), []byte(campaign.StartupID), []byte(strconv.FormatFloat(amount, 'f', -1, 64))},
+		"common-channel",
+	)
+	if receipt.Status != 200 {
+		return "", fmt.Errorf("failed to record funding receipt with PlatformOrg: %s", receipt.Message)
+	}
+
+	// amount may be gross or net of platform fees; PlatformOrg returns both from the release
+	var posted struct {
+		GrossAmount float64 `json:"grossAmount"`
+		Amount      float64 `json:"amount"`
+	}
+	if err := json.Unmarshal(receipt.Payload, &posted); err != nil {
+		return "", fmt.Errorf("failed to parse funding receipt: %v", err)
+	}
+
+	now := time.Now().Format(time.RFC3339)
+
+	// Update milestone
+	for i, m := range campaign.Milestones {
+		if m.MilestoneID == milestoneID {
+			campaign.Milestones[i].FundsReleased = true
+			campaign.Milestones[i].VerifiedAt = now
+			campaign.Milestones[i].Status = "VERIFIED"
+			break
+		}
+	}
+
+	// Update total funds
+	campaign.FundsRaisedAmount += posted.GrossAmount
+	if campaign.GoalAmount > 0 {
+		campaign.FundsRaisedPercent = (campaign.FundsRaisedAmount / campaign.GoalAmount) * 100
+	}
+	if campaign.FundsRaisedPercent >= 100 {
+		campaign.IsSuccessful = true
+	}
+	campaign.UpdatedAt = now
+
+	updatedCampaignJSON, err := json.Marshal(campaign)
+	if err != nil {
+		return "", err
+	}
+
+	err = ctx.GetStub().PutState(platformKey, updatedCampaignJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event on common-channel
+	eventPayload := map[string]interface{}{
+		"campaignId":       campaignID,
+		"milestoneId":      milestoneID,
+		"amount":           posted.Amount,
+		"grossAmount":      posted.GrossAmount,
+		"releaseId":        releaseID,
+		"totalFundsRaised": campaign.FundsRaisedAmount,
+		"percentComplete":  campaign.FundsRaisedPercent,
+		"action":           "FUNDING_RECEIVED",
+		"channel":          "common-channel",
+		"timestamp":        now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("FundingReceived", eventJSON)
+
+	response := map[string]interface{}{
+		"message":          "Funding received successfully",
+		"campaignId":       campaignID,
+		"milestoneId":      milestoneID,
+		"amountReceived":   posted.Amount,
+		"grossAmount":      posted.GrossAmount,
+		"totalFundsRaised": campaign.FundsRaisedAmount,
+		"percentComplete":  campaign.FundsRaisedPercent,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// SetFundingModel chooses how the campaign's escrow is settled at close: ALL_OR_NOTHING
+// (released only if the goal is met, refunded otherwise) or KEEP_IT_ALL (the default)
+// PlatformOrg accepts the change until the first agreement or pledge is escrowed
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (s *StartupContract) SetFundingModel(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	fundingModel string,
+) (string, error) {
+	// Retrieve campaign
+	platformKey := fmt.Sprintf("PLATFORM_%s", campaignID)
+	campaignJSON, err := ctx.GetStub().GetState(platformKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign Campaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+
+	// PlatformOrg validates and records the model on the published campaign (same channel, so the write is committed)
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{
+			[]byte("RecordFundingModel"),
+			[]byte(campaignID),
+			[]byte(campaign.StartupID),
+			[]byte(fundingModel),
+		},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("failed to record funding model with PlatformOrg: %s", response.Message)
+	}
+
+	now := time.Now().Format(time.RFC3339)
+
+	// Emit event on common-channel
+	eventPayload := map[string]interface{}{
+		"campaignId":   campaignID,
+		"startupId":    campaign.StartupID,
+		"fundingModel": fundingModel,
+		"action":       "FUNDING_MODEL_SET",
+		"channel":      "common-channel",
+		"timestamp":    now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("FundingModelSet", eventJSON)
+
+	return string(response.Payload), nil
+}
+
+// OfferShareClass makes the campaign an equity offering of a share class
+// Investors buy between minShares and maxShares at pricePerShare; PlatformOrg issues the
+// shares into the startup's cap table as agreements are witnessed
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (s *StartupContract) OfferShareClass(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	className string,
+	preMoneyValuation float64,
+	pricePerShare float64,
+	minShares int,
+	maxShares int,
+) (string, error) {
+	// Retrieve campaign
+	platformKey := fmt.Sprintf("PLATFORM_%s", campaignID)
+	campaignJSON, err := ctx.GetStub().GetState(platformKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign Campaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+
+	// PlatformOrg records the share class on the published campaign (same channel, so the write is committed)
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{
+			[]byte("RecordShareClass"),
+			[]byte(campaignID),
+			[]byte(campaign.StartupID),
+			[]byte(className),
+			[]byte(strconv.FormatFloat(preMoneyValuation, 'f', -1, 64)),
+			[]byte(strconv.FormatFloat(pricePerShare, 'f', -1, 64)),
+			[]byte(strconv.Itoa(minShares)),
+			[]byte(strconv.Itoa(maxShares)),
+		},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("failed to record share class with PlatformOrg: %s", response.Message)
+	}
+
+	now := time.Now().Format(time.RFC3339)
+
+	// Emit event on common-channel
+	eventPayload := map[string]interface{}{
+		"campaignId":        campaignID,
+		"startupId":         campaign.StartupID,
+		"className":         className,
+		"preMoneyValuation": preMoneyValuation,
+		"pricePerShare":     pricePerShare,
+		"minShares":         minShares,
+		"maxShares":         maxShares,
+		"action":            "SHARE_CLASS_OFFERED",
+		"channel":           "common-channel",
+		"timestamp":         now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("ShareClassOffered", eventJSON)
+
+	return string(response.Payload), nil
+}
+
+// OfferLoanTerms makes the campaign a lending campaign: investors fund a loan at interestRate
+// over termMonths, amortized EQUAL_PAYMENT, EQUAL_PRINCIPAL or INTEREST_ONLY
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (s *StartupContract) OfferLoanTerms(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	interestRate float64,
+	termMonths int,
+	amortizationType string,
+) (string, error) {
+	// Retrieve campaign
+	platformKey := fmt.Sprintf("PLATFORM_%s", campaignID)
+	campaignJSON, err := ctx.GetStub().GetState(platformKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign Campaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+
+	// PlatformOrg records the loan terms (same channel, so the write is committed)
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{
+			[]byte("RecordLoanTerms"),
+			[]byte(campaignID),
+			[]byte(campaign.StartupID),
+			[]byte(strconv.FormatFloat(interestRate, 'f', -1, 64)),
+			[]byte(strconv.Itoa(termMonths)),
+			[]byte(amortizationType),
+		},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("failed to record loan terms with PlatformOrg: %s", response.Message)
+	}
+
+	now := time.Now().Format(time.RFC3339)
+
+	// Emit event on common-channel
+	eventPayload := map[string]interface{}{
+		"campaignId":       campaignID,
+		"startupId":        campaign.StartupID,
+		"interestRate":     interestRate,
+		"termMonths":       termMonths,
+		"amortizationType": amortizationType,
+		"action":           "LOAN_TERMS_OFFERED",
+		"channel":          "common-channel",
+		"timestamp":        now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("LoanTermsOffered", eventJSON)
+
+	return string(response.Payload), nil
+}
+
+// RepayLoan repays the campaign's loan from the startup's cash
+// PlatformOrg applies it to the repayment schedule and pays the lenders pro-rata
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (s *StartupContract) RepayLoan(
+	ctx contractapi.TransactionContextInterface,
+	repaymentID string,
+	campaignID string,
+	amount float64,
+) (string, error) {
+	// Retrieve campaign
+	platformKey := fmt.Sprintf("PLATFORM_%s", campaignID)
+	campaignJSON, err := ctx.GetStub().GetState(platformKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign Campaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+
+	// PlatformOrg records the repayment and moves the tokens (same channel, so the write is committed)
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{
+			[]byte("RecordLoanRepayment"),
+			[]byte(repaymentID),
+			[]byte(campaignID),
+			[]byte(campaign.StartupID),
+			[]byte(strconv.FormatFloat(amount, 'f', -1, 64)),
+		},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("failed to record loan repayment with PlatformOrg: %s", response.Message)
+	}
+
+	now := time.Now().Format(time.RFC3339)
+
+	// Emit event on common-channel
+	eventPayload := map[string]interface{}{
+		"repaymentId": repaymentID,
+		"campaignId":  campaignID,
+		"startupId":   campaign.StartupID,
+		"amount":      amount,
+		"action":      "LOAN_REPAYMENT_SUBMITTED",
+		"channel":     "common-channel",
+		"timestamp":   now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("LoanRepaymentSubmitted", eventJSON)
+
+	return string(response.Payload), nil
+}
+
+// OfferRewardTier adds a reward tier backers can pledge to: a minimum pledge, an optional quantity
+// limit (0 for unlimited), an estimated delivery date and the regions it ships to
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (s *StartupContract) OfferRewardTier(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	tierID string,
+	title string,
+	description string,
+	pledgeAmount float64,
+	quantityLimit int,
+	estimatedDelivery string,
+	shippingRegionsJSON string,
+) (string, error) {
+	// Retrieve campaign
+	platformKey := fmt.Sprintf("PLATFORM_%s", campaignID)
+	campaignJSON, err := ctx.GetStub().GetState(platformKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign Campaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+
+	// PlatformOrg records the reward tier (same channel, so the write is committed)
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{
+			[]byte("RecordRewardTier"),
+			[]byte(campaignID),
+			[]byte(campaign.StartupID),
+			[]byte(tierID),
+			[]byte(title),
+			[]byte(description),
+			[]byte(strconv.FormatFloat(pledgeAmount, 'f', -1, 64)),
+			[]byte(strconv.Itoa(quantityLimit)),
+			[]byte(estimatedDelivery),
+			[]byte(shippingRegionsJSON),
+		},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("failed to record reward tier with PlatformOrg: %s", response.Message)
+	}
+
+	now := time.Now().Format(time.RFC3339)
+
+	// Emit event on common-channel
+	eventPayload := map[string]interface{}{
+		"campaignId":        campaignID,
+		"startupId":         campaign.StartupID,
+		"tierId":            tierID,
+		"pledgeAmount":      pledgeAmount,
+		"quantityLimit":     quantityLimit,
+		"estimatedDelivery": estimatedDelivery,
+		"action":            "REWARD_TIER_OFFERED",
+		"channel":           "common-channel",
+		"timestamp":         now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("RewardTierOffered", eventJSON)
+
+	return string(response.Payload), nil
+}
+
+// UpdateRewardFulfillment records the fulfillment status of a backer's reward (IN_PRODUCTION, SHIPPED, DELIVERED)
+// DELIVERED releases the backer's pledge from escrow to the startup
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (s *StartupContract) UpdateRewardFulfillment(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	pledgeID string,
+	fulfillmentStatus string,
+	trackingReference string,
+) (string, error) {
+	// Retrieve campaign
+	platformKey := fmt.Sprintf("PLATFORM_%s", campaignID)
+	campaignJSON, err := ctx.GetStub().GetState(platformKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign Campaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+
+	// PlatformOrg records the fulfillment and releases delivered pledges (same channel, so the write is committed)
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{
+			[]byte("RecordRewardFulfillment"),
+			[]byte(pledgeID),
+			[]byte(campaign.StartupID),
+			[]byte(fulfillmentStatus),
+			[]byte(trackingReference),
+		},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("failed to record reward fulfillment with PlatformOrg: %s", response.Message)
+	}
+
+	now := time.Now().Format(time.RFC3339)
+
+	// Emit event on common-channel
+	eventPayload := map[string]interface{}{
+		"campaignId":        campaignID,
+		"startupId":         campaign.StartupID,
+		"pledgeId":          pledgeID,
+		"fulfillmentStatus": fulfillmentStatus,
+		"trackingReference": trackingReference,
+		"action":            "REWARD_FULFILLMENT_UPDATED",
+		"channel":           "common-channel",
+		"timestamp":         now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("RewardFulfillmentUpdated", eventJSON)
+
+	return string(response.Payload), nil
+}
+
+// DeclareDistribution declares a return to the campaign's investors (dividend, revenue share, exit proceeds)
+// PlatformOrg computes each investor's payout from their witnessed agreements
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (s *StartupContract) DeclareDistribution(
+	ctx contractapi.TransactionContextInterface,
+	distributionID string,
+	campaignID string,
+	distributionType string, // DIVIDEND, REVENUE_SHARE, EXIT_PROCEEDS
+	totalAmount float64,
+	currency string,
+	description string,
+) (string, error) {
+	// Retrieve campaign
+	platformKey := fmt.Sprintf("PLATFORM_%s", campaignID)
+	campaignJSON, err := ctx.GetStub().GetState(platformKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign Campaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+
+	// PlatformOrg records the distribution and its payout lines (same channel, so the write is committed)
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{
+			[]byte("RecordDistribution"),
+			[]byte(distributionID),
+			[]byte(campaignID),
+			[]byte(campaign.StartupID),
+			[]byte(distributionType),
+			[]byte(strconv.FormatFloat(totalAmount, 'f', -1, 64)),
+			[]byte(currency),
+			[]byte(description),
+		},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("failed to record distribution with PlatformOrg: %s", response.Message)
+	}
+
+	now := time.Now().Format(time.RFC3339)
+
+	// Emit event on common-channel
+	eventPayload := map[string]interface{}{
+		"distributionId":   distributionID,
+		"campaignId":       campaignID,
+		"startupId":        campaign.StartupID,
+		"distributionType": distributionType,
+		"totalAmount":      totalAmount,
+		"currency":         currency,
+		"action":           "DISTRIBUTION_DECLARED",
+		"channel":          "common-channel",
+		"timestamp":        now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("DistributionDeclared", eventJSON)
+
+	return string(response.Payload), nil
+}
+
+// OfferRevenueShareTerms makes the campaign a revenue-share campaign: investors together are owed
+// revenuePercent of each period's revenue, split pro-rata by invested amount, until each has been
+// repaid capMultiple times their investment. reportingFrequency: MONTHLY, QUARTERLY or ANNUAL
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (s *StartupContract) OfferRevenueShareTerms(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	revenuePercent float64,
+	capMultiple float64,
+	reportingFrequency string,
+	firstPeriodStart string, // YYYY-MM-DD
+) (string, error) {
+	// Retrieve campaign
+	platformKey := fmt.Sprintf("PLATFORM_%s", campaignID)
+	campaignJSON, err := ctx.GetStub().GetState(platformKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign: %v", err)
+	}
+	if campaignJSON == nil {
+		return "", fmt.Errorf("campaign %s does not exist", campaignID)
+	}
+
+	var campaign Campaign
+	err = json.Unmarshal(campaignJSON, &campaign)
+	if err != nil {
+		return "", err
+	}
+
+	// PlatformOrg records the revenue-share terms (same channel, so the write is committed)
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{
+			[]byte("RecordRevenueShareTerms"),
+			[]byte(campaignID),
+			[]byte(campaign.StartupID),
+			[]byte(strconv.FormatFloat(revenuePercent, 'f', -1, 64)),
+			[]byte(strconv.FormatFloat(capMultiple, 'f', -1, 64)),
+			[]byte(reportingFrequency),
+			[]byte(firstPeriodStart),
+		},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("failed to record revenue-share terms with PlatformOrg: %s", response.Message)
+	}
+
+	now := time.Now().Format(time.RFC3339)
+
+	// Emit event on common-channel
+	eventPayload := map[string]interface{}{
+		"campaignId":         campaignID,
+		"startupId":          campaign.StartupID,
+		"revenuePercent":     revenuePercent,
+		"capMultiple":        capMultiple,
+		"reportingFrequency": reportingFrequency,
+		"action":             "REVENUE_SHARE_TERMS_OFFERED",
+		"channel":            "common-channel",
+		"timestamp":          now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("RevenueShareTermsOffered", eventJSON)
+
+	return string(response.Payload), nil
 }
 
-// ReceiveFunding records funding received for a milestone
-// Step 13: Startup receives funding from Platform escrow on common-channel
+// SubmitRevenueReport reports the startup's revenue for a period to its revenue-share investors
+// PlatformOrg splits the campaign's revenue share pro-rata, flags late reports and closes agreements at their cap
 // Channel: common-channel
-// Endorsers: All Organizations
-func (s *StartupContract) ReceiveFunding(
+// Endorsers: StartupOrg, PlatformOrg
+func (s *StartupContract) SubmitRevenueReport(
 	ctx contractapi.TransactionContextInterface,
+	reportID string,
 	campaignID string,
-	milestoneID string,
-	amount float64,
-	releaseID string,
+	periodStart string, // YYYY-MM-DD
+	periodEnd string, // YYYY-MM-DD
+	revenue float64,
+	currency string,
+	reportHash string,
 ) (string, error) {
 	// Retrieve campaign
 	platformKey := fmt.Sprintf("PLATFORM_%s", campaignID)
@@ -909,63 +1590,45 @@ func (s *StartupContract) ReceiveFunding(
 		return "", err
 	}
 
-	now := time.Now().Format(time.RFC3339)
-
-	// Update milestone
-	for i, m := range campaign.Milestones {
-		if m.MilestoneID == milestoneID {
-			campaign.Milestones[i].FundsReleased = true
-			campaign.Milestones[i].VerifiedAt = now
-			campaign.Milestones[i].Status = "VERIFIED"
-			break
-		}
-	}
-
-	// Update total funds
-	campaign.FundsRaisedAmount += amount
-	if campaign.GoalAmount > 0 {
-		campaign.FundsRaisedPercent = (campaign.FundsRaisedAmount / campaign.GoalAmount) * 100
-	}
-	if campaign.FundsRaisedPercent >= 100 {
-		campaign.IsSuccessful = true
-	}
-	campaign.UpdatedAt = now
-
-	updatedCampaignJSON, err := json.Marshal(campaign)
-	if err != nil {
-		return "", err
+	// PlatformOrg records the report and its obligations (same channel, so the write is committed)
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{
+			[]byte("RecordRevenueReport"),
+			[]byte(reportID),
+			[]byte(campaignID),
+			[]byte(campaign.StartupID),
+			[]byte(periodStart),
+			[]byte(periodEnd),
+			[]byte(strconv.FormatFloat(revenue, 'f', -1, 64)),
+			[]byte(currency),
+			[]byte(reportHash),
+		},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("failed to record revenue report with PlatformOrg: %s", response.Message)
 	}
 
-	err = ctx.GetStub().PutState(platformKey, updatedCampaignJSON)
-	if err != nil {
-		return "", err
-	}
+	now := time.Now().Format(time.RFC3339)
 
 	// Emit event on common-channel
 	eventPayload := map[string]interface{}{
-		"campaignId":       campaignID,
-		"milestoneId":      milestoneID,
-		"amount":           amount,
-		"releaseId":        releaseID,
-		"totalFundsRaised": campaign.FundsRaisedAmount,
-		"percentComplete":  campaign.FundsRaisedPercent,
-		"action":           "FUNDING_RECEIVED",
-		"channel":          "common-channel",
-		"timestamp":        now,
+		"reportId":    reportID,
+		"campaignId":  campaignID,
+		"startupId":   campaign.StartupID,
+		"periodStart": periodStart,
+		"periodEnd":   periodEnd,
+		"revenue":     revenue,
+		"currency":    currency,
+		"action":      "REVENUE_REPORT_SUBMITTED",
+		"channel":     "common-channel",
+		"timestamp":   now,
 	}
 	eventJSON, _ := json.Marshal(eventPayload)
-	ctx.GetStub().SetEvent("FundingReceived", eventJSON)
+	ctx.GetStub().SetEvent("RevenueReportSubmitted", eventJSON)
 
-	response := map[string]interface{}{
-		"message":          "Funding received successfully",
-		"campaignId":       campaignID,
-		"milestoneId":      milestoneID,
-		"amountReceived":   amount,
-		"totalFundsRaised": campaign.FundsRaisedAmount,
-		"percentComplete":  campaign.FundsRaisedPercent,
-	}
-	responseJSON, _ := json.Marshal(response)
-	return string(responseJSON), nil
+	return string(response.Payload), nil
 }
 
 // ============================================================================
@@ -1057,6 +1720,56 @@ func (s *StartupContract) AcknowledgeInvestment(
 // Read by: All Orgs, Write by: StartupOrg (hash only - privacy preserving)
 // ============================================================================
 
+// PublishAgreementAcceptance publishes the terms the startup accepted in RespondToInvestmentProposal
+// PlatformOrg witnesses the agreement only when they match the terms the investor accepted
+// Channel: common-channel
+// Endorsers: StartupOrg, PlatformOrg
+func (s *StartupContract) PublishAgreementAcceptance(
+	ctx contractapi.TransactionContextInterface,
+	agreementID string,
+) (string, error) {
+	// Cross-channel read of the agreement as negotiated with the investor
+	agreementResponse := ctx.GetStub().InvokeChaincode(
+		"startuporg",
+		[][]byte{[]byte("GetAgreement"), []byte(agreementID)},
+		"startup-investor-channel",
+	)
+	if agreementResponse.Status != 200 {
+		return "", fmt.Errorf("cross-channel query to startup-investor-channel failed: %s", agreementResponse.Message)
+	}
+
+	var agreement Agreement
+	if err := json.Unmarshal(agreementResponse.Payload, &agreement); err != nil {
+		return "", fmt.Errorf("failed to parse agreement: %v", err)
+	}
+	if !agreement.StartupAccepted {
+		return "", fmt.Errorf("agreement %s has not been accepted by the startup", agreementID)
+	}
+
+	// PlatformOrg records the accepted terms (same channel, so the write is committed)
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{[]byte("RecordAgreementAcceptance"), []byte(agreementID), []byte("STARTUP"), agreement.NegotiatedTerms, []byte(agreement.TermsHash)},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("failed to record agreement acceptance with PlatformOrg: %s", response.Message)
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"agreementId": agreementID,
+		"termsHash":   agreement.TermsHash,
+		"channel":     "common-channel",
+		"action":      "STARTUP_ACCEPTANCE_PUBLISHED",
+		"timestamp":   time.Now().Format(time.RFC3339),
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("StartupAcceptancePublished", eventJSON)
+
+	return string(response.Payload), nil
+}
+
 // PublishSummaryHash publishes campaign summary hash to common-channel
 // Channel: common-channel
 // Purpose: Privacy-preserving summary for all organizations (no sensitive data)
@@ -1273,6 +1986,288 @@ func (s *StartupContract) GetMilestoneReport(ctx contractapi.TransactionContextI
 	return string(reportJSON), nil
 }
 
+// GetCampaignsByValidationStatus returns campaigns in a validation status (e.g. PENDING_VALIDATION, ON_HOLD)
+// Read by ValidatorOrg to rebuild its work queues without relying on events
+func (s *StartupContract) GetCampaignsByValidationStatus(ctx contractapi.TransactionContextInterface, validationStatus string) (string, error) {
+	records, err := getQueryResults(ctx, map[string]interface{}{
+		"validationStatus": validationStatus,
+		"campaignId":       map[string]bool{"$exists": true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	campaigns := []map[string]interface{}{}
+	for _, record := range records {
+		var campaign Campaign
+		// Only the primary record tracks validation status
+		if json.Unmarshal(record.Value, &campaign) != nil || record.Key != campaign.CampaignID {
+			continue
+		}
+		campaigns = append(campaigns, map[string]interface{}{
+			"Key":    record.Key,
+			"Record": campaign,
+		})
+	}
+
+	campaignsJSON, err := json.Marshal(campaigns)
+	if err != nil {
+		return "", err
+	}
+
+	return string(campaignsJSON), nil
+}
+
+// GetMilestoneReportsByStatus returns milestone reports in a status (SUBMITTED, UNDER_REVIEW, APPROVED, REJECTED)
+func (s *StartupContract) GetMilestoneReportsByStatus(ctx contractapi.TransactionContextInterface, status string) (string, error) {
+	records, err := getQueryResults(ctx, map[string]interface{}{
+		"status":      status,
+		"reportId":    map[string]bool{"$exists": true},
+		"milestoneId": map[string]bool{"$exists": true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	reports := []map[string]interface{}{}
+	for _, record := range records {
+		var report MilestoneReport
+		if json.Unmarshal(record.Value, &report) != nil {
+			continue
+		}
+		reports = append(reports, map[string]interface{}{
+			"Key":    record.Key,
+			"Record": report,
+		})
+	}
+
+	reportsJSON, err := json.Marshal(reports)
+	if err != nil {
+		return "", err
+	}
+
+	return string(reportsJSON), nil
+}
+
+// GetStartupDashboard returns an overview of one startup's campaigns, agreements and milestones
+// daysAhead controls the window for upcoming milestones
+func (s *StartupContract) GetStartupDashboard(ctx contractapi.TransactionContextInterface, startupID string, daysAhead int) (string, error) {
+	if daysAhead < 0 {
+		return "", fmt.Errorf("daysAhead must not be negative")
+	}
+
+	now, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	// Milestones due today are still upcoming, so compare whole days
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	horizon := today.AddDate(0, 0, daysAhead)
+
+	// Campaigns are stored under several keys (campaignID, PLATFORM_, INVESTOR_CAMPAIGN_);
+	// keep the most recently updated copy of each
+	campaignRecords, err := getQueryResults(ctx, map[string]interface{}{
+		"startupId":        startupID,
+		"campaignId":       map[string]bool{"$exists": true},
+		"validationStatus": map[string]bool{"$exists": true},
+	})
+	if err != nil {
+		return "", err
+	}
+	latest := map[string]Campaign{}
+	for _, record := range campaignRecords {
+		var campaign Campaign
+		if json.Unmarshal(record.Value, &campaign) != nil {
+			continue
+		}
+		if existing, ok := latest[campaign.CampaignID]; !ok || campaign.UpdatedAt > existing.UpdatedAt {
+			latest[campaign.CampaignID] = campaign
+		}
+	}
+
+	campaignIDs := make([]string, 0, len(latest))
+	for campaignID := range latest {
+		campaignIDs = append(campaignIDs, campaignID)
+	}
+	sort.Strings(campaignIDs)
+
+	campaigns := []map[string]interface{}{}
+	upcomingMilestones := []map[string]interface{}{}
+	for _, campaignID := range campaignIDs {
+		campaign := latest[campaignID]
+		campaigns = append(campaigns, map[string]interface{}{
+			"campaignId":         campaign.CampaignID,
+			"projectName":        campaign.ProjectName,
+			"status":             campaign.Status,
+			"validationStatus":   campaign.ValidationStatus,
+			"platformStatus":     campaign.PlatformStatus,
+			"goalAmount":         campaign.GoalAmount,
+			"fundsRaisedAmount":  campaign.FundsRaisedAmount,
+			"fundsRaisedPercent": campaign.FundsRaisedPercent,
+			"currency":           campaign.Currency,
+			"investorCount":      campaign.InvestorCount,
+			"closeDate":          campaign.CloseDate,
+		})
+
+		for _, m := range campaign.Milestones {
+			if m.Status == "COMPLETED" || m.Status == "VERIFIED" {
+				continue
+			}
+			targetDate, err := parseDate(m.TargetDate)
+			if err != nil || targetDate.Before(today) || targetDate.After(horizon) {
+				continue
+			}
+			upcomingMilestones = append(upcomingMilestones, map[string]interface{}{
+				"campaignId":   campaign.CampaignID,
+				"milestoneId":  m.MilestoneID,
+				"title":        m.Title,
+				"targetAmount": m.TargetAmount,
+				"targetDate":   m.TargetDate,
+				"status":       m.Status,
+				"daysUntilDue": int(targetDate.Sub(today).Hours() / 24),
+			})
+		}
+	}
+	sort.SliceStable(upcomingMilestones, func(a, b int) bool {
+		return upcomingMilestones[a]["targetDate"].(string) < upcomingMilestones[b]["targetDate"].(string)
+	})
+
+	// Open proposals are the investment proposals InvestorOrg still awaits a response on
+	// Cross-channel READ on startup-investor-channel
+	response := ctx.GetStub().InvokeChaincode(
+		"investororg",
+		[][]byte{[]byte("GetProposalsByStartup"), []byte(startupID), []byte("")},
+		"startup-investor-channel",
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("cross-channel query to InvestorOrg failed: %s", response.Message)
+	}
+	var proposalRecords []struct {
+		Key    string                 `json:"Key"`
+		Record map[string]interface{} `json:"Record"`
+	}
+	if err := json.Unmarshal(response.Payload, &proposalRecords); err != nil {
+		return "", fmt.Errorf("failed to parse proposals: %v", err)
+	}
+	openProposals := []map[string]interface{}{}
+	for _, record := range proposalRecords {
+		if status, _ := record.Record["status"].(string); status == "PROPOSED" || status == "COUNTERED" {
+			openProposals = append(openProposals, record.Record)
+		}
+	}
+
+	// Active agreements
+	agreementRecords, err := getQueryResults(ctx, map[string]interface{}{
+		"startupId":   startupID,
+		"agreementId": map[string]bool{"$exists": true},
+	})
+	if err != nil {
+		return "", err
+	}
+	activeAgreements := []Agreement{}
+	for _, record := range agreementRecords {
+		var agreement Agreement
+		if json.Unmarshal(record.Value, &agreement) != nil || record.Key != agreement.AgreementID {
+			continue
+		}
+		if agreement.Status == "ACCEPTED" || agreement.Status == "ACTIVE" {
+			activeAgreements = append(activeAgreements, agreement)
+		}
+	}
+
+	// Milestone reports not yet reviewed by ValidatorOrg
+	pendingReports := []MilestoneReport{}
+	if len(campaignIDs) > 0 {
+		reportRecords, err := getQueryResults(ctx, map[string]interface{}{
+			"campaignId":  map[string]interface{}{"$in": campaignIDs},
+			"reportId":    map[string]bool{"$exists": true},
+			"milestoneId": map[string]bool{"$exists": true},
+			"status":      map[string]interface{}{"$in": []string{"SUBMITTED", "UNDER_REVIEW"}},
+		})
+		if err != nil {
+			return "", err
+		}
+		for _, record := range reportRecords {
+			var report MilestoneReport
+			if json.Unmarshal(record.Value, &report) != nil {
+				continue
+			}
+			pendingReports = append(pendingReports, report)
+		}
+		sort.SliceStable(pendingReports, func(a, b int) bool {
+			return pendingReports[a].SubmittedAt < pendingReports[b].SubmittedAt
+		})
+	}
+
+	dashboard := map[string]interface{}{
+		"startupId":          startupID,
+		"campaigns":          campaigns,
+		"openProposals":      openProposals,
+		"activeAgreements":   activeAgreements,
+		"upcomingMilestones": upcomingMilestones,
+		"daysAhead":          daysAhead,
+		"pendingReports":     pendingReports,
+		"generatedAt":        now.Format(time.RFC3339),
+	}
+	responseJSON, err := json.Marshal(dashboard)
+	if err != nil {
+		return "", err
+	}
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// LEDGER HELPERS
+// ============================================================================
+
+// queryRecord is a single key/value returned by a rich query
+type queryRecord struct {
+	Key   string
+	Value []byte
+}
+
+// getQueryResults runs a CouchDB selector query and collects all matching records
+func getQueryResults(ctx contractapi.TransactionContextInterface, selector map[string]interface{}) ([]queryRecord, error) {
+	queryJSON, err := json.Marshal(map[string]interface{}{"selector": selector})
+	if err != nil {
+		return nil, err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetQueryResult(string(queryJSON))
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	var records []queryRecord
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, queryRecord{Key: queryResponse.Key, Value: queryResponse.Value})
+	}
+
+	return records, nil
+}
+
+// getTxTime returns the transaction timestamp so every endorser sees the same time
+func getTxTime(ctx contractapi.TransactionContextInterface) (time.Time, error) {
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read transaction timestamp: %v", err)
+	}
+	return txTimestamp.AsTime().UTC(), nil
+}
+
+// parseDate parses dates given as YYYY-MM-DD or RFC3339
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
 // ============================================================================
 // CROSS-CHANNEL INVOCATION HELPER FUNCTIONS
 // ============================================================================
@@ -1292,6 +2287,8 @@ func (s *StartupContract) InvokePlatformOrgPublish(
 	closeDate string,
 	durationDays string,
 	validationScore string,
+	validationHash string,
+	milestonesJSON string,
 ) (string, error) {
 	// Prepare arguments for cross-channel invocation
 	args := [][]byte{
@@ -1307,6 +2304,8 @@ func (s *StartupContract) InvokePlatformOrgPublish(
 		[]byte(closeDate),
 		[]byte(durationDays),
 		[]byte(validationScore),
+		[]byte(validationHash),
+		[]byte(milestonesJSON),
 	}
 
 	// Cross-channel invocation to platformorg on startup-platform-channel
@@ -1363,6 +2362,32 @@ func (s *StartupContract) InvokeInvestorOrgNotify(
 	return string(response.Payload), nil
 }
 
+// InvokeInvestorOrgGetProposals lists investment proposals received by a startup from InvestorOrg
+// Cross-channel READ on startup-investor-channel (status optional)
+func (s *StartupContract) InvokeInvestorOrgGetProposals(
+	ctx contractapi.TransactionContextInterface,
+	startupID string,
+	status string,
+) (string, error) {
+	args := [][]byte{
+		[]byte("GetProposalsByStartup"),
+		[]byte(startupID),
+		[]byte(status),
+	}
+
+	response := ctx.GetStub().InvokeChaincode(
+		"investororg",
+		args,
+		"startup-investor-channel",
+	)
+
+	if response.Status != 200 {
+		return "", fmt.Errorf("cross-channel query to InvestorOrg failed: %s", response.Message)
+	}
+
+	return string(response.Payload), nil
+}
+
 // generateHash generates SHA256 hash
 func generateHash(data string) string {
 	hash := sha256.Sum256([]byte(data))
@@ -1384,6 +2409,80 @@ func generateCampaignHash(campaign Campaign) string {
 	return hex.EncodeToString(hash[:])
 }
 
+// evaluatedTerms is a term sheet as normalized, hashed and diffed by PlatformOrg
+type evaluatedTerms struct {
+	Terms      json.RawMessage `json:"terms"`
+	TermsHash  string          `json:"termsHash"`
+	Instrument json.RawMessage `json:"instrument"`
+	Diff       json.RawMessage `json:"diff"`
+	Changes    int             `json:"changes"`
+}
+
+// termsDocument builds a term sheet from an offer's terms argument
+// A JSON object is the structured term sheet; free text keeps the previous terms, with the text as their notes
+func termsDocument(offerTerms string, previous json.RawMessage) (map[string]interface{}, error) {
+	document := map[string]interface{}{}
+	if strings.HasPrefix(strings.TrimSpace(offerTerms), "{") {
+		if err := json.Unmarshal([]byte(offerTerms), &document); err != nil {
+			return nil, fmt.Errorf("failed to parse negotiation terms: %v", err)
+		}
+		return document, nil
+	}
+
+	if len(previous) > 0 {
+		if err := json.Unmarshal(previous, &document); err != nil {
+			return nil, fmt.Errorf("failed to parse previous terms: %v", err)
+		}
+	}
+	if offerTerms != "" {
+		document["notes"] = offerTerms
+	}
+	return document, nil
+}
+
+// evaluateNegotiationTerms has PlatformOrg normalize and hash a term sheet, and diff it against the previous one
+func evaluateNegotiationTerms(ctx contractapi.TransactionContextInterface, previous json.RawMessage, document map[string]interface{}) (*evaluatedTerms, error) {
+	documentJSON, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		[][]byte{[]byte("EvaluateNegotiationTerms"), previous, documentJSON},
+		"common-channel",
+	)
+	if response.Status != 200 {
+		return nil, fmt.Errorf("failed to evaluate negotiation terms with PlatformOrg: %s", response.Message)
+	}
+
+	var terms evaluatedTerms
+	if err := json.Unmarshal(response.Payload, &terms); err != nil {
+		return nil, fmt.Errorf("failed to parse evaluated terms: %v", err)
+	}
+	return &terms, nil
+}
+
+// agreementTerms returns the agreement's current term sheet, deriving one from its fields when none was negotiated yet
+func agreementTerms(agreement Agreement) (json.RawMessage, error) {
+	if agreement.TermsHash != "" {
+		return agreement.NegotiatedTerms, nil
+	}
+	milestoneTerms := []map[string]interface{}{}
+	for _, milestone := range agreement.Milestones {
+		milestoneTerms = append(milestoneTerms, map[string]interface{}{
+			"milestoneId": milestone.MilestoneID,
+			"title":       milestone.Title,
+			"targetDate":  milestone.TargetDate,
+		})
+	}
+	return json.Marshal(map[string]interface{}{
+		"amount":     agreement.InvestmentAmount,
+		"currency":   agreement.Currency,
+		"milestones": milestoneTerms,
+	})
+}
+
 func main() {
 	startupChaincode, err := contractapi.NewChaincode(&StartupContract{})
 	if err != nil {
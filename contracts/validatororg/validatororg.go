@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
@@ -109,6 +111,24 @@ type MilestoneValidation struct {
 	VerifiedAt           string  `json:"verifiedAt"`
 }
 
+// MilestoneAttestation publishes a milestone validation to common-channel
+// PlatformOrg reads it as release evidence; the hash lets anyone on
+// startup-validator-channel check it against the full MilestoneValidation
+type MilestoneAttestation struct {
+	AttestationID       string  `json:"attestationId"`
+	VerificationID      string  `json:"verificationId"`
+	MilestoneID         string  `json:"milestoneId"`
+	CampaignID          string  `json:"campaignId"`
+	StartupID           string  `json:"startupId"`
+	MilestoneReportHash string  `json:"milestoneReportHash"`
+	QualityScore        float64 `json:"qualityScore"`
+	Approved            bool    `json:"approved"`
+	ValidationHash      string  `json:"validationHash"` // SHA256 of the MilestoneValidation record
+	AttestedAt          string  `json:"attestedAt"`
+	RevokedByDispute    string  `json:"revokedByDispute"` // Dispute that re-opened the milestone
+	RevokedAt           string  `json:"revokedAt"`
+}
+
 // AgreementWitness represents Validator witnessing an agreement
 // Used in Phase 9: common-channel
 type AgreementWitness struct {
@@ -118,10 +138,103 @@ type AgreementWitness struct {
 	StartupID         string  `json:"startupId"`
 	InvestorID        string  `json:"investorId"`
 	InvestmentAmount  float64 `json:"investmentAmount"`
+	TermsHash         string  `json:"termsHash"` // Normalized terms hash the validator signed against
 	ValidatorComments string  `json:"validatorComments"`
 	WitnessedAt       string  `json:"witnessedAt"`
 }
 
+// ValidatorAssignment records which validator owns a queued work item
+type ValidatorAssignment struct {
+	AssignmentID string `json:"assignmentId"`
+	ItemType     string `json:"itemType"` // CAMPAIGN, MILESTONE_REPORT, RISK_REQUEST
+	ItemID       string `json:"itemId"`
+	ValidatorID  string `json:"validatorId"`
+	AssignedAt   string `json:"assignedAt"`
+}
+
+// WorkQueueItem is one entry in a validator work queue
+type WorkQueueItem struct {
+	ItemType          string  `json:"itemType"`
+	ItemID            string  `json:"itemId"`
+	CampaignID        string  `json:"campaignId"`
+	Summary           string  `json:"summary"`
+	Status            string  `json:"status"`
+	QueuedAt          string  `json:"queuedAt"`
+	AgeHours          float64 `json:"ageHours"`
+	AssignedValidator string  `json:"assignedValidator"`
+}
+
+// Dispute is a formal challenge against an agreement, milestone, escrow or validation
+// It runs on the claimant's bilateral channel with ValidatorOrg, which arbitrates
+type Dispute struct {
+	DisputeID       string            `json:"disputeId"`
+	EntityType      string            `json:"entityType"` // AGREEMENT, MILESTONE, ESCROW, VALIDATION
+	EntityID        string            `json:"entityId"`
+	CampaignID      string            `json:"campaignId"`
+	ClaimantType    string            `json:"claimantType"` // INVESTOR, STARTUP, PLATFORM
+	ClaimantID      string            `json:"claimantId"`
+	RespondentType  string            `json:"respondentType"`
+	RespondentID    string            `json:"respondentId"`
+	Reason          string            `json:"reason"`
+	RequestedRemedy string            `json:"requestedRemedy"` // RELEASE, REFUND, REVALIDATE, REOPEN_MILESTONE
+	Channel         string            `json:"channel"`         // Claimant's channel with ValidatorOrg
+	Status          string            `json:"status"`          // OPEN, UNDER_ARBITRATION, DECIDED
+	ArbitratorID    string            `json:"arbitratorId"`
+	Evidence        []DisputeEvidence `json:"evidence"`
+	Decision        DisputeDecision   `json:"decision"`
+	FollowUpStatus  string            `json:"followUpStatus"` // NONE, PENDING, APPLIED
+	OpenedAt        string            `json:"openedAt"`
+	UpdatedAt       string            `json:"updatedAt"`
+}
+
+// DisputeEvidence is a hash of one side's supporting documents
+type DisputeEvidence struct {
+	Party          string `json:"party"` // CLAIMANT, RESPONDENT
+	PartyID        string `json:"partyId"`
+	EvidenceHash   string `json:"evidenceHash"`
+	Description    string `json:"description"`
+	Channel        string `json:"channel"` // Channel the evidence was submitted on
+	SubmittedByMSP string `json:"submittedByMsp"`
+	SubmittedAt    string `json:"submittedAt"`
+}
+
+// DisputeEvidenceLog holds evidence a respondent submitted on their own channel with ValidatorOrg
+type DisputeEvidenceLog struct {
+	DisputeID string            `json:"disputeId"`
+	Evidence  []DisputeEvidence `json:"evidence"`
+}
+
+// DisputeDecision is the arbitrator's binding decision
+type DisputeDecision struct {
+	Outcome      string  `json:"outcome"` // UPHELD, DISMISSED
+	Remedy       string  `json:"remedy"`  // RELEASE, REFUND, REVALIDATE, REOPEN_MILESTONE, NONE
+	EscrowID     string  `json:"escrowId"`
+	MilestoneID  string  `json:"milestoneId"`
+	Amount       float64 `json:"amount"` // Amount to release (RELEASE only)
+	Rationale    string  `json:"rationale"`
+	DecisionHash string  `json:"decisionHash"` // SHA256 of the decision and all evidence hashes
+	DecidedBy    string  `json:"decidedBy"`
+	DecidedAt    string  `json:"decidedAt"`
+}
+
+// DisputeSummary is the decided dispute published to common-channel
+// PlatformOrg executes RELEASE and REFUND remedies from it
+type DisputeSummary struct {
+	SummaryID   string  `json:"summaryId"`
+	DisputeID   string  `json:"disputeId"`
+	EntityType  string  `json:"entityType"`
+	EntityID    string  `json:"entityId"`
+	CampaignID  string  `json:"campaignId"`
+	Channel     string  `json:"channel"`
+	Outcome     string  `json:"outcome"`
+	Remedy      string  `json:"remedy"`
+	EscrowID    string  `json:"escrowId"`
+	MilestoneID string  `json:"milestoneId"`
+	Amount      float64 `json:"amount"`
+	SummaryHash string  `json:"summaryHash"` // SHA256 of the full dispute record on its channel
+	PublishedAt string  `json:"publishedAt"`
+}
+
 // InitLedger initializes the ValidatorOrg ledger
 func (v *ValidatorContract) InitLedger(ctx contractapi.TransactionContextInterface) error {
 	fmt.Println("ValidatorOrg contract initialized - Merged Version")
@@ -533,7 +646,7 @@ func (v *ValidatorContract) VerifyMilestoneCompletion(
 		"approved":       approved,
 		"status":         status,
 		"qualityScore":   qualityScore,
-		"nextStep":       "Platform to release funds from escrow on common-channel",
+		"nextStep":       "Attest on common-channel with AttestMilestoneValidation for fund release",
 	}
 	responseJSON, _ := json.Marshal(response)
 	return string(responseJSON), nil
@@ -759,6 +872,12 @@ func (v *ValidatorContract) WitnessAgreement(
 	investmentAmount float64,
 	validatorComments string,
 ) (string, error) {
+	// Validators sign against the same normalized terms Platform witnessed and both parties accepted
+	termsHash, err := witnessedTermsHash(ctx, agreementID)
+	if err != nil {
+		return "", err
+	}
+
 	now := time.Now().Format(time.RFC3339)
 
 	// Create witness record
@@ -769,6 +888,7 @@ func (v *ValidatorContract) WitnessAgreement(
 		StartupID:         startupID,
 		InvestorID:        investorID,
 		InvestmentAmount:  investmentAmount,
+		TermsHash:         termsHash,
 		ValidatorComments: validatorComments,
 		WitnessedAt:       now,
 	}
@@ -796,6 +916,7 @@ func (v *ValidatorContract) WitnessAgreement(
 		"startupId":        startupID,
 		"investorId":       investorID,
 		"investmentAmount": investmentAmount,
+		"termsHash":        termsHash,
 		"channel":          "common-channel",
 		"action":           "VALIDATOR_WITNESSED_AGREEMENT",
 		"timestamp":        now,
@@ -808,6 +929,7 @@ func (v *ValidatorContract) WitnessAgreement(
 		"witnessId":        witnessID,
 		"agreementId":      agreementID,
 		"investmentAmount": investmentAmount,
+		"termsHash":        termsHash,
 		"witnessedAt":      now,
 	}
 	responseJSON, _ := json.Marshal(response)
@@ -875,6 +997,112 @@ func (v *ValidatorContract) ConfirmCampaignCompletion(
 	return string(responseJSON), nil
 }
 
+// AttestMilestoneValidation publishes the milestone validation to common-channel as release evidence
+// Reads MilestoneValidation from startup-validator-channel and stores its hash
+// Attestations are keyed by campaign and milestone and cannot be overwritten
+// unless a dispute re-opened the milestone
+// Channel: common-channel
+// Endorsers: ValidatorOrg (multi-party visibility)
+func (v *ValidatorContract) AttestMilestoneValidation(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+	milestoneID string,
+) (string, error) {
+	if err := requireMSP(ctx, "ValidatorOrgMSP"); err != nil {
+		return "", err
+	}
+
+	attestationID := milestoneAttestationKey(campaignID, milestoneID)
+	existingJSON, err := ctx.GetStub().GetState(attestationID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read milestone attestation: %v", err)
+	}
+	if existingJSON != nil {
+		var existing MilestoneAttestation
+		if err := json.Unmarshal(existingJSON, &existing); err != nil {
+			return "", err
+		}
+		if existing.RevokedByDispute == "" {
+			return "", fmt.Errorf("milestone %s of campaign %s has already been attested", milestoneID, campaignID)
+		}
+	}
+
+	// Cross-channel read of the validation recorded in Phase 12
+	response := ctx.GetStub().InvokeChaincode(
+		"validatororg",
+		[][]byte{[]byte("GetMilestoneValidation"), []byte(milestoneID)},
+		"startup-validator-channel",
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("cross-channel query to startup-validator-channel failed: %s", response.Message)
+	}
+
+	var validation MilestoneValidation
+	if err := json.Unmarshal(response.Payload, &validation); err != nil {
+		return "", fmt.Errorf("failed to parse milestone validation: %v", err)
+	}
+	if validation.CampaignID != campaignID {
+		return "", fmt.Errorf("milestone %s was validated for campaign %s, not %s", milestoneID, validation.CampaignID, campaignID)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	attestation := MilestoneAttestation{
+		AttestationID:       attestationID,
+		VerificationID:      validation.VerificationID,
+		MilestoneID:         milestoneID,
+		CampaignID:          validation.CampaignID,
+		StartupID:           validation.StartupID,
+		MilestoneReportHash: validation.MilestoneReportHash,
+		QualityScore:        validation.QualityScore,
+		Approved:            validation.Approved,
+		ValidationHash:      generateHash(string(response.Payload)),
+		AttestedAt:          now,
+	}
+
+	attestationJSON, err := json.Marshal(attestation)
+	if err != nil {
+		return "", err
+	}
+
+	// Store on common-channel
+	err = ctx.GetStub().PutState(attestation.AttestationID, attestationJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"attestationId":  attestation.AttestationID,
+		"verificationId": attestation.VerificationID,
+		"milestoneId":    milestoneID,
+		"campaignId":     attestation.CampaignID,
+		"approved":       attestation.Approved,
+		"validationHash": attestation.ValidationHash,
+		"channel":        "common-channel",
+		"action":         "MILESTONE_VALIDATION_ATTESTED",
+		"timestamp":      now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("MilestoneValidationAttested", eventJSON)
+
+	responseData := map[string]interface{}{
+		"message":        "Milestone validation attested on common channel",
+		"attestationId":  attestation.AttestationID,
+		"campaignId":     campaignID,
+		"milestoneId":    milestoneID,
+		"approved":       attestation.Approved,
+		"validationHash": attestation.ValidationHash,
+		"nextStep":       "Platform can release milestone funds once the investor has approved",
+	}
+	responseJSON, _ := json.Marshal(responseData)
+	return string(responseJSON), nil
+}
+
 // PublishValidationProof publishes cryptographic validation proof to common-channel
 // Channel: common-channel
 // Purpose: Privacy-preserving validation proof (no confidential details)
@@ -941,180 +1169,1613 @@ func (v *ValidatorContract) PublishValidationProof(
 	return string(responseJSON), nil
 }
 
-// ============================================================================
-// QUERY FUNCTIONS
-// ============================================================================
-
-// GetValidation retrieves validation record by ID
-func (v *ValidatorContract) GetValidation(ctx contractapi.TransactionContextInterface, validationID string) (*ValidationRecord, error) {
-	validationJSON, err := ctx.GetStub().GetState(validationID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read validation: %v", err)
-	}
-	if validationJSON == nil {
-		return nil, fmt.Errorf("validation %s does not exist", validationID)
+// PublishBlacklistedCampaign copies a campaign's blacklist entry to common-channel
+// PlatformOrg opens clawbacks of released funds only for campaigns blacklisted here
+// Channel: common-channel
+// Endorsers: ValidatorOrg (multi-party visibility)
+func (v *ValidatorContract) PublishBlacklistedCampaign(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+) (string, error) {
+	if err := requireMSP(ctx, "ValidatorOrgMSP"); err != nil {
+		return "", err
 	}
 
-	var validation ValidationRecord
-	err = json.Unmarshal(validationJSON, &validation)
-	if err != nil {
-		return nil, err
+	// Cross-channel read of the blacklist entry made during validation
+	response := ctx.GetStub().InvokeChaincode(
+		"validatororg",
+		[][]byte{[]byte("IsCampaignBlacklisted"), []byte(campaignID)},
+		"startup-validator-channel",
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("cross-channel query to startup-validator-channel failed: %s", response.Message)
 	}
 
-	return &validation, nil
-}
-
-// GetRiskInsight retrieves risk insight by campaign ID
-func (v *ValidatorContract) GetRiskInsight(ctx contractapi.TransactionContextInterface, campaignID string) (*RiskInsight, error) {
-	riskKey := fmt.Sprintf("RISK_%s", campaignID)
-	insightJSON, err := ctx.GetStub().GetState(riskKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read risk insight: %v", err)
+	var status struct {
+		Blacklisted   bool   `json:"blacklisted"`
+		Reason        string `json:"reason"`
+		BlacklistedAt string `json:"blacklistedAt"`
 	}
-	if insightJSON == nil {
-		return nil, fmt.Errorf("risk insight for campaign %s does not exist", campaignID)
+	if err := json.Unmarshal(response.Payload, &status); err != nil {
+		return "", fmt.Errorf("failed to parse blacklist status: %v", err)
+	}
+	if !status.Blacklisted {
+		return "", fmt.Errorf("campaign %s is not blacklisted", campaignID)
 	}
 
-	var insight RiskInsight
-	err = json.Unmarshal(insightJSON, &insight)
+	blacklistEntry := BlacklistedCampaign{
+		CampaignID:    campaignID,
+		Reason:        status.Reason,
+		BlacklistedAt: status.BlacklistedAt,
+		BlacklistedBy: "ValidatorOrgMSP",
+	}
+	blacklistJSON, err := json.Marshal(blacklistEntry)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return &insight, nil
-}
-
-// GetValidationReport retrieves validation report by campaign ID
-func (v *ValidatorContract) GetValidationReport(ctx contractapi.TransactionContextInterface, campaignID string) (*ValidationReport, error) {
-	reportKey := fmt.Sprintf("PLATFORM_REPORT_%s", campaignID)
-	reportJSON, err := ctx.GetStub().GetState(reportKey)
+	// Store on common-channel under the same key IsCampaignBlacklisted reads
+	err = ctx.GetStub().PutState(fmt.Sprintf("BLACKLIST_%s", campaignID), blacklistJSON)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read validation report: %v", err)
-	}
-	if reportJSON == nil {
-		return nil, fmt.Errorf("validation report for campaign %s does not exist", campaignID)
+		return "", err
 	}
 
-	var report ValidationReport
-	err = json.Unmarshal(reportJSON, &report)
-	if err != nil {
-		return nil, err
+	now := time.Now().Format(time.RFC3339)
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"campaignId":    campaignID,
+		"reason":        status.Reason,
+		"blacklistedAt": status.BlacklistedAt,
+		"channel":       "common-channel",
+		"action":        "BLACKLIST_PUBLISHED",
+		"timestamp":     now,
 	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("BlacklistPublished", eventJSON)
 
-	return &report, nil
+	responseData := map[string]interface{}{
+		"message":    "Blacklisted campaign published to common channel",
+		"campaignId": campaignID,
+		"reason":     status.Reason,
+		"channel":    "common-channel",
+	}
+	responseJSON, _ := json.Marshal(responseData)
+	return string(responseJSON), nil
 }
 
 // ============================================================================
-// CROSS-CHANNEL INVOCATION HELPER FUNCTIONS
+// DISPUTE RESOLUTION
+// Disputes run on the claimant's channel with ValidatorOrg; decisions are
+// summarized on common-channel where PlatformOrg executes releases and refunds
 // ============================================================================
 
-// InvokeStartupOrgGetCampaign reads campaign data from StartupOrg on startup-validator-channel
-// This is a cross-channel READ operation
-func (v *ValidatorContract) InvokeStartupOrgGetCampaign(
+// OpenDispute opens a dispute against an agreement, milestone, escrow or validation
+// Must be invoked by the claimant on its channel with ValidatorOrg:
+// INVESTOR: investor-validator-channel, STARTUP: startup-validator-channel, PLATFORM: validator-platform-channel
+// Endorsers: ValidatorOrg and the claimant's organization
+func (v *ValidatorContract) OpenDispute(
 	ctx contractapi.TransactionContextInterface,
+	disputeID string,
+	entityType string,
+	entityID string,
 	campaignID string,
+	claimantType string,
+	claimantID string,
+	respondentType string,
+	respondentID string,
+	reason string,
+	requestedRemedy string,
+	evidenceHash string,
 ) (string, error) {
-	args := [][]byte{
-		[]byte("GetCampaign"),
-		[]byte(campaignID),
+	validEntities := map[string]bool{"AGREEMENT": true, "MILESTONE": true, "ESCROW": true, "VALIDATION": true}
+	if !validEntities[entityType] {
+		return "", fmt.Errorf("invalid entity type: %s. Must be AGREEMENT, MILESTONE, ESCROW or VALIDATION", entityType)
 	}
-
-	// Cross-channel query to startuporg
-	response := ctx.GetStub().InvokeChaincode(
-		"startuporg",
-		args,
-		"startup-validator-channel",
-	)
-
-	if response.Status != 200 {
-		return "", fmt.Errorf("cross-channel query to StartupOrg failed: %s", response.Message)
+	if _, ok := disputePartyChannels[claimantType]; !ok {
+		return "", fmt.Errorf("invalid claimant type: %s. Must be INVESTOR, STARTUP or PLATFORM", claimantType)
+	}
+	if _, ok := disputePartyChannels[respondentType]; !ok && respondentType != "VALIDATOR" {
+		return "", fmt.Errorf("invalid respondent type: %s. Must be INVESTOR, STARTUP, PLATFORM or VALIDATOR", respondentType)
+	}
+	if requestedRemedy != "" && !disputeRemedies[requestedRemedy] {
+		return "", fmt.Errorf("invalid remedy: %s", requestedRemedy)
+	}
+	if reason == "" {
+		return "", fmt.Errorf("a dispute needs a reason")
 	}
 
-	return string(response.Payload), nil
-}
+	// Claimant opens on its own channel with ValidatorOrg
+	if err := requireMSP(ctx, disputePartyMSPs[claimantType]); err != nil {
+		return "", err
+	}
+	channel := ctx.GetStub().GetChannelID()
+	if channel != disputePartyChannels[claimantType] {
+		return "", fmt.Errorf("%s disputes must be opened on %s", claimantType, disputePartyChannels[claimantType])
+	}
 
-// InvokePlatformOrgRecordDecision sends validation decision to PlatformOrg
-// Cross-channel call from startup-validator-channel to validator-platform-channel
-func (v *ValidatorContract) InvokePlatformOrgRecordDecision(
-	ctx contractapi.TransactionContextInterface,
-	recordID string,
-	campaignID string,
-	validationID string,
-	approved string,
-	overallScore string,
-	reportHash string,
-) (string, error) {
-	args := [][]byte{
-		[]byte("RecordValidatorDecision"),
-		[]byte(recordID),
-		[]byte(campaignID),
-		[]byte(validationID),
-		[]byte(approved),
-		[]byte(overallScore),
-		[]byte(reportHash),
+	existingJSON, err := ctx.GetStub().GetState(disputeID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read dispute: %v", err)
+	}
+	if existingJSON != nil {
+		return "", fmt.Errorf("dispute %s already exists", disputeID)
 	}
 
-	response := ctx.GetStub().InvokeChaincode(
-		"platformorg",
-		args,
-		"validator-platform-channel",
-	)
+	now := time.Now().Format(time.RFC3339)
 
-	if response.Status != 200 {
-		return "", fmt.Errorf("cross-channel invoke to PlatformOrg failed: %s", response.Message)
+	dispute := Dispute{
+		DisputeID:       disputeID,
+		EntityType:      entityType,
+		EntityID:        entityID,
+		CampaignID:      campaignID,
+		ClaimantType:    claimantType,
+		ClaimantID:      claimantID,
+		RespondentType:  respondentType,
+		RespondentID:    respondentID,
+		Reason:          reason,
+		RequestedRemedy: requestedRemedy,
+		Channel:         channel,
+		Status:          "OPEN",
+		Evidence:        []DisputeEvidence{},
+		FollowUpStatus:  "NONE",
+		OpenedAt:        now,
+		UpdatedAt:       now,
+	}
+	if evidenceHash != "" {
+		dispute.Evidence = append(dispute.Evidence, DisputeEvidence{
+			Party:          "CLAIMANT",
+			PartyID:        claimantID,
+			EvidenceHash:   evidenceHash,
+			Description:    "Submitted with dispute",
+			Channel:        channel,
+			SubmittedByMSP: disputePartyMSPs[claimantType],
+			SubmittedAt:    now,
+		})
+	}
+
+	disputeJSON, err := json.Marshal(dispute)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(disputeID, disputeJSON)
+	if err != nil {
+		return "", err
 	}
 
-	// Emit cross-channel event
+	// Emit event
 	eventPayload := map[string]interface{}{
+		"disputeId":      disputeID,
+		"entityType":     entityType,
+		"entityId":       entityID,
 		"campaignId":     campaignID,
-		"validationId":   validationID,
-		"targetChannel":  "validator-platform-channel",
-		"targetContract": "platformorg",
-		"action":         "CROSS_CHANNEL_RECORD_DECISION",
-		"timestamp":      time.Now().Format(time.RFC3339),
+		"claimantType":   claimantType,
+		"respondentType": respondentType,
+		"channel":        channel,
+		"action":         "DISPUTE_OPENED",
+		"timestamp":      now,
 	}
 	eventJSON, _ := json.Marshal(eventPayload)
-	ctx.GetStub().SetEvent("CrossChannelInvoke", eventJSON)
+	ctx.GetStub().SetEvent("DisputeOpened", eventJSON)
 
-	return string(response.Payload), nil
+	response := map[string]interface{}{
+		"message":   "Dispute opened",
+		"disputeId": disputeID,
+		"channel":   channel,
+		"status":    dispute.Status,
+		"nextStep":  "Both sides submit evidence; ValidatorOrg assigns an arbitrator",
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
 }
 
-// InvokeInvestorOrgShareRisk shares risk insights with InvestorOrg
-// Cross-channel call to investor-validator-channel
-func (v *ValidatorContract) InvokeInvestorOrgShareRisk(
+// SubmitDisputeEvidence records an evidence hash from the claimant or respondent
+// On the dispute's channel the evidence is added to the dispute; a respondent outside that
+// channel submits on its own channel with ValidatorOrg, pass disputeChannel so the dispute can be read
+// Endorsers: ValidatorOrg and the submitting party's organization
+func (v *ValidatorContract) SubmitDisputeEvidence(
 	ctx contractapi.TransactionContextInterface,
-	insightID string,
-	campaignID string,
-	riskScore string,
-	riskLevel string,
-	recommendation string,
+	disputeID string,
+	disputeChannel string,
+	party string,
+	partyID string,
+	evidenceHash string,
+	description string,
 ) (string, error) {
-	args := [][]byte{
-		[]byte("ReceiveRiskInsight"),
-		[]byte(insightID),
-		[]byte(campaignID),
-		[]byte(riskScore),
-		[]byte(riskLevel),
-		[]byte(recommendation),
+	if party != "CLAIMANT" && party != "RESPONDENT" {
+		return "", fmt.Errorf("invalid party: %s. Must be CLAIMANT or RESPONDENT", party)
+	}
+	if evidenceHash == "" {
+		return "", fmt.Errorf("evidence hash is required")
 	}
 
-	response := ctx.GetStub().InvokeChaincode(
-		"investororg",
-		args,
-		"investor-validator-channel",
-	)
+	channel := ctx.GetStub().GetChannelID()
+	if disputeChannel == "" {
+		disputeChannel = channel
+	}
+	dispute, err := readDispute(ctx, disputeID, disputeChannel)
+	if err != nil {
+		return "", err
+	}
+	if dispute.Status == "DECIDED" {
+		return "", fmt.Errorf("dispute %s has already been decided", disputeID)
+	}
 
-	if response.Status != 200 {
-		return "", fmt.Errorf("cross-channel invoke to InvestorOrg failed: %s", response.Message)
+	partyType, expectedID := dispute.ClaimantType, dispute.ClaimantID
+	if party == "RESPONDENT" {
+		partyType, expectedID = dispute.RespondentType, dispute.RespondentID
+	}
+	if partyID != expectedID {
+		return "", fmt.Errorf("%s of dispute %s is %s, not %s", strings.ToLower(party), disputeID, expectedID, partyID)
+	}
+	mspID := disputePartyMSPs[partyType]
+	if err := requireMSP(ctx, mspID); err != nil {
+		return "", err
 	}
 
-	return string(response.Payload), nil
-}
+	now := time.Now().Format(time.RFC3339)
 
-// generateHash generates SHA256 hash
-func generateHash(data string) string {
-	hash := sha256.Sum256([]byte(data))
-	return hex.EncodeToString(hash[:])
+	evidence := DisputeEvidence{
+		Party:          party,
+		PartyID:        partyID,
+		EvidenceHash:   evidenceHash,
+		Description:    description,
+		Channel:        channel,
+		SubmittedByMSP: mspID,
+		SubmittedAt:    now,
+	}
+
+	evidenceCount := 0
+	if channel == dispute.Channel {
+		dispute.Evidence = append(dispute.Evidence, evidence)
+		dispute.UpdatedAt = now
+		evidenceCount = len(dispute.Evidence)
+		disputeJSON, err := json.Marshal(dispute)
+		if err != nil {
+			return "", err
+		}
+		err = ctx.GetStub().PutState(disputeID, disputeJSON)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		// Kept on this channel until the arbitrator merges it into the decision
+		log, err := getDisputeEvidenceLog(ctx, disputeID)
+		if err != nil {
+			return "", err
+		}
+		log.Evidence = append(log.Evidence, evidence)
+		evidenceCount = len(log.Evidence)
+		logJSON, err := json.Marshal(log)
+		if err != nil {
+			return "", err
+		}
+		err = ctx.GetStub().PutState(fmt.Sprintf("DISPUTE_EVIDENCE_%s", disputeID), logJSON)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"disputeId":    disputeID,
+		"party":        party,
+		"partyId":      partyID,
+		"evidenceHash": evidenceHash,
+		"channel":      channel,
+		"action":       "DISPUTE_EVIDENCE_SUBMITTED",
+		"timestamp":    now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("DisputeEvidenceSubmitted", eventJSON)
+
+	response := map[string]interface{}{
+		"message":       "Dispute evidence recorded",
+		"disputeId":     disputeID,
+		"party":         party,
+		"channel":       channel,
+		"evidenceCount": evidenceCount,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// AssignDisputeArbitrator assigns a ValidatorOrg arbitrator to an open dispute
+// Channel: the dispute's channel
+// Endorsers: ValidatorOrg
+func (v *ValidatorContract) AssignDisputeArbitrator(
+	ctx contractapi.TransactionContextInterface,
+	disputeID string,
+	arbitratorID string,
+) (string, error) {
+	if err := requireMSP(ctx, "ValidatorOrgMSP"); err != nil {
+		return "", err
+	}
+
+	dispute, err := readDispute(ctx, disputeID, ctx.GetStub().GetChannelID())
+	if err != nil {
+		return "", err
+	}
+	if dispute.Status == "DECIDED" {
+		return "", fmt.Errorf("dispute %s has already been decided", disputeID)
+	}
+	if arbitratorID == "" {
+		return "", fmt.Errorf("arbitrator ID is required")
+	}
+
+	// A validator cannot arbitrate a dispute about their own validation
+	if dispute.EntityType == "VALIDATION" {
+		validationJSON, _ := ctx.GetStub().GetState(dispute.EntityID)
+		var validation ValidationRecord
+		if validationJSON != nil && json.Unmarshal(validationJSON, &validation) == nil && validation.ValidatorID == arbitratorID {
+			return "", fmt.Errorf("validator %s made the disputed validation and cannot arbitrate it", arbitratorID)
+		}
+	}
+
+	now := time.Now().Format(time.RFC3339)
+
+	dispute.ArbitratorID = arbitratorID
+	dispute.Status = "UNDER_ARBITRATION"
+	dispute.UpdatedAt = now
+
+	disputeJSON, err := json.Marshal(dispute)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(disputeID, disputeJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"disputeId":    disputeID,
+		"arbitratorId": arbitratorID,
+		"channel":      dispute.Channel,
+		"action":       "DISPUTE_ARBITRATOR_ASSIGNED",
+		"timestamp":    now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("DisputeArbitratorAssigned", eventJSON)
+
+	response := map[string]interface{}{
+		"message":      "Arbitrator assigned",
+		"disputeId":    disputeID,
+		"arbitratorId": arbitratorID,
+		"status":       dispute.Status,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// RecordDisputeDecision records the arbitrator's binding decision
+// Respondent evidence submitted on another channel is merged in before hashing.
+// Follow-ups: RELEASE/REFUND are executed by PlatformOrg after PublishDisputeSummary;
+// REVALIDATE/REOPEN_MILESTONE apply here on startup-validator-channel, else via ApplyDisputeDecision
+// Channel: the dispute's channel
+// Endorsers: ValidatorOrg
+func (v *ValidatorContract) RecordDisputeDecision(
+	ctx contractapi.TransactionContextInterface,
+	disputeID string,
+	arbitratorID string,
+	outcome string,
+	remedy string,
+	escrowID string,
+	milestoneID string,
+	amount float64,
+	rationale string,
+) (string, error) {
+	if err := requireMSP(ctx, "ValidatorOrgMSP"); err != nil {
+		return "", err
+	}
+
+	channel := ctx.GetStub().GetChannelID()
+	dispute, err := readDispute(ctx, disputeID, channel)
+	if err != nil {
+		return "", err
+	}
+	if dispute.Status != "UNDER_ARBITRATION" {
+		return "", fmt.Errorf("dispute %s is not under arbitration (status: %s)", disputeID, dispute.Status)
+	}
+	if dispute.ArbitratorID != arbitratorID {
+		return "", fmt.Errorf("dispute %s is assigned to arbitrator %s", disputeID, dispute.ArbitratorID)
+	}
+
+	// Validate outcome and remedy
+	switch outcome {
+	case "DISMISSED":
+		if remedy != "" && remedy != "NONE" {
+			return "", fmt.Errorf("a dismissed dispute has no remedy")
+		}
+		remedy = "NONE"
+	case "UPHELD":
+		if !disputeRemedies[remedy] {
+			return "", fmt.Errorf("invalid remedy: %s. Must be RELEASE, REFUND, REVALIDATE or REOPEN_MILESTONE", remedy)
+		}
+	default:
+		return "", fmt.Errorf("invalid outcome: %s. Must be UPHELD or DISMISSED", outcome)
+	}
+	switch remedy {
+	case "RELEASE":
+		if escrowID == "" || milestoneID == "" || amount <= 0 {
+			return "", fmt.Errorf("a release needs escrowId, milestoneId and a positive amount")
+		}
+	case "REFUND":
+		if escrowID == "" {
+			return "", fmt.Errorf("a refund needs escrowId")
+		}
+	case "REVALIDATE":
+		if dispute.EntityType != "VALIDATION" {
+			return "", fmt.Errorf("re-validation applies only to VALIDATION disputes")
+		}
+	case "REOPEN_MILESTONE":
+		if milestoneID == "" {
+			milestoneID = dispute.EntityID
+		}
+		if dispute.EntityType != "MILESTONE" {
+			return "", fmt.Errorf("milestone re-open applies only to MILESTONE disputes")
+		}
+	}
+
+	// Merge respondent evidence submitted on the respondent's own channel
+	respondentChannel := disputePartyChannels[dispute.RespondentType]
+	if respondentChannel != "" && respondentChannel != channel {
+		response := ctx.GetStub().InvokeChaincode(
+			"validatororg",
+			[][]byte{[]byte("GetDisputeEvidence"), []byte(disputeID)},
+			respondentChannel,
+		)
+		if response.Status != 200 {
+			return "", fmt.Errorf("cross-channel query to %s failed: %s", respondentChannel, response.Message)
+		}
+		var log DisputeEvidenceLog
+		if err := json.Unmarshal(response.Payload, &log); err != nil {
+			return "", fmt.Errorf("failed to parse respondent evidence: %v", err)
+		}
+		dispute.Evidence = append(dispute.Evidence, log.Evidence...)
+	}
+
+	now := time.Now().Format(time.RFC3339)
+
+	decision := DisputeDecision{
+		Outcome:     outcome,
+		Remedy:      remedy,
+		EscrowID:    escrowID,
+		MilestoneID: milestoneID,
+		Amount:      amount,
+		Rationale:   rationale,
+		DecidedBy:   arbitratorID,
+		DecidedAt:   now,
+	}
+	evidenceHashes := []string{}
+	for _, evidence := range dispute.Evidence {
+		evidenceHashes = append(evidenceHashes, evidence.Party+":"+evidence.EvidenceHash)
+	}
+	decisionData, _ := json.Marshal(map[string]interface{}{
+		"disputeId":      disputeID,
+		"decision":       decision,
+		"evidenceHashes": evidenceHashes,
+	})
+	decision.DecisionHash = generateHash(string(decisionData))
+
+	dispute.Decision = decision
+	dispute.Status = "DECIDED"
+	dispute.UpdatedAt = now
+	dispute.FollowUpStatus = "NONE"
+	if remedy != "NONE" {
+		dispute.FollowUpStatus = "PENDING"
+	}
+
+	// Re-validation and milestone re-open change ValidatorOrg records on startup-validator-channel
+	if (remedy == "REVALIDATE" || remedy == "REOPEN_MILESTONE") && channel == "startup-validator-channel" {
+		if err := applyDisputeRemedy(ctx, dispute, now); err != nil {
+			return "", err
+		}
+		dispute.FollowUpStatus = "APPLIED"
+	}
+
+	disputeJSON, err := json.Marshal(dispute)
+	if err != nil {
+		return "", err
+	}
+	err = ctx.GetStub().PutState(disputeID, disputeJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"disputeId":      disputeID,
+		"outcome":        outcome,
+		"remedy":         remedy,
+		"escrowId":       escrowID,
+		"milestoneId":    milestoneID,
+		"amount":         amount,
+		"decisionHash":   decision.DecisionHash,
+		"followUpStatus": dispute.FollowUpStatus,
+		"channel":        channel,
+		"action":         "DISPUTE_DECIDED",
+		"timestamp":      now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("DisputeDecided", eventJSON)
+
+	nextStep := "Publish the summary to common-channel with PublishDisputeSummary"
+	switch {
+	case remedy == "RELEASE" || remedy == "REFUND":
+		nextStep = "Publish the summary to common-channel, then PlatformOrg calls ExecuteDisputeDecision"
+	case dispute.FollowUpStatus == "PENDING":
+		nextStep = "Call ApplyDisputeDecision on startup-validator-channel, then publish the summary"
+	}
+
+	response := map[string]interface{}{
+		"message":        "Dispute decided",
+		"disputeId":      disputeID,
+		"outcome":        outcome,
+		"remedy":         remedy,
+		"decisionHash":   decision.DecisionHash,
+		"followUpStatus": dispute.FollowUpStatus,
+		"nextStep":       nextStep,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// ApplyDisputeDecision applies a REVALIDATE or REOPEN_MILESTONE remedy decided on another channel
+// Channel: startup-validator-channel
+// Endorsers: ValidatorOrg
+func (v *ValidatorContract) ApplyDisputeDecision(
+	ctx contractapi.TransactionContextInterface,
+	disputeID string,
+	disputeChannel string,
+) (string, error) {
+	if err := requireMSP(ctx, "ValidatorOrgMSP"); err != nil {
+		return "", err
+	}
+	if ctx.GetStub().GetChannelID() != "startup-validator-channel" {
+		return "", fmt.Errorf("dispute decisions are applied on startup-validator-channel")
+	}
+
+	dispute, err := readDispute(ctx, disputeID, disputeChannel)
+	if err != nil {
+		return "", err
+	}
+	if dispute.Status != "DECIDED" {
+		return "", fmt.Errorf("dispute %s has not been decided", disputeID)
+	}
+	if dispute.Decision.Remedy != "REVALIDATE" && dispute.Decision.Remedy != "REOPEN_MILESTONE" {
+		return "", fmt.Errorf("remedy %s is not applied by ValidatorOrg", dispute.Decision.Remedy)
+	}
+
+	appliedKey := fmt.Sprintf("DISPUTE_APPLIED_%s", disputeID)
+	appliedJSON, err := ctx.GetStub().GetState(appliedKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read dispute follow-up: %v", err)
+	}
+	if appliedJSON != nil || dispute.FollowUpStatus == "APPLIED" {
+		return "", fmt.Errorf("decision of dispute %s has already been applied", disputeID)
+	}
+
+	now := time.Now().Format(time.RFC3339)
+
+	if err := applyDisputeRemedy(ctx, dispute, now); err != nil {
+		return "", err
+	}
+
+	applied := map[string]interface{}{
+		"disputeId":    disputeID,
+		"remedy":       dispute.Decision.Remedy,
+		"decisionHash": dispute.Decision.DecisionHash,
+		"appliedAt":    now,
+	}
+	appliedJSON, _ = json.Marshal(applied)
+	err = ctx.GetStub().PutState(appliedKey, appliedJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"disputeId": disputeID,
+		"remedy":    dispute.Decision.Remedy,
+		"entityId":  dispute.EntityID,
+		"channel":   "startup-validator-channel",
+		"action":    "DISPUTE_DECISION_APPLIED",
+		"timestamp": now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("DisputeDecisionApplied", eventJSON)
+
+	response := map[string]interface{}{
+		"message":   "Dispute decision applied",
+		"disputeId": disputeID,
+		"remedy":    dispute.Decision.Remedy,
+		"entityId":  dispute.EntityID,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// PublishDisputeSummary publishes a decided dispute to common-channel with a hash of the full record
+// PlatformOrg executes RELEASE and REFUND remedies from this summary; a REOPEN_MILESTONE
+// summary revokes the milestone's attestation so no release can use it
+// Channel: common-channel
+// Endorsers: ValidatorOrg (multi-party visibility)
+func (v *ValidatorContract) PublishDisputeSummary(
+	ctx contractapi.TransactionContextInterface,
+	disputeID string,
+	disputeChannel string,
+) (string, error) {
+	if err := requireMSP(ctx, "ValidatorOrgMSP"); err != nil {
+		return "", err
+	}
+
+	summaryID := fmt.Sprintf("DISPUTE_SUMMARY_%s", disputeID)
+	existingJSON, err := ctx.GetStub().GetState(summaryID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read dispute summary: %v", err)
+	}
+	if existingJSON != nil {
+		return "", fmt.Errorf("summary of dispute %s has already been published", disputeID)
+	}
+
+	// Cross-channel read of the decided dispute
+	response := ctx.GetStub().InvokeChaincode(
+		"validatororg",
+		[][]byte{[]byte("GetDispute"), []byte(disputeID)},
+		disputeChannel,
+	)
+	if response.Status != 200 {
+		return "", fmt.Errorf("cross-channel query to %s failed: %s", disputeChannel, response.Message)
+	}
+
+	var dispute Dispute
+	if err := json.Unmarshal(response.Payload, &dispute); err != nil {
+		return "", fmt.Errorf("failed to parse dispute: %v", err)
+	}
+	if dispute.Status != "DECIDED" {
+		return "", fmt.Errorf("dispute %s has not been decided", disputeID)
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	summary := DisputeSummary{
+		SummaryID:   summaryID,
+		DisputeID:   disputeID,
+		EntityType:  dispute.EntityType,
+		EntityID:    dispute.EntityID,
+		CampaignID:  dispute.CampaignID,
+		Channel:     dispute.Channel,
+		Outcome:     dispute.Decision.Outcome,
+		Remedy:      dispute.Decision.Remedy,
+		EscrowID:    dispute.Decision.EscrowID,
+		MilestoneID: dispute.Decision.MilestoneID,
+		Amount:      dispute.Decision.Amount,
+		SummaryHash: generateHash(string(response.Payload)),
+		PublishedAt: now,
+	}
+
+	summaryJSON, err := json.Marshal(summary)
+	if err != nil {
+		return "", err
+	}
+
+	// Store on common-channel
+	err = ctx.GetStub().PutState(summary.SummaryID, summaryJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// A re-opened milestone needs a fresh validation before any release
+	attestationRevoked := false
+	if summary.Remedy == "REOPEN_MILESTONE" {
+		attestationRevoked, err = revokeMilestoneAttestation(ctx, summary.CampaignID, summary.MilestoneID, disputeID, now)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"disputeId":          disputeID,
+		"campaignId":         summary.CampaignID,
+		"outcome":            summary.Outcome,
+		"remedy":             summary.Remedy,
+		"summaryHash":        summary.SummaryHash,
+		"channel":            "common-channel",
+		"action":             "DISPUTE_SUMMARY_PUBLISHED",
+		"attestationRevoked": attestationRevoked,
+		"timestamp":          now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("DisputeSummaryPublished", eventJSON)
+
+	responseData := map[string]interface{}{
+		"message":            "Dispute summary published to common channel",
+		"summaryId":          summary.SummaryID,
+		"outcome":            summary.Outcome,
+		"remedy":             summary.Remedy,
+		"summaryHash":        summary.SummaryHash,
+		"attestationRevoked": attestationRevoked,
+	}
+	responseJSON, _ := json.Marshal(responseData)
+	return string(responseJSON), nil
+}
+
+// ============================================================================
+// QUERY FUNCTIONS
+// ============================================================================
+
+// GetValidation retrieves validation record by ID
+func (v *ValidatorContract) GetValidation(ctx contractapi.TransactionContextInterface, validationID string) (*ValidationRecord, error) {
+	validationJSON, err := ctx.GetStub().GetState(validationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read validation: %v", err)
+	}
+	if validationJSON == nil {
+		return nil, fmt.Errorf("validation %s does not exist", validationID)
+	}
+
+	var validation ValidationRecord
+	err = json.Unmarshal(validationJSON, &validation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validation, nil
+}
+
+// GetMilestoneValidation retrieves the latest milestone validation
+// Channel: startup-validator-channel
+func (v *ValidatorContract) GetMilestoneValidation(ctx contractapi.TransactionContextInterface, milestoneID string) (*MilestoneValidation, error) {
+	validationJSON, err := ctx.GetStub().GetState(fmt.Sprintf("MILESTONE_VERIFY_%s", milestoneID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read milestone validation: %v", err)
+	}
+	if validationJSON == nil {
+		return nil, fmt.Errorf("milestone %s has not been validated", milestoneID)
+	}
+
+	var validation MilestoneValidation
+	err = json.Unmarshal(validationJSON, &validation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validation, nil
+}
+
+// GetMilestoneAttestation retrieves the milestone attestation published as release evidence
+// Channel: common-channel
+func (v *ValidatorContract) GetMilestoneAttestation(ctx contractapi.TransactionContextInterface, campaignID string, milestoneID string) (*MilestoneAttestation, error) {
+	attestationJSON, err := ctx.GetStub().GetState(milestoneAttestationKey(campaignID, milestoneID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read milestone attestation: %v", err)
+	}
+	if attestationJSON == nil {
+		return nil, fmt.Errorf("milestone %s of campaign %s has not been attested", milestoneID, campaignID)
+	}
+
+	var attestation MilestoneAttestation
+	err = json.Unmarshal(attestationJSON, &attestation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attestation, nil
+}
+
+// GetDispute retrieves a dispute by ID
+// Channel: the dispute's channel
+func (v *ValidatorContract) GetDispute(ctx contractapi.TransactionContextInterface, disputeID string) (*Dispute, error) {
+	return readDispute(ctx, disputeID, ctx.GetStub().GetChannelID())
+}
+
+// GetDisputeEvidence returns evidence submitted for a dispute on this channel outside the dispute record
+func (v *ValidatorContract) GetDisputeEvidence(ctx contractapi.TransactionContextInterface, disputeID string) (*DisputeEvidenceLog, error) {
+	return getDisputeEvidenceLog(ctx, disputeID)
+}
+
+// GetDisputeSummary retrieves the published summary of a decided dispute
+// Channel: common-channel
+func (v *ValidatorContract) GetDisputeSummary(ctx contractapi.TransactionContextInterface, disputeID string) (*DisputeSummary, error) {
+	summaryJSON, err := ctx.GetStub().GetState(fmt.Sprintf("DISPUTE_SUMMARY_%s", disputeID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dispute summary: %v", err)
+	}
+	if summaryJSON == nil {
+		return nil, fmt.Errorf("dispute %s has no published summary", disputeID)
+	}
+
+	var summary DisputeSummary
+	err = json.Unmarshal(summaryJSON, &summary)
+	if err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
+// GetDisputesByStatus returns disputes on this channel with a status (OPEN, UNDER_ARBITRATION, DECIDED), oldest first
+func (v *ValidatorContract) GetDisputesByStatus(ctx contractapi.TransactionContextInterface, status string) (string, error) {
+	records, err := getQueryResults(ctx, map[string]interface{}{
+		"disputeId":    map[string]bool{"$exists": true},
+		"claimantType": map[string]bool{"$exists": true},
+		"status":       status,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	disputes := []Dispute{}
+	for _, record := range records {
+		var dispute Dispute
+		if json.Unmarshal(record.Value, &dispute) != nil || record.Key != dispute.DisputeID {
+			continue
+		}
+		disputes = append(disputes, dispute)
+	}
+	sort.Slice(disputes, func(a, b int) bool { return disputes[a].OpenedAt < disputes[b].OpenedAt })
+
+	disputesJSON, err := json.Marshal(disputes)
+	if err != nil {
+		return "", err
+	}
+
+	return string(disputesJSON), nil
+}
+
+// GetRiskInsight retrieves risk insight by campaign ID
+func (v *ValidatorContract) GetRiskInsight(ctx contractapi.TransactionContextInterface, campaignID string) (*RiskInsight, error) {
+	riskKey := fmt.Sprintf("RISK_%s", campaignID)
+	insightJSON, err := ctx.GetStub().GetState(riskKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read risk insight: %v", err)
+	}
+	if insightJSON == nil {
+		return nil, fmt.Errorf("risk insight for campaign %s does not exist", campaignID)
+	}
+
+	var insight RiskInsight
+	err = json.Unmarshal(insightJSON, &insight)
+	if err != nil {
+		return nil, err
+	}
+
+	return &insight, nil
+}
+
+// GetValidationReport retrieves validation report by campaign ID
+func (v *ValidatorContract) GetValidationReport(ctx contractapi.TransactionContextInterface, campaignID string) (*ValidationReport, error) {
+	reportKey := fmt.Sprintf("PLATFORM_REPORT_%s", campaignID)
+	reportJSON, err := ctx.GetStub().GetState(reportKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read validation report: %v", err)
+	}
+	if reportJSON == nil {
+		return nil, fmt.Errorf("validation report for campaign %s does not exist", campaignID)
+	}
+
+	var report ValidationReport
+	err = json.Unmarshal(reportJSON, &report)
+	if err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// ============================================================================
+// VALIDATOR WORK QUEUES
+// Rebuilt from StartupOrg/InvestorOrg state and ValidatorOrg decisions
+// ============================================================================
+
+// AssignValidator assigns a queued item to a validator
+// itemType: CAMPAIGN, MILESTONE_REPORT, RISK_REQUEST
+func (v *ValidatorContract) AssignValidator(
+	ctx contractapi.TransactionContextInterface,
+	itemType string,
+	itemID string,
+	validatorID string,
+) (string, error) {
+	validTypes := map[string]bool{"CAMPAIGN": true, "MILESTONE_REPORT": true, "RISK_REQUEST": true}
+	if !validTypes[itemType] {
+		return "", fmt.Errorf("invalid item type: %s. Must be CAMPAIGN, MILESTONE_REPORT, or RISK_REQUEST", itemType)
+	}
+	if itemID == "" || validatorID == "" {
+		return "", fmt.Errorf("itemID and validatorID are required")
+	}
+
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := txTime.Format(time.RFC3339)
+
+	assignment := ValidatorAssignment{
+		AssignmentID: fmt.Sprintf("ASSIGNMENT_%s_%s", itemType, itemID),
+		ItemType:     itemType,
+		ItemID:       itemID,
+		ValidatorID:  validatorID,
+		AssignedAt:   now,
+	}
+
+	assignmentJSON, err := json.Marshal(assignment)
+	if err != nil {
+		return "", err
+	}
+
+	err = ctx.GetStub().PutState(assignment.AssignmentID, assignmentJSON)
+	if err != nil {
+		return "", err
+	}
+
+	// Emit event
+	eventPayload := map[string]interface{}{
+		"assignmentId": assignment.AssignmentID,
+		"itemType":     itemType,
+		"itemId":       itemID,
+		"validatorId":  validatorID,
+		"action":       "VALIDATOR_ASSIGNED",
+		"timestamp":    now,
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("ValidatorAssigned", eventJSON)
+
+	response := map[string]interface{}{
+		"message":      "Validator assigned",
+		"assignmentId": assignment.AssignmentID,
+		"itemType":     itemType,
+		"itemId":       itemID,
+		"validatorId":  validatorID,
+	}
+	responseJSON, _ := json.Marshal(response)
+	return string(responseJSON), nil
+}
+
+// GetPendingValidationQueue lists campaigns submitted for validation with no decision since submission
+// Channel: startup-validator-channel
+func (v *ValidatorContract) GetPendingValidationQueue(ctx contractapi.TransactionContextInterface) (string, error) {
+	records, err := invokeQueryRecords(ctx, "startuporg", "startup-validator-channel", "GetCampaignsByValidationStatus", "PENDING_VALIDATION")
+	if err != nil {
+		return "", err
+	}
+
+	var items []WorkQueueItem
+	for _, record := range records {
+		var campaign queuedCampaign
+		if json.Unmarshal(record.Record, &campaign) != nil {
+			continue
+		}
+
+		// Skip campaigns already decided after their latest submission
+		validation, _ := getValidationByCampaign(ctx, campaign.CampaignID)
+		if validation != nil && !isBefore(validation.ValidatedAt, campaign.UpdatedAt) {
+			continue
+		}
+
+		validatorID := ""
+		if validation != nil {
+			validatorID = validation.ValidatorID
+		}
+
+		items = append(items, WorkQueueItem{
+			ItemType:          "CAMPAIGN",
+			ItemID:            campaign.CampaignID,
+			CampaignID:        campaign.CampaignID,
+			Summary:           campaign.ProjectName,
+			Status:            campaign.ValidationStatus,
+			QueuedAt:          campaign.UpdatedAt,
+			AssignedValidator: assignedValidator(ctx, "CAMPAIGN", campaign.CampaignID, validatorID),
+		})
+	}
+
+	return buildWorkQueue(ctx, items)
+}
+
+// GetUpdatedOnHoldQueue lists ON_HOLD campaigns whose documents were updated since the hold
+// Channel: startup-validator-channel
+func (v *ValidatorContract) GetUpdatedOnHoldQueue(ctx contractapi.TransactionContextInterface) (string, error) {
+	records, err := getQueryResults(ctx, map[string]interface{}{
+		"status":       "ON_HOLD",
+		"validationId": map[string]bool{"$exists": true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var items []WorkQueueItem
+	for _, record := range records {
+		var validation ValidationRecord
+		// Skip the CAMPAIGN_VAL_ copy
+		if json.Unmarshal(record.Value, &validation) != nil || record.Key != validation.ValidationID {
+			continue
+		}
+
+		campaignJSON, err := v.InvokeStartupOrgGetCampaign(ctx, validation.CampaignID)
+		if err != nil {
+			continue
+		}
+		var campaign queuedCampaign
+		if json.Unmarshal([]byte(campaignJSON), &campaign) != nil || len(campaign.DocumentHistory) == 0 {
+			continue
+		}
+
+		latest := campaign.DocumentHistory[len(campaign.DocumentHistory)-1]
+		if !isBefore(validation.ValidatedAt, latest.SubmittedAt) {
+			continue
+		}
+
+		items = append(items, WorkQueueItem{
+			ItemType:          "CAMPAIGN",
+			ItemID:            validation.CampaignID,
+			CampaignID:        validation.CampaignID,
+			Summary:           fmt.Sprintf("%s: %s (requested: %s)", campaign.ProjectName, latest.SubmissionID, validation.RequiredDocuments),
+			Status:            validation.Status,
+			QueuedAt:          latest.SubmittedAt,
+			AssignedValidator: assignedValidator(ctx, "CAMPAIGN", validation.CampaignID, validation.ValidatorID),
+		})
+	}
+
+	return buildWorkQueue(ctx, items)
+}
+
+// GetMilestoneReportQueue lists milestone reports awaiting verification
+// Channel: startup-validator-channel
+func (v *ValidatorContract) GetMilestoneReportQueue(ctx contractapi.TransactionContextInterface) (string, error) {
+	var items []WorkQueueItem
+	for _, status := range []string{"SUBMITTED", "UNDER_REVIEW"} {
+		records, err := invokeQueryRecords(ctx, "startuporg", "startup-validator-channel", "GetMilestoneReportsByStatus", status)
+		if err != nil {
+			return "", err
+		}
+
+		for _, record := range records {
+			var report queuedMilestoneReport
+			if json.Unmarshal(record.Record, &report) != nil {
+				continue
+			}
+
+			// Skip reports already verified after submission
+			verificationJSON, _ := ctx.GetStub().GetState(fmt.Sprintf("MILESTONE_VERIFY_%s", report.MilestoneID))
+			if verificationJSON != nil {
+				var verification MilestoneValidation
+				if json.Unmarshal(verificationJSON, &verification) == nil && !isBefore(verification.VerifiedAt, report.SubmittedAt) {
+					continue
+				}
+			}
+
+			items = append(items, WorkQueueItem{
+				ItemType:          "MILESTONE_REPORT",
+				ItemID:            report.ReportID,
+				CampaignID:        report.CampaignID,
+				Summary:           fmt.Sprintf("%s (milestone %s)", report.Title, report.MilestoneID),
+				Status:            report.Status,
+				QueuedAt:          report.SubmittedAt,
+				AssignedValidator: assignedValidator(ctx, "MILESTONE_REPORT", report.ReportID, ""),
+			})
+		}
+	}
+
+	return buildWorkQueue(ctx, items)
+}
+
+// GetRiskRequestQueue lists investor risk insight requests that have not been answered
+// Channel: investor-validator-channel
+func (v *ValidatorContract) GetRiskRequestQueue(ctx contractapi.TransactionContextInterface) (string, error) {
+	records, err := invokeQueryRecords(ctx, "investororg", "investor-validator-channel", "GetRiskInsightRequestsByStatus", "PENDING")
+	if err != nil {
+		return "", err
+	}
+
+	var items []WorkQueueItem
+	for _, record := range records {
+		var request queuedRiskRequest
+		if json.Unmarshal(record.Record, &request) != nil {
+			continue
+		}
+
+		// Skip requests already answered by AssignRiskScore
+		insightJSON, _ := ctx.GetStub().GetState(fmt.Sprintf("INVESTOR_RISK_%s_%s", request.InvestorID, request.CampaignID))
+		if insightJSON != nil {
+			var insight RiskInsight
+			if json.Unmarshal(insightJSON, &insight) == nil && !isBefore(insight.CreatedAt, request.RequestedAt) {
+				continue
+			}
+		}
+
+		items = append(items, WorkQueueItem{
+			ItemType:          "RISK_REQUEST",
+			ItemID:            request.RequestID,
+			CampaignID:        request.CampaignID,
+			Summary:           fmt.Sprintf("Risk insight requested by %s", request.InvestorID),
+			Status:            request.Status,
+			QueuedAt:          request.RequestedAt,
+			AssignedValidator: assignedValidator(ctx, "RISK_REQUEST", request.RequestID, ""),
+		})
+	}
+
+	return buildWorkQueue(ctx, items)
+}
+
+// ============================================================================
+// LEDGER HELPERS
+// ============================================================================
+
+// queryRecord is a single key/value returned by a rich query
+type queryRecord struct {
+	Key   string
+	Value []byte
+}
+
+// getQueryResults runs a CouchDB selector query and collects all matching records
+func getQueryResults(ctx contractapi.TransactionContextInterface, selector map[string]interface{}) ([]queryRecord, error) {
+	queryJSON, err := json.Marshal(map[string]interface{}{"selector": selector})
+	if err != nil {
+		return nil, err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetQueryResult(string(queryJSON))
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	var records []queryRecord
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, queryRecord{Key: queryResponse.Key, Value: queryResponse.Value})
+	}
+
+	return records, nil
+}
+
+// getTxTime returns the transaction timestamp so every endorser sees the same time
+func getTxTime(ctx contractapi.TransactionContextInterface) (time.Time, error) {
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read transaction timestamp: %v", err)
+	}
+	return txTimestamp.AsTime().UTC(), nil
+}
+
+// ============================================================================
+// CROSS-CHANNEL INVOCATION HELPER FUNCTIONS
+// ============================================================================
+
+// InvokeStartupOrgGetCampaign reads campaign data from StartupOrg on startup-validator-channel
+// This is a cross-channel READ operation
+func (v *ValidatorContract) InvokeStartupOrgGetCampaign(
+	ctx contractapi.TransactionContextInterface,
+	campaignID string,
+) (string, error) {
+	args := [][]byte{
+		[]byte("GetCampaign"),
+		[]byte(campaignID),
+	}
+
+	// Cross-channel query to startuporg
+	response := ctx.GetStub().InvokeChaincode(
+		"startuporg",
+		args,
+		"startup-validator-channel",
+	)
+
+	if response.Status != 200 {
+		return "", fmt.Errorf("cross-channel query to StartupOrg failed: %s", response.Message)
+	}
+
+	return string(response.Payload), nil
+}
+
+// InvokePlatformOrgRecordDecision sends validation decision to PlatformOrg
+// Cross-channel call from startup-validator-channel to validator-platform-channel
+func (v *ValidatorContract) InvokePlatformOrgRecordDecision(
+	ctx contractapi.TransactionContextInterface,
+	recordID string,
+	campaignID string,
+	validationID string,
+	approved string,
+	overallScore string,
+	reportHash string,
+) (string, error) {
+	args := [][]byte{
+		[]byte("RecordValidatorDecision"),
+		[]byte(recordID),
+		[]byte(campaignID),
+		[]byte(validationID),
+		[]byte(approved),
+		[]byte(overallScore),
+		[]byte(reportHash),
+	}
+
+	response := ctx.GetStub().InvokeChaincode(
+		"platformorg",
+		args,
+		"validator-platform-channel",
+	)
+
+	if response.Status != 200 {
+		return "", fmt.Errorf("cross-channel invoke to PlatformOrg failed: %s", response.Message)
+	}
+
+	// Emit cross-channel event
+	eventPayload := map[string]interface{}{
+		"campaignId":     campaignID,
+		"validationId":   validationID,
+		"targetChannel":  "validator-platform-channel",
+		"targetContract": "platformorg",
+		"action":         "CROSS_CHANNEL_RECORD_DECISION",
+		"timestamp":      time.Now().Format(time.RFC3339),
+	}
+	eventJSON, _ := json.Marshal(eventPayload)
+	ctx.GetStub().SetEvent("CrossChannelInvoke", eventJSON)
+
+	return string(response.Payload), nil
+}
+
+// InvokeInvestorOrgShareRisk shares risk insights with InvestorOrg
+// Cross-channel call to investor-validator-channel
+func (v *ValidatorContract) InvokeInvestorOrgShareRisk(
+	ctx contractapi.TransactionContextInterface,
+	insightID string,
+	campaignID string,
+	riskScore string,
+	riskLevel string,
+	recommendation string,
+) (string, error) {
+	args := [][]byte{
+		[]byte("ReceiveRiskInsight"),
+		[]byte(insightID),
+		[]byte(campaignID),
+		[]byte(riskScore),
+		[]byte(riskLevel),
+		[]byte(recommendation),
+	}
+
+	response := ctx.GetStub().InvokeChaincode(
+		"investororg",
+		args,
+		"investor-validator-channel",
+	)
+
+	if response.Status != 200 {
+		return "", fmt.Errorf("cross-channel invoke to InvestorOrg failed: %s", response.Message)
+	}
+
+	return string(response.Payload), nil
+}
+
+// disputePartyChannels maps each dispute party to its bilateral channel with ValidatorOrg
+var disputePartyChannels = map[string]string{
+	"INVESTOR": "investor-validator-channel",
+	"STARTUP":  "startup-validator-channel",
+	"PLATFORM": "validator-platform-channel",
+}
+
+// disputePartyMSPs maps each dispute party to its organization
+var disputePartyMSPs = map[string]string{
+	"INVESTOR":  "InvestorOrgMSP",
+	"STARTUP":   "StartupOrgMSP",
+	"PLATFORM":  "PlatformOrgMSP",
+	"VALIDATOR": "ValidatorOrgMSP",
+}
+
+// disputeRemedies are the follow-ups a decision can order
+var disputeRemedies = map[string]bool{"RELEASE": true, "REFUND": true, "REVALIDATE": true, "REOPEN_MILESTONE": true}
+
+// milestoneAttestationKey is the common-channel key of a campaign milestone's attestation
+func milestoneAttestationKey(campaignID string, milestoneID string) string {
+	return fmt.Sprintf("MILESTONE_ATTESTATION_%s_%s", campaignID, milestoneID)
+}
+
+// requireMSP rejects callers outside the given organization
+func requireMSP(ctx contractapi.TransactionContextInterface, mspID string) error {
+	clientMSPID, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		return fmt.Errorf("failed to read client MSP ID: %v", err)
+	}
+	if clientMSPID != mspID {
+		return fmt.Errorf("only %s can perform this action, caller is %s", mspID, clientMSPID)
+	}
+	return nil
+}
+
+// readDispute reads a dispute from this channel, or from disputeChannel through a cross-channel query
+func readDispute(ctx contractapi.TransactionContextInterface, disputeID string, disputeChannel string) (*Dispute, error) {
+	var disputeJSON []byte
+	if disputeChannel == ctx.GetStub().GetChannelID() {
+		var err error
+		disputeJSON, err = ctx.GetStub().GetState(disputeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dispute: %v", err)
+		}
+		if disputeJSON == nil {
+			return nil, fmt.Errorf("dispute %s does not exist", disputeID)
+		}
+	} else {
+		response := ctx.GetStub().InvokeChaincode(
+			"validatororg",
+			[][]byte{[]byte("GetDispute"), []byte(disputeID)},
+			disputeChannel,
+		)
+		if response.Status != 200 {
+			return nil, fmt.Errorf("cross-channel query to %s failed: %s", disputeChannel, response.Message)
+		}
+		disputeJSON = response.Payload
+	}
+
+	var dispute Dispute
+	if err := json.Unmarshal(disputeJSON, &dispute); err != nil {
+		return nil, err
+	}
+	if dispute.Evidence == nil {
+		dispute.Evidence = []DisputeEvidence{}
+	}
+
+	return &dispute, nil
+}
+
+// getDisputeEvidenceLog returns evidence stored on this channel outside the dispute record
+func getDisputeEvidenceLog(ctx contractapi.TransactionContextInterface, disputeID string) (*DisputeEvidenceLog, error) {
+	logJSON, err := ctx.GetStub().GetState(fmt.Sprintf("DISPUTE_EVIDENCE_%s", disputeID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dispute evidence: %v", err)
+	}
+
+	log := DisputeEvidenceLog{DisputeID: disputeID, Evidence: []DisputeEvidence{}}
+	if logJSON != nil {
+		if err := json.Unmarshal(logJSON, &log); err != nil {
+			return nil, err
+		}
+	}
+	return &log, nil
+}
+
+// applyDisputeRemedy applies REVALIDATE or REOPEN_MILESTONE to records on startup-validator-channel
+func applyDisputeRemedy(ctx contractapi.TransactionContextInterface, dispute *Dispute, now string) error {
+	note := fmt.Sprintf("Dispute %s: %s", dispute.DisputeID, dispute.Decision.Rationale)
+
+	if dispute.Decision.Remedy == "REVALIDATE" {
+		// Send the validation back to PENDING for a fresh review
+		validationJSON, err := ctx.GetStub().GetState(dispute.EntityID)
+		if err != nil {
+			return fmt.Errorf("failed to read validation: %v", err)
+		}
+		if validationJSON == nil {
+			return fmt.Errorf("validation %s does not exist", dispute.EntityID)
+		}
+
+		var validation ValidationRecord
+		if err := json.Unmarshal(validationJSON, &validation); err != nil {
+			return err
+		}
+		validation.Status = "PENDING"
+		validation.Comments = append(validation.Comments, "Re-validation ordered. "+note)
+
+		updatedJSON, err := json.Marshal(validation)
+		if err != nil {
+			return err
+		}
+		return ctx.GetStub().PutState(validation.ValidationID, updatedJSON)
+	}
+
+	// Re-open: the latest milestone verification no longer approves the milestone
+	milestoneKey := fmt.Sprintf("MILESTONE_VERIFY_%s", dispute.Decision.MilestoneID)
+	verificationJSON, err := ctx.GetStub().GetState(milestoneKey)
+	if err != nil {
+		return fmt.Errorf("failed to read milestone validation: %v", err)
+	}
+
+	verification := MilestoneValidation{
+		VerificationID: fmt.Sprintf("REOPEN_%s", dispute.DisputeID),
+		MilestoneID:    dispute.Decision.MilestoneID,
+		CampaignID:     dispute.CampaignID,
+	}
+	if verificationJSON != nil {
+		if err := json.Unmarshal(verificationJSON, &verification); err != nil {
+			return err
+		}
+	}
+	verification.Approved = false
+	verification.DeliverablesVerified = false
+	verification.Comments = "Milestone re-opened. " + note
+	verification.VerifiedAt = now
+
+	updatedJSON, err := json.Marshal(verification)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(milestoneKey, updatedJSON)
+}
+
+// revokeMilestoneAttestation withdraws the common-channel approval of a milestone re-opened by a dispute
+// PlatformOrg re-reads the attestation before executing a release, so pending releases are blocked too
+func revokeMilestoneAttestation(ctx contractapi.TransactionContextInterface, campaignID string, milestoneID string, disputeID string, now string) (bool, error) {
+	attestationKey := milestoneAttestationKey(campaignID, milestoneID)
+	attestationJSON, err := ctx.GetStub().GetState(attestationKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to read milestone attestation: %v", err)
+	}
+	if attestationJSON == nil {
+		return false, nil
+	}
+
+	var attestation MilestoneAttestation
+	if err := json.Unmarshal(attestationJSON, &attestation); err != nil {
+		return false, err
+	}
+	attestation.Approved = false
+	attestation.RevokedByDispute = disputeID
+	attestation.RevokedAt = now
+
+	updatedJSON, err := json.Marshal(attestation)
+	if err != nil {
+		return false, err
+	}
+	if err := ctx.GetStub().PutState(attestationKey, updatedJSON); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// generateHash generates SHA256 hash
+func generateHash(data string) string {
+	hash := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(hash[:])
+}
+
+// queuedCampaign is the subset of a StartupOrg campaign used by the work queues
+type queuedCampaign struct {
+	CampaignID       string `json:"campaignId"`
+	ProjectName      string `json:"projectName"`
+	ValidationStatus string `json:"validationStatus"`
+	UpdatedAt        string `json:"updatedAt"`
+	DocumentHistory  []struct {
+		SubmissionID string `json:"submissionId"`
+		SubmittedAt  string `json:"submittedAt"`
+	} `json:"documentHistory"`
+}
+
+// queuedMilestoneReport is the subset of a StartupOrg milestone report used by the work queues
+type queuedMilestoneReport struct {
+	ReportID    string `json:"reportId"`
+	CampaignID  string `json:"campaignId"`
+	MilestoneID string `json:"milestoneId"`
+	Title       string `json:"title"`
+	Status      string `json:"status"`
+	SubmittedAt string `json:"submittedAt"`
+}
+
+// queuedRiskRequest is the subset of an InvestorOrg risk insight request used by the work queues
+type queuedRiskRequest struct {
+	RequestID   string `json:"requestId"`
+	CampaignID  string `json:"campaignId"`
+	InvestorID  string `json:"investorId"`
+	Status      string `json:"status"`
+	RequestedAt string `json:"requestedAt"`
+}
+
+// witnessedTermsHash returns the terms hash of the agreement Platform witnessed on common-channel
+// It rejects agreements whose parties accepted a different hash than the one witnessed
+func witnessedTermsHash(ctx contractapi.TransactionContextInterface, agreementID string) (string, error) {
+	response := ctx.GetStub().InvokeChaincode("platformorg", [][]byte{[]byte("GetAgreement"), []byte(agreementID)}, "common-channel")
+	if response.Status != 200 {
+		return "", fmt.Errorf("cross-channel query to PlatformOrg failed: %s", response.Message)
+	}
+
+	var agreement struct {
+		PlatformWitnessed bool   `json:"platformWitnessed"`
+		TermsHash         string `json:"termsHash"`
+		InvestorTermsHash string `json:"investorTermsHash"`
+		StartupTermsHash  string `json:"startupTermsHash"`
+	}
+	if err := json.Unmarshal(response.Payload, &agreement); err != nil {
+		return "", fmt.Errorf("failed to parse GetAgreement response: %v", err)
+	}
+	if !agreement.PlatformWitnessed {
+		return "", fmt.Errorf("agreement %s has not been witnessed by Platform", agreementID)
+	}
+	if agreement.InvestorTermsHash != agreement.TermsHash || agreement.StartupTermsHash != agreement.TermsHash {
+		return "", fmt.Errorf("agreement terms hash %s does not match the accepted hashes: investor %s, startup %s", agreement.TermsHash, agreement.InvestorTermsHash, agreement.StartupTermsHash)
+	}
+
+	return agreement.TermsHash, nil
+}
+
+type invokedRecord struct {
+	Key    string          `json:"Key"`
+	Record json.RawMessage `json:"Record"`
+}
+
+// invokeQueryRecords calls a Key/Record list query on another chaincode
+func invokeQueryRecords(ctx contractapi.TransactionContextInterface, chaincode string, channel string, function string, arg string) ([]invokedRecord, error) {
+	response := ctx.GetStub().InvokeChaincode(chaincode, [][]byte{[]byte(function), []byte(arg)}, channel)
+	if response.Status != 200 {
+		return nil, fmt.Errorf("cross-channel query to %s failed: %s", chaincode, response.Message)
+	}
+
+	var records []invokedRecord
+	if err := json.Unmarshal(response.Payload, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse %s response: %v", function, err)
+	}
+
+	return records, nil
+}
+
+// getValidationByCampaign returns the latest validation record for a campaign, or nil
+func getValidationByCampaign(ctx contractapi.TransactionContextInterface, campaignID string) (*ValidationRecord, error) {
+	validationJSON, err := ctx.GetStub().GetState(fmt.Sprintf("CAMPAIGN_VAL_%s", campaignID))
+	if err != nil || validationJSON == nil {
+		return nil, err
+	}
+
+	var validation ValidationRecord
+	if err := json.Unmarshal(validationJSON, &validation); err != nil {
+		return nil, err
+	}
+
+	return &validation, nil
+}
+
+// assignedValidator returns the explicit assignment for an item, or the fallback validator
+func assignedValidator(ctx contractapi.TransactionContextInterface, itemType string, itemID string, fallback string) string {
+	assignmentJSON, _ := ctx.GetStub().GetState(fmt.Sprintf("ASSIGNMENT_%s_%s", itemType, itemID))
+	if assignmentJSON != nil {
+		var assignment ValidatorAssignment
+		if json.Unmarshal(assignmentJSON, &assignment) == nil && assignment.ValidatorID != "" {
+			return assignment.ValidatorID
+		}
+	}
+	return fallback
+}
+
+// isBefore reports whether RFC3339 timestamp a is before b (an empty a is always before)
+func isBefore(a string, b string) bool {
+	at, err := time.Parse(time.RFC3339, a)
+	if err != nil {
+		return true
+	}
+	bt, err := time.Parse(time.RFC3339, b)
+	if err != nil {
+		return false
+	}
+	return at.Before(bt)
+}
+
+// buildWorkQueue sets item ages from the transaction time and sorts oldest first
+func buildWorkQueue(ctx contractapi.TransactionContextInterface, items []WorkQueueItem) (string, error) {
+	txTime, err := getTxTime(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for idx := range items {
+		if queuedAt, err := time.Parse(time.RFC3339, items[idx].QueuedAt); err == nil {
+			items[idx].AgeHours = txTime.Sub(queuedAt).Hours()
+		}
+	}
+
+	sort.SliceStable(items, func(a, b int) bool {
+		return items[a].AgeHours > items[b].AgeHours
+	})
+
+	if items == nil {
+		items = []WorkQueueItem{}
+	}
+	queueJSON, err := json.Marshal(items)
+	if err != nil {
+		return "", err
+	}
+
+	return string(queueJSON), nil
 }
 
 func main() {